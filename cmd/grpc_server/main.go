@@ -6,15 +6,19 @@ import (
 	"context"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/dzakaammar/event-scheduling-example/cmd/pkg"
 	"github.com/dzakaammar/event-scheduling-example/internal"
 	"github.com/dzakaammar/event-scheduling-example/internal/app"
+	"github.com/dzakaammar/event-scheduling-example/internal/auth"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/notification"
 	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
 	"github.com/dzakaammar/event-scheduling-example/internal/scheduling"
+	"github.com/dzakaammar/event-scheduling-example/internal/user"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 	"go.opentelemetry.io/otel"
@@ -56,13 +60,70 @@ func run() error {
 		repo = postgresql.NewInstrumentation(repo)
 	}
 
+	var availabilityRepo core.AvailabilityRepository
+	{
+		availabilityRepo = postgresql.NewAvailabilityRepository(dbConn)
+		availabilityRepo = postgresql.NewAvailabilityInstrumentation(availabilityRepo)
+	}
+
+	var resourceRepo core.ResourceRepository
+	{
+		resourceRepo = postgresql.NewResourceRepository(dbConn)
+		resourceRepo = postgresql.NewResourceInstrumentation(resourceRepo)
+	}
+
+	var holidayRepo core.HolidayCalendarRepository
+	{
+		holidayRepo = postgresql.NewHolidayCalendarRepository(dbConn)
+		holidayRepo = postgresql.NewHolidayCalendarInstrumentation(holidayRepo)
+	}
+
+	var invitationRepo core.InvitationRepository
+	{
+		invitationRepo = postgresql.NewInvitationRepository(dbConn)
+		invitationRepo = postgresql.NewInvitationInstrumentation(invitationRepo)
+	}
+
+	var userRepo core.UserRepository
+	{
+		userRepo = postgresql.NewUserRepository(dbConn)
+		userRepo = postgresql.NewUserInstrumentation(userRepo)
+	}
+
+	var groupRepo core.GroupRepository
+	{
+		groupRepo = postgresql.NewGroupRepository(dbConn)
+		groupRepo = postgresql.NewGroupInstrumentation(groupRepo)
+	}
+
+	tokenSigner, err := core.NewInvitationTokenSigner(cfg.InvitationToken)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var notifier core.Notifier = notification.Nop{}
+	if cfg.NotificationWebhookURL != "" {
+		notifier = notification.NewWebhook(cfg.NotificationWebhookURL, &http.Client{Timeout: 10 * time.Second})
+	}
+
 	var svc core.SchedulingService
 	{
-		svc = scheduling.NewService(repo)
+		svc = scheduling.NewService(repo, availabilityRepo, resourceRepo, holidayRepo, invitationRepo, userRepo, groupRepo, tokenSigner, notifier)
 		svc = scheduling.NewInstrumentation(svc)
 	}
 
-	grpcServer := app.NewGRPCServer(svc)
+	var userSvc core.UserService
+	{
+		userSvc = user.NewService(userRepo)
+		userSvc = user.NewInstrumentation(userSvc)
+	}
+
+	verifier, err := auth.NewVerifier(cfg.Auth)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	grpcServer := app.NewGRPCServer(svc, userSvc, verifier)
 	waitForSignal := pkg.GracefulShutdown(func() error {
 		return grpcServer.Start(cfg.GRPCAddress)
 	})
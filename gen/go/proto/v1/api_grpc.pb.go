@@ -20,24 +20,81 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	API_CreateEvent_FullMethodName     = "/proto.v1.API/CreateEvent"
-	API_UpdateEvent_FullMethodName     = "/proto.v1.API/UpdateEvent"
-	API_DeleteEventByID_FullMethodName = "/proto.v1.API/DeleteEventByID"
-	API_FindEventByID_FullMethodName   = "/proto.v1.API/FindEventByID"
-	API_Check_FullMethodName           = "/proto.v1.API/Check"
-	API_Watch_FullMethodName           = "/proto.v1.API/Watch"
+	API_CreateEvent_FullMethodName               = "/proto.v1.API/CreateEvent"
+	API_UpdateEvent_FullMethodName               = "/proto.v1.API/UpdateEvent"
+	API_DeleteEventByID_FullMethodName           = "/proto.v1.API/DeleteEventByID"
+	API_FindEventByID_FullMethodName             = "/proto.v1.API/FindEventByID"
+	API_ListOccurrences_FullMethodName           = "/proto.v1.API/ListOccurrences"
+	API_CancelOccurrence_FullMethodName          = "/proto.v1.API/CancelOccurrence"
+	API_UpdateOccurrence_FullMethodName          = "/proto.v1.API/UpdateOccurrence"
+	API_QueryFreeBusy_FullMethodName             = "/proto.v1.API/QueryFreeBusy"
+	API_SuggestMeetingTimes_FullMethodName       = "/proto.v1.API/SuggestMeetingTimes"
+	API_GetAvailability_FullMethodName           = "/proto.v1.API/GetAvailability"
+	API_UpdateAvailability_FullMethodName        = "/proto.v1.API/UpdateAvailability"
+	API_CreateResource_FullMethodName            = "/proto.v1.API/CreateResource"
+	API_GetResource_FullMethodName               = "/proto.v1.API/GetResource"
+	API_ListResources_FullMethodName             = "/proto.v1.API/ListResources"
+	API_CreateHolidayCalendar_FullMethodName     = "/proto.v1.API/CreateHolidayCalendar"
+	API_GetHolidayCalendar_FullMethodName        = "/proto.v1.API/GetHolidayCalendar"
+	API_RespondToInvitation_FullMethodName       = "/proto.v1.API/RespondToInvitation"
+	API_RegenerateInvitationToken_FullMethodName = "/proto.v1.API/RegenerateInvitationToken"
+	API_ListProposals_FullMethodName             = "/proto.v1.API/ListProposals"
+	API_AcceptProposal_FullMethodName            = "/proto.v1.API/AcceptProposal"
+	API_ListMyInvitations_FullMethodName         = "/proto.v1.API/ListMyInvitations"
+	API_CreateUser_FullMethodName                = "/proto.v1.API/CreateUser"
+	API_GetUser_FullMethodName                   = "/proto.v1.API/GetUser"
+	API_ListUsers_FullMethodName                 = "/proto.v1.API/ListUsers"
+	API_DeactivateUser_FullMethodName            = "/proto.v1.API/DeactivateUser"
+	API_CreateGroup_FullMethodName               = "/proto.v1.API/CreateGroup"
+	API_GetGroup_FullMethodName                  = "/proto.v1.API/GetGroup"
+	API_UpdateGroupMembers_FullMethodName        = "/proto.v1.API/UpdateGroupMembers"
+	API_Check_FullMethodName                     = "/proto.v1.API/Check"
+	API_Watch_FullMethodName                     = "/proto.v1.API/Watch"
 )
 
 // APIClient is the client API for API service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-//
-// API
 type APIClient interface {
 	CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*CreateEventResponse, error)
-	UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*UpdateEventResponse, error)
 	DeleteEventByID(ctx context.Context, in *DeleteEventByIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	FindEventByID(ctx context.Context, in *FindEventByIDRequest, opts ...grpc.CallOption) (*FindEventByIDResponse, error)
+	ListOccurrences(ctx context.Context, in *ListOccurrencesRequest, opts ...grpc.CallOption) (*ListOccurrencesResponse, error)
+	CancelOccurrence(ctx context.Context, in *CancelOccurrenceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateOccurrence(ctx context.Context, in *UpdateOccurrenceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	QueryFreeBusy(ctx context.Context, in *QueryFreeBusyRequest, opts ...grpc.CallOption) (*QueryFreeBusyResponse, error)
+	SuggestMeetingTimes(ctx context.Context, in *SuggestMeetingTimesRequest, opts ...grpc.CallOption) (*SuggestMeetingTimesResponse, error)
+	GetAvailability(ctx context.Context, in *GetAvailabilityRequest, opts ...grpc.CallOption) (*GetAvailabilityResponse, error)
+	UpdateAvailability(ctx context.Context, in *UpdateAvailabilityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*CreateResourceResponse, error)
+	GetResource(ctx context.Context, in *GetResourceRequest, opts ...grpc.CallOption) (*GetResourceResponse, error)
+	ListResources(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListResourcesResponse, error)
+	CreateHolidayCalendar(ctx context.Context, in *CreateHolidayCalendarRequest, opts ...grpc.CallOption) (*CreateHolidayCalendarResponse, error)
+	GetHolidayCalendar(ctx context.Context, in *GetHolidayCalendarRequest, opts ...grpc.CallOption) (*GetHolidayCalendarResponse, error)
+	// RespondToInvitation doesn't require the attendee to be authenticated, the token of the
+	// invitation is enough
+	RespondToInvitation(ctx context.Context, in *RespondToInvitationRequest, opts ...grpc.CallOption) (*RespondToInvitationResponse, error)
+	RegenerateInvitationToken(ctx context.Context, in *RegenerateInvitationTokenRequest, opts ...grpc.CallOption) (*RegenerateInvitationTokenResponse, error)
+	// ListProposals lists the new times proposed by the attendees, to the organizer of the event
+	ListProposals(ctx context.Context, in *ListProposalsRequest, opts ...grpc.CallOption) (*ListProposalsResponse, error)
+	// AcceptProposal moves the schedule to the proposed time and resets the responses of the other
+	// attendees, apart from the organizers and the waitlisted ones
+	AcceptProposal(ctx context.Context, in *AcceptProposalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListMyInvitations lists the invitations of the user, whether pending or responded
+	ListMyInvitations(ctx context.Context, in *ListMyInvitationsRequest, opts ...grpc.CallOption) (*ListMyInvitationsResponse, error)
+	// CreateUser adds a user to the directory, any authenticated caller can
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	// DeactivateUser keeps the user on the events already attended, but the user can't be invited
+	// anymore. Users can only deactivate themselves
+	DeactivateUser(ctx context.Context, in *DeactivateUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error)
+	// UpdateGroupMembers replaces the members of the group, the events inviting the group with sync
+	// set follow the change
+	UpdateGroupMembers(ctx context.Context, in *UpdateGroupMembersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Check(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 	Watch(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HealthCheckResponse], error)
 }
@@ -60,9 +117,9 @@ func (c *aPIClient) CreateEvent(ctx context.Context, in *CreateEventRequest, opt
 	return out, nil
 }
 
-func (c *aPIClient) UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *aPIClient) UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*UpdateEventResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(UpdateEventResponse)
 	err := c.cc.Invoke(ctx, API_UpdateEvent_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -90,6 +147,246 @@ func (c *aPIClient) FindEventByID(ctx context.Context, in *FindEventByIDRequest,
 	return out, nil
 }
 
+func (c *aPIClient) ListOccurrences(ctx context.Context, in *ListOccurrencesRequest, opts ...grpc.CallOption) (*ListOccurrencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOccurrencesResponse)
+	err := c.cc.Invoke(ctx, API_ListOccurrences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CancelOccurrence(ctx context.Context, in *CancelOccurrenceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_CancelOccurrence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateOccurrence(ctx context.Context, in *UpdateOccurrenceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UpdateOccurrence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) QueryFreeBusy(ctx context.Context, in *QueryFreeBusyRequest, opts ...grpc.CallOption) (*QueryFreeBusyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryFreeBusyResponse)
+	err := c.cc.Invoke(ctx, API_QueryFreeBusy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SuggestMeetingTimes(ctx context.Context, in *SuggestMeetingTimesRequest, opts ...grpc.CallOption) (*SuggestMeetingTimesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestMeetingTimesResponse)
+	err := c.cc.Invoke(ctx, API_SuggestMeetingTimes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetAvailability(ctx context.Context, in *GetAvailabilityRequest, opts ...grpc.CallOption) (*GetAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAvailabilityResponse)
+	err := c.cc.Invoke(ctx, API_GetAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateAvailability(ctx context.Context, in *UpdateAvailabilityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UpdateAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*CreateResourceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateResourceResponse)
+	err := c.cc.Invoke(ctx, API_CreateResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetResource(ctx context.Context, in *GetResourceRequest, opts ...grpc.CallOption) (*GetResourceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResourceResponse)
+	err := c.cc.Invoke(ctx, API_GetResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListResources(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResourcesResponse)
+	err := c.cc.Invoke(ctx, API_ListResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateHolidayCalendar(ctx context.Context, in *CreateHolidayCalendarRequest, opts ...grpc.CallOption) (*CreateHolidayCalendarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateHolidayCalendarResponse)
+	err := c.cc.Invoke(ctx, API_CreateHolidayCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetHolidayCalendar(ctx context.Context, in *GetHolidayCalendarRequest, opts ...grpc.CallOption) (*GetHolidayCalendarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHolidayCalendarResponse)
+	err := c.cc.Invoke(ctx, API_GetHolidayCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RespondToInvitation(ctx context.Context, in *RespondToInvitationRequest, opts ...grpc.CallOption) (*RespondToInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespondToInvitationResponse)
+	err := c.cc.Invoke(ctx, API_RespondToInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RegenerateInvitationToken(ctx context.Context, in *RegenerateInvitationTokenRequest, opts ...grpc.CallOption) (*RegenerateInvitationTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegenerateInvitationTokenResponse)
+	err := c.cc.Invoke(ctx, API_RegenerateInvitationToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListProposals(ctx context.Context, in *ListProposalsRequest, opts ...grpc.CallOption) (*ListProposalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProposalsResponse)
+	err := c.cc.Invoke(ctx, API_ListProposals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AcceptProposal(ctx context.Context, in *AcceptProposalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_AcceptProposal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListMyInvitations(ctx context.Context, in *ListMyInvitationsRequest, opts ...grpc.CallOption) (*ListMyInvitationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyInvitationsResponse)
+	err := c.cc.Invoke(ctx, API_ListMyInvitations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateUserResponse)
+	err := c.cc.Invoke(ctx, API_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserResponse)
+	err := c.cc.Invoke(ctx, API_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, API_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeactivateUser(ctx context.Context, in *DeactivateUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_DeactivateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateGroupResponse)
+	err := c.cc.Invoke(ctx, API_CreateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGroupResponse)
+	err := c.cc.Invoke(ctx, API_GetGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateGroupMembers(ctx context.Context, in *UpdateGroupMembersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UpdateGroupMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) Check(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HealthCheckResponse)
@@ -122,13 +419,46 @@ type API_WatchClient = grpc.ServerStreamingClient[HealthCheckResponse]
 // APIServer is the server API for API service.
 // All implementations must embed UnimplementedAPIServer
 // for forward compatibility.
-//
-// API
 type APIServer interface {
 	CreateEvent(context.Context, *CreateEventRequest) (*CreateEventResponse, error)
-	UpdateEvent(context.Context, *UpdateEventRequest) (*emptypb.Empty, error)
+	UpdateEvent(context.Context, *UpdateEventRequest) (*UpdateEventResponse, error)
 	DeleteEventByID(context.Context, *DeleteEventByIDRequest) (*emptypb.Empty, error)
 	FindEventByID(context.Context, *FindEventByIDRequest) (*FindEventByIDResponse, error)
+	ListOccurrences(context.Context, *ListOccurrencesRequest) (*ListOccurrencesResponse, error)
+	CancelOccurrence(context.Context, *CancelOccurrenceRequest) (*emptypb.Empty, error)
+	UpdateOccurrence(context.Context, *UpdateOccurrenceRequest) (*emptypb.Empty, error)
+	QueryFreeBusy(context.Context, *QueryFreeBusyRequest) (*QueryFreeBusyResponse, error)
+	SuggestMeetingTimes(context.Context, *SuggestMeetingTimesRequest) (*SuggestMeetingTimesResponse, error)
+	GetAvailability(context.Context, *GetAvailabilityRequest) (*GetAvailabilityResponse, error)
+	UpdateAvailability(context.Context, *UpdateAvailabilityRequest) (*emptypb.Empty, error)
+	CreateResource(context.Context, *CreateResourceRequest) (*CreateResourceResponse, error)
+	GetResource(context.Context, *GetResourceRequest) (*GetResourceResponse, error)
+	ListResources(context.Context, *emptypb.Empty) (*ListResourcesResponse, error)
+	CreateHolidayCalendar(context.Context, *CreateHolidayCalendarRequest) (*CreateHolidayCalendarResponse, error)
+	GetHolidayCalendar(context.Context, *GetHolidayCalendarRequest) (*GetHolidayCalendarResponse, error)
+	// RespondToInvitation doesn't require the attendee to be authenticated, the token of the
+	// invitation is enough
+	RespondToInvitation(context.Context, *RespondToInvitationRequest) (*RespondToInvitationResponse, error)
+	RegenerateInvitationToken(context.Context, *RegenerateInvitationTokenRequest) (*RegenerateInvitationTokenResponse, error)
+	// ListProposals lists the new times proposed by the attendees, to the organizer of the event
+	ListProposals(context.Context, *ListProposalsRequest) (*ListProposalsResponse, error)
+	// AcceptProposal moves the schedule to the proposed time and resets the responses of the other
+	// attendees, apart from the organizers and the waitlisted ones
+	AcceptProposal(context.Context, *AcceptProposalRequest) (*emptypb.Empty, error)
+	// ListMyInvitations lists the invitations of the user, whether pending or responded
+	ListMyInvitations(context.Context, *ListMyInvitationsRequest) (*ListMyInvitationsResponse, error)
+	// CreateUser adds a user to the directory, any authenticated caller can
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	// DeactivateUser keeps the user on the events already attended, but the user can't be invited
+	// anymore. Users can only deactivate themselves
+	DeactivateUser(context.Context, *DeactivateUserRequest) (*emptypb.Empty, error)
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error)
+	// UpdateGroupMembers replaces the members of the group, the events inviting the group with sync
+	// set follow the change
+	UpdateGroupMembers(context.Context, *UpdateGroupMembersRequest) (*emptypb.Empty, error)
 	Check(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	Watch(*HealthCheckRequest, grpc.ServerStreamingServer[HealthCheckResponse]) error
 	mustEmbedUnimplementedAPIServer()
@@ -144,7 +474,7 @@ type UnimplementedAPIServer struct{}
 func (UnimplementedAPIServer) CreateEvent(context.Context, *CreateEventRequest) (*CreateEventResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateEvent not implemented")
 }
-func (UnimplementedAPIServer) UpdateEvent(context.Context, *UpdateEventRequest) (*emptypb.Empty, error) {
+func (UnimplementedAPIServer) UpdateEvent(context.Context, *UpdateEventRequest) (*UpdateEventResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateEvent not implemented")
 }
 func (UnimplementedAPIServer) DeleteEventByID(context.Context, *DeleteEventByIDRequest) (*emptypb.Empty, error) {
@@ -153,6 +483,78 @@ func (UnimplementedAPIServer) DeleteEventByID(context.Context, *DeleteEventByIDR
 func (UnimplementedAPIServer) FindEventByID(context.Context, *FindEventByIDRequest) (*FindEventByIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindEventByID not implemented")
 }
+func (UnimplementedAPIServer) ListOccurrences(context.Context, *ListOccurrencesRequest) (*ListOccurrencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOccurrences not implemented")
+}
+func (UnimplementedAPIServer) CancelOccurrence(context.Context, *CancelOccurrenceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOccurrence not implemented")
+}
+func (UnimplementedAPIServer) UpdateOccurrence(context.Context, *UpdateOccurrenceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOccurrence not implemented")
+}
+func (UnimplementedAPIServer) QueryFreeBusy(context.Context, *QueryFreeBusyRequest) (*QueryFreeBusyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryFreeBusy not implemented")
+}
+func (UnimplementedAPIServer) SuggestMeetingTimes(context.Context, *SuggestMeetingTimesRequest) (*SuggestMeetingTimesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestMeetingTimes not implemented")
+}
+func (UnimplementedAPIServer) GetAvailability(context.Context, *GetAvailabilityRequest) (*GetAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailability not implemented")
+}
+func (UnimplementedAPIServer) UpdateAvailability(context.Context, *UpdateAvailabilityRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAvailability not implemented")
+}
+func (UnimplementedAPIServer) CreateResource(context.Context, *CreateResourceRequest) (*CreateResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateResource not implemented")
+}
+func (UnimplementedAPIServer) GetResource(context.Context, *GetResourceRequest) (*GetResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResource not implemented")
+}
+func (UnimplementedAPIServer) ListResources(context.Context, *emptypb.Empty) (*ListResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListResources not implemented")
+}
+func (UnimplementedAPIServer) CreateHolidayCalendar(context.Context, *CreateHolidayCalendarRequest) (*CreateHolidayCalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHolidayCalendar not implemented")
+}
+func (UnimplementedAPIServer) GetHolidayCalendar(context.Context, *GetHolidayCalendarRequest) (*GetHolidayCalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHolidayCalendar not implemented")
+}
+func (UnimplementedAPIServer) RespondToInvitation(context.Context, *RespondToInvitationRequest) (*RespondToInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondToInvitation not implemented")
+}
+func (UnimplementedAPIServer) RegenerateInvitationToken(context.Context, *RegenerateInvitationTokenRequest) (*RegenerateInvitationTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegenerateInvitationToken not implemented")
+}
+func (UnimplementedAPIServer) ListProposals(context.Context, *ListProposalsRequest) (*ListProposalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProposals not implemented")
+}
+func (UnimplementedAPIServer) AcceptProposal(context.Context, *AcceptProposalRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptProposal not implemented")
+}
+func (UnimplementedAPIServer) ListMyInvitations(context.Context, *ListMyInvitationsRequest) (*ListMyInvitationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyInvitations not implemented")
+}
+func (UnimplementedAPIServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedAPIServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedAPIServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedAPIServer) DeactivateUser(context.Context, *DeactivateUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateUser not implemented")
+}
+func (UnimplementedAPIServer) CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedAPIServer) GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedAPIServer) UpdateGroupMembers(context.Context, *UpdateGroupMembersRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGroupMembers not implemented")
+}
 func (UnimplementedAPIServer) Check(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
 }
@@ -252,58 +654,586 @@ func _API_FindEventByID_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
-func _API_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HealthCheckRequest)
+func _API_ListOccurrences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOccurrencesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServer).Check(ctx, in)
+		return srv.(APIServer).ListOccurrences(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: API_Check_FullMethodName,
+		FullMethod: API_ListOccurrences_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).Check(ctx, req.(*HealthCheckRequest))
+		return srv.(APIServer).ListOccurrences(ctx, req.(*ListOccurrencesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _API_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(HealthCheckRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _API_CancelOccurrence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOccurrenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(APIServer).Watch(m, &grpc.GenericServerStream[HealthCheckRequest, HealthCheckResponse]{ServerStream: stream})
+	if interceptor == nil {
+		return srv.(APIServer).CancelOccurrence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CancelOccurrence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CancelOccurrence(ctx, req.(*CancelOccurrenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type API_WatchServer = grpc.ServerStreamingServer[HealthCheckResponse]
+func _API_UpdateOccurrence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOccurrenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateOccurrence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UpdateOccurrence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateOccurrence(ctx, req.(*UpdateOccurrenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// API_ServiceDesc is the grpc.ServiceDesc for API service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var API_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "proto.v1.API",
-	HandlerType: (*APIServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateEvent",
-			Handler:    _API_CreateEvent_Handler,
-		},
-		{
-			MethodName: "UpdateEvent",
-			Handler:    _API_UpdateEvent_Handler,
-		},
-		{
-			MethodName: "DeleteEventByID",
-			Handler:    _API_DeleteEventByID_Handler,
-		},
-		{
-			MethodName: "FindEventByID",
+func _API_QueryFreeBusy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryFreeBusyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).QueryFreeBusy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_QueryFreeBusy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).QueryFreeBusy(ctx, req.(*QueryFreeBusyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SuggestMeetingTimes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestMeetingTimesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SuggestMeetingTimes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_SuggestMeetingTimes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SuggestMeetingTimes(ctx, req.(*SuggestMeetingTimesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetAvailability(ctx, req.(*GetAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UpdateAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateAvailability(ctx, req.(*UpdateAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CreateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateResource(ctx, req.(*CreateResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetResource(ctx, req.(*GetResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListResources(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateHolidayCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHolidayCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateHolidayCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CreateHolidayCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateHolidayCalendar(ctx, req.(*CreateHolidayCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetHolidayCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHolidayCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetHolidayCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetHolidayCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetHolidayCalendar(ctx, req.(*GetHolidayCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RespondToInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondToInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RespondToInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_RespondToInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RespondToInvitation(ctx, req.(*RespondToInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RegenerateInvitationToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateInvitationTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RegenerateInvitationToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_RegenerateInvitationToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RegenerateInvitationToken(ctx, req.(*RegenerateInvitationTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListProposals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProposalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListProposals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListProposals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListProposals(ctx, req.(*ListProposalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_AcceptProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AcceptProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_AcceptProposal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AcceptProposal(ctx, req.(*AcceptProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListMyInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyInvitationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListMyInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListMyInvitations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListMyInvitations(ctx, req.(*ListMyInvitationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeactivateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeactivateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_DeactivateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeactivateUser(ctx, req.(*DeactivateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CreateGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetGroup(ctx, req.(*GetGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateGroupMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UpdateGroupMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateGroupMembers(ctx, req.(*UpdateGroupMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Check(ctx, req.(*HealthCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HealthCheckRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).Watch(m, &grpc.GenericServerStream[HealthCheckRequest, HealthCheckResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type API_WatchServer = grpc.ServerStreamingServer[HealthCheckResponse]
+
+// API_ServiceDesc is the grpc.ServiceDesc for API service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var API_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.v1.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateEvent",
+			Handler:    _API_CreateEvent_Handler,
+		},
+		{
+			MethodName: "UpdateEvent",
+			Handler:    _API_UpdateEvent_Handler,
+		},
+		{
+			MethodName: "DeleteEventByID",
+			Handler:    _API_DeleteEventByID_Handler,
+		},
+		{
+			MethodName: "FindEventByID",
 			Handler:    _API_FindEventByID_Handler,
 		},
+		{
+			MethodName: "ListOccurrences",
+			Handler:    _API_ListOccurrences_Handler,
+		},
+		{
+			MethodName: "CancelOccurrence",
+			Handler:    _API_CancelOccurrence_Handler,
+		},
+		{
+			MethodName: "UpdateOccurrence",
+			Handler:    _API_UpdateOccurrence_Handler,
+		},
+		{
+			MethodName: "QueryFreeBusy",
+			Handler:    _API_QueryFreeBusy_Handler,
+		},
+		{
+			MethodName: "SuggestMeetingTimes",
+			Handler:    _API_SuggestMeetingTimes_Handler,
+		},
+		{
+			MethodName: "GetAvailability",
+			Handler:    _API_GetAvailability_Handler,
+		},
+		{
+			MethodName: "UpdateAvailability",
+			Handler:    _API_UpdateAvailability_Handler,
+		},
+		{
+			MethodName: "CreateResource",
+			Handler:    _API_CreateResource_Handler,
+		},
+		{
+			MethodName: "GetResource",
+			Handler:    _API_GetResource_Handler,
+		},
+		{
+			MethodName: "ListResources",
+			Handler:    _API_ListResources_Handler,
+		},
+		{
+			MethodName: "CreateHolidayCalendar",
+			Handler:    _API_CreateHolidayCalendar_Handler,
+		},
+		{
+			MethodName: "GetHolidayCalendar",
+			Handler:    _API_GetHolidayCalendar_Handler,
+		},
+		{
+			MethodName: "RespondToInvitation",
+			Handler:    _API_RespondToInvitation_Handler,
+		},
+		{
+			MethodName: "RegenerateInvitationToken",
+			Handler:    _API_RegenerateInvitationToken_Handler,
+		},
+		{
+			MethodName: "ListProposals",
+			Handler:    _API_ListProposals_Handler,
+		},
+		{
+			MethodName: "AcceptProposal",
+			Handler:    _API_AcceptProposal_Handler,
+		},
+		{
+			MethodName: "ListMyInvitations",
+			Handler:    _API_ListMyInvitations_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _API_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _API_GetUser_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _API_ListUsers_Handler,
+		},
+		{
+			MethodName: "DeactivateUser",
+			Handler:    _API_DeactivateUser_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _API_CreateGroup_Handler,
+		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _API_GetGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroupMembers",
+			Handler:    _API_UpdateGroupMembers_Handler,
+		},
 		{
 			MethodName: "Check",
 			Handler:    _API_Check_Handler,
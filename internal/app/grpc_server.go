@@ -6,6 +6,7 @@ import (
 	"net"
 
 	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal/auth"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"github.com/dzakaammar/event-scheduling-example/internal/endpoint"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -15,17 +16,29 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
+// publicMethods are callable without a bearer token: the health checks, and the responses to the
+// invitations, authenticated by the token of the invitation.
+var publicMethods = []string{
+	v1.API_Check_FullMethodName,
+	v1.API_Watch_FullMethodName,
+	v1.API_RespondToInvitation_FullMethodName,
+}
+
 type GRPCServer struct {
 	srv *grpc.Server
 }
 
-func NewGRPCServer(schedulingSvc core.SchedulingService) *GRPCServer {
-	endpoint := endpoint.NewGRPCEndpoint(schedulingSvc)
+func NewGRPCServer(schedulingSvc core.SchedulingService, userSvc core.UserService, verifier *auth.Verifier) *GRPCServer {
+	endpoint := endpoint.NewGRPCEndpoint(schedulingSvc, userSvc)
 
 	srv := grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc_middleware.WithUnaryServerChain(
 			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			auth.UnaryServerInterceptor(verifier, publicMethods...),
+		),
+		grpc_middleware.WithStreamServerChain(
+			auth.StreamServerInterceptor(verifier, publicMethods...),
 		),
 	)
 	v1.RegisterAPIServer(srv, endpoint)
@@ -0,0 +1,125 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	keys := newTestKeys(t)
+	v, err := auth.NewVerifier(internal.AuthConfig{JWKS: keys.jwks()})
+	require.NoError(t, err)
+
+	claims := validClaims()
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+	token := keys.sign(t, "RS256", "rsa", claims)
+
+	interceptor := auth.UnaryServerInterceptor(v, "/proto.v1.API/Check")
+
+	tests := []struct {
+		name        string
+		method      string
+		auth        string
+		wantCode    codes.Code
+		wantSubject string
+	}{
+		{
+			name:        "OK",
+			method:      "/proto.v1.API/CreateEvent",
+			auth:        "Bearer " + token,
+			wantSubject: "1",
+		},
+		{
+			name:   "public method",
+			method: "/proto.v1.API/Check",
+		},
+		{
+			name:     "missing token",
+			method:   "/proto.v1.API/CreateEvent",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "not a bearer token",
+			method:   "/proto.v1.API/CreateEvent",
+			auth:     "1",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid token",
+			method:   "/proto.v1.API/CreateEvent",
+			auth:     "Bearer " + tamper(token),
+			wantCode: codes.Unauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.auth != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.auth))
+			}
+
+			var subject string
+			handler := func(ctx context.Context, _ any) (any, error) {
+				p, _ := auth.FromContext(ctx)
+				subject = p.Subject
+				return "ok", nil
+			}
+
+			res, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				assert.Nil(t, res)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ok", res)
+			assert.Equal(t, tt.wantSubject, subject)
+		})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	keys := newTestKeys(t)
+	v, err := auth.NewVerifier(internal.AuthConfig{JWKS: keys.jwks()})
+	require.NoError(t, err)
+
+	claims := validClaims()
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+keys.sign(t, "ES256", "ec", claims)))
+
+	interceptor := auth.StreamServerInterceptor(v)
+	info := &grpc.StreamServerInfo{FullMethod: "/proto.v1.API/Watch"}
+
+	var subject string
+	err = interceptor(nil, &serverStream{ctx: ctx}, info, func(_ any, ss grpc.ServerStream) error {
+		p, _ := auth.FromContext(ss.Context())
+		subject = p.Subject
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1", subject)
+
+	err = interceptor(nil, &serverStream{ctx: context.Background()}, info, func(any, grpc.ServerStream) error {
+		return nil
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
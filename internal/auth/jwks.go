@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// minRSAKeyBits is the minimum size of the RSA keys verifying the RS256 tokens.
+	minRSAKeyBits = 2048
+	// minHMACKeyLength is the minimum length of the secrets verifying the HS256 tokens, the size of
+	// an HMAC-SHA256 output.
+	minHMACKeyLength = 32
+)
+
+// Key is a key of a JWKS, verifying the tokens signed with its algorithm. Its public key is an
+// *rsa.PublicKey for RS256, an *ecdsa.PublicKey for ES256, and the secret as []byte for HS256.
+type Key struct {
+	ID        string
+	Algorithm string
+	PublicKey any
+}
+
+// KeySet is the keys verifying the tokens, by ID.
+type KeySet map[string]Key
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// N and E are the modulus and the exponent of an RSA key.
+	N string `json:"n"`
+	E string `json:"e"`
+	// Crv, X and Y are the curve and the coordinates of an EC key.
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// K is the secret of a symmetric key.
+	K string `json:"k"`
+}
+
+// ParseJWKS parses a JSON Web Key Set holding RSA, P-256 EC or symmetric keys. The keys not meant
+// for signatures are skipped.
+func ParseJWKS(data []byte) (KeySet, error) {
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("invalid jwks: %w", err)
+	}
+
+	keys := make(KeySet, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := parseJWK(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwks: key %q: %w", k.Kid, err)
+		}
+		if _, ok := keys[key.ID]; ok {
+			return nil, fmt.Errorf("invalid jwks: duplicated key %q", key.ID)
+		}
+		keys[key.ID] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("invalid jwks: no signing key")
+	}
+	return keys, nil
+}
+
+func parseJWK(k jwk) (Key, error) {
+	key := Key{ID: k.Kid}
+
+	var err error
+	switch k.Kty {
+	case "RSA":
+		key.Algorithm = AlgorithmRS256
+		key.PublicKey, err = parseRSAKey(k)
+	case "EC":
+		key.Algorithm = AlgorithmES256
+		key.PublicKey, err = parseECKey(k)
+	case "oct":
+		key.Algorithm = AlgorithmHS256
+		key.PublicKey, err = parseHMACKey(k)
+	default:
+		return Key{}, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	if k.Alg != "" && k.Alg != key.Algorithm {
+		return Key{}, fmt.Errorf("unsupported algorithm %q for key type %q", k.Alg, k.Kty)
+	}
+	return key, nil
+}
+
+func parseRSAKey(k jwk) (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus")
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 || e.Bit(0) == 0 {
+		return nil, fmt.Errorf("invalid exponent")
+	}
+	if n.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("the RSA key must be at least %d bits long", minRSAKeyBits)
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func parseECKey(k jwk) (*ecdsa.PublicKey, error) {
+	if k.Crv != "P-256" {
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+
+	x, errX := base64.RawURLEncoding.DecodeString(k.X)
+	y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+	if errX != nil || errY != nil || len(x) != 32 || len(y) != 32 {
+		return nil, fmt.Errorf("invalid coordinates")
+	}
+
+	// ecdh rejects the points which aren't on the curve.
+	if _, err := ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+		return nil, fmt.Errorf("invalid coordinates")
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+}
+
+func parseHMACKey(k jwk) ([]byte, error) {
+	secret, err := base64.RawURLEncoding.DecodeString(k.K)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret")
+	}
+	if len(secret) < minHMACKeyLength {
+		return nil, fmt.Errorf("the secret must be at least %d bytes long", minHMACKeyLength)
+	}
+	return secret, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmHS256 = "HS256"
+)
+
+// leeway tolerates the clock skew between the issuer of the tokens and the server.
+const leeway = time.Minute
+
+// Verifier verifies the JWT bearer tokens of the callers against a JWKS.
+type Verifier struct {
+	keys     KeySet
+	issuer   string
+	audience string
+}
+
+// NewVerifier returns a verifier of the tokens signed by the keys of the JWKS of the config, read
+// from its file or given inline.
+func NewVerifier(cfg internal.AuthConfig) (*Verifier, error) {
+	var data []byte
+	switch {
+	case cfg.JWKSFile != "" && cfg.JWKS != "":
+		return nil, fmt.Errorf("invalid auth config: the jwks must be given either as a file or inline, not both")
+	case cfg.JWKSFile != "":
+		var err error
+		data, err = os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth config: %w", err)
+		}
+	case cfg.JWKS != "":
+		data = []byte(cfg.JWKS)
+	default:
+		return nil, fmt.Errorf("invalid auth config: missing jwks")
+	}
+
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth config: %w", err)
+	}
+
+	return &Verifier{
+		keys:     keys,
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+	}, nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *int64   `json:"exp"`
+	NotBefore *int64   `json:"nbf"`
+}
+
+// audience is the aud claim, either a single audience or a list of them.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// Verify checks the token is signed by a key of the JWKS with the algorithm of the key, isn't
+// expired at now, and is issued by the issuer for the audience when they're configured. The token
+// must name its key, unless the JWKS holds a single one. It returns the principal the token is
+// issued to.
+func (v *Verifier) Verify(token string, now time.Time) (Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, "malformed bearer token")
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, "malformed bearer token header")
+	}
+
+	key, ok := v.key(h.Kid)
+	if !ok {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, "bearer token signed by an unknown key")
+	}
+	// The algorithm of the key is the only one accepted, a token can't pick another one, i.e: HS256
+	// with the public key of RS256 as the secret.
+	if h.Alg != key.Algorithm {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, fmt.Sprintf("unexpected bearer token algorithm %q", h.Alg))
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !verifySignature(key, parts[0]+"."+parts[1], signature) {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, "invalid bearer token signature")
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, "malformed bearer token claims")
+	}
+	return v.principal(&c, now)
+}
+
+func (v *Verifier) key(id string) (Key, bool) {
+	if id == "" && len(v.keys) == 1 {
+		for _, k := range v.keys {
+			return k, true
+		}
+	}
+	k, ok := v.keys[id]
+	return k, ok
+}
+
+func (v *Verifier) principal(c *claims, now time.Time) (Principal, error) {
+	if c.ExpiresAt == nil {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, "bearer token without expiry")
+	}
+	expiresAt := time.Unix(*c.ExpiresAt, 0)
+	if !now.Before(expiresAt.Add(leeway)) {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, "expired bearer token")
+	}
+	if c.NotBefore != nil && now.Add(leeway).Before(time.Unix(*c.NotBefore, 0)) {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, "bearer token not valid yet")
+	}
+
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, fmt.Sprintf("bearer token issued by %q", c.Issuer))
+	}
+	if v.audience != "" && !slices.Contains(c.Audience, v.audience) {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, "bearer token issued for another audience")
+	}
+
+	if c.Subject == "" {
+		return Principal{}, internal.WrapErr(internal.ErrInvalidToken, "bearer token without subject")
+	}
+	return Principal{
+		Subject:   c.Subject,
+		Issuer:    c.Issuer,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func verifySignature(key Key, signed string, signature []byte) bool {
+	digest := sha256.Sum256([]byte(signed))
+
+	switch pub := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		// A JWS ES256 signature is r and s as 32 bytes each, not ASN.1.
+		if len(signature) != 64 {
+			return false
+		}
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(pub, digest[:], r, s)
+	case []byte:
+		mac := hmac.New(sha256.New, pub)
+		mac.Write([]byte(signed))
+		return hmac.Equal(signature, mac.Sum(nil))
+	}
+	return false
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,301 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	now        = time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+)
+
+type testKeys struct {
+	rsa   *rsa.PrivateKey
+	ecdsa *ecdsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return testKeys{rsa: rsaKey, ecdsa: ecKey}
+}
+
+func (k testKeys) jwks() string {
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwks := map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA", "kid": "rsa", "alg": "RS256", "use": "sig",
+				"n": b64(k.rsa.N.Bytes()), "e": b64([]byte{1, 0, 1}),
+			},
+			{
+				"kty": "EC", "kid": "ec", "crv": "P-256",
+				"x": b64(k.ecdsa.X.FillBytes(make([]byte, 32))), "y": b64(k.ecdsa.Y.FillBytes(make([]byte, 32))),
+			},
+			{"kty": "oct", "kid": "hmac", "k": b64(hmacSecret)},
+			{"kty": "RSA", "kid": "enc", "use": "enc"},
+		},
+	}
+	data, _ := json.Marshal(jwks)
+	return string(data)
+}
+
+// sign returns a token of the claims signed with the key of kid, using alg.
+func (k testKeys) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch kid {
+	case "rsa":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k.rsa, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case "ec":
+		r, s, err := ecdsa.Sign(rand.Reader, k.ecdsa, digest[:])
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	default:
+		mac := hmac.New(sha256.New, hmacSecret)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub": "1",
+		"iss": "https://id.example.com",
+		"aud": []string{"scheduling", "other"},
+		"exp": now.Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	keys := newTestKeys(t)
+	v, err := auth.NewVerifier(internal.AuthConfig{JWKS: keys.jwks(), Issuer: "https://id.example.com", Audience: "scheduling"})
+	require.NoError(t, err)
+
+	with := func(key string, value any) map[string]any {
+		c := validClaims()
+		if value == nil {
+			delete(c, key)
+			return c
+		}
+		c[key] = value
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    auth.Principal
+		wantErr error
+	}{
+		{
+			name:  "RS256",
+			token: keys.sign(t, "RS256", "rsa", validClaims()),
+			want:  auth.Principal{Subject: "1", Issuer: "https://id.example.com", ExpiresAt: time.Unix(now.Add(time.Hour).Unix(), 0)},
+		},
+		{
+			name:  "ES256",
+			token: keys.sign(t, "ES256", "ec", validClaims()),
+			want:  auth.Principal{Subject: "1", Issuer: "https://id.example.com", ExpiresAt: time.Unix(now.Add(time.Hour).Unix(), 0)},
+		},
+		{
+			name:  "HS256 with a single audience",
+			token: keys.sign(t, "HS256", "hmac", with("aud", "scheduling")),
+			want:  auth.Principal{Subject: "1", Issuer: "https://id.example.com", ExpiresAt: time.Unix(now.Add(time.Hour).Unix(), 0)},
+		},
+		{
+			name:    "malformed",
+			token:   "not.a-token",
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:    "unknown key",
+			token:   keys.sign(t, "HS256", "unknown", validClaims()),
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:    "key not meant for signatures",
+			token:   keys.sign(t, "RS256", "enc", validClaims()),
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:    "algorithm of another key",
+			token:   keys.sign(t, "HS256", "rsa", validClaims()),
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:    "none algorithm",
+			token:   keys.sign(t, "none", "hmac", validClaims()),
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:    "tampered claims",
+			token:   tamper(keys.sign(t, "HS256", "hmac", validClaims())),
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:    "expired",
+			token:   keys.sign(t, "RS256", "rsa", with("exp", now.Add(-2*time.Minute).Unix())),
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:  "expired within the leeway",
+			token: keys.sign(t, "RS256", "rsa", with("exp", now.Add(-30*time.Second).Unix())),
+			want:  auth.Principal{Subject: "1", Issuer: "https://id.example.com", ExpiresAt: time.Unix(now.Add(-30*time.Second).Unix(), 0)},
+		},
+		{
+			name:    "without expiry",
+			token:   keys.sign(t, "RS256", "rsa", with("exp", nil)),
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:    "not valid yet",
+			token:   keys.sign(t, "RS256", "rsa", with("nbf", now.Add(time.Hour).Unix())),
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:    "another issuer",
+			token:   keys.sign(t, "ES256", "ec", with("iss", "https://evil.example.com")),
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:    "another audience",
+			token:   keys.sign(t, "ES256", "ec", with("aud", "other")),
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name:    "without subject",
+			token:   keys.sign(t, "ES256", "ec", with("sub", nil)),
+			wantErr: internal.ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// tamper replaces the subject of the token, keeping its signature.
+func tamper(token string) string {
+	c := validClaims()
+	c["sub"] = "2"
+	payload, _ := json.Marshal(c)
+
+	parts := strings.Split(token, ".")
+	return parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+}
+
+func TestVerifier_Verify_SingleKey(t *testing.T) {
+	keys := newTestKeys(t)
+	jwks := `{"keys":[{"kty":"oct","k":"` + base64.RawURLEncoding.EncodeToString(hmacSecret) + `"}]}`
+	v, err := auth.NewVerifier(internal.AuthConfig{JWKS: jwks})
+	require.NoError(t, err)
+
+	got, err := v.Verify(keys.sign(t, "HS256", "", validClaims()), now)
+	require.NoError(t, err)
+	assert.Equal(t, "1", got.Subject)
+}
+
+func TestNewVerifier(t *testing.T) {
+	keys := newTestKeys(t)
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(file, []byte(keys.jwks()), 0o600))
+
+	tests := []struct {
+		name    string
+		cfg     internal.AuthConfig
+		wantErr bool
+	}{
+		{
+			name: "JWKS file",
+			cfg:  internal.AuthConfig{JWKSFile: file},
+		},
+		{
+			name: "inline JWKS",
+			cfg:  internal.AuthConfig{JWKS: keys.jwks()},
+		},
+		{
+			name:    "missing JWKS",
+			cfg:     internal.AuthConfig{},
+			wantErr: true,
+		},
+		{
+			name:    "both a file and an inline JWKS",
+			cfg:     internal.AuthConfig{JWKSFile: file, JWKS: keys.jwks()},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			cfg:     internal.AuthConfig{JWKSFile: filepath.Join(t.TempDir(), "missing.json")},
+			wantErr: true,
+		},
+		{
+			name:    "short secret",
+			cfg:     internal.AuthConfig{JWKS: `{"keys":[{"kty":"oct","kid":"hmac","k":"c2hvcnQ"}]}`},
+			wantErr: true,
+		},
+		{
+			name:    "algorithm not matching the key type",
+			cfg:     internal.AuthConfig{JWKS: `{"keys":[{"kty":"oct","kid":"hmac","alg":"RS256","k":"` + base64.RawURLEncoding.EncodeToString(hmacSecret) + `"}]}`},
+			wantErr: true,
+		},
+		{
+			name:    "point off the curve",
+			cfg:     internal.AuthConfig{JWKS: `{"keys":[{"kty":"EC","kid":"ec","crv":"P-256","x":"` + base64.RawURLEncoding.EncodeToString(make([]byte, 32)) + `","y":"` + base64.RawURLEncoding.EncodeToString(make([]byte, 32)) + `"}]}`},
+			wantErr: true,
+		},
+		{
+			name:    "duplicated key",
+			cfg:     internal.AuthConfig{JWKS: `{"keys":[{"kty":"oct","kid":"hmac","k":"` + base64.RawURLEncoding.EncodeToString(hmacSecret) + `"},{"kty":"oct","kid":"hmac","k":"` + base64.RawURLEncoding.EncodeToString(hmacSecret) + `"}]}`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.NewVerifier(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Principal is the caller authenticated by a bearer token.
+type Principal struct {
+	// Subject is the ID of the user the token is issued to, the actor of the requests.
+	Subject   string
+	Issuer    string
+	ExpiresAt time.Time
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal.
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal of ctx, false when the caller isn't authenticated.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -1,12 +1,43 @@
 package internal
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
 	DbSource           string `mapstructure:"db_source"`
 	GRPCAddress        string `mapstructure:"grpc_address"`
 	GRPCGatewayAddress string `mapstructure:"grpc_gateway_address"`
 	OTLPEndpoint       string `mapstructure:"otel_exporter_otlp_endpoint"`
+	// NotificationWebhookURL receives the notifications of the attendees, none are sent when empty.
+	NotificationWebhookURL string `mapstructure:"notification_webhook_url"`
+
+	InvitationToken InvitationTokenConfig `mapstructure:",squash"`
+	Auth            AuthConfig            `mapstructure:",squash"`
+}
+
+// AuthConfig holds the JWKS verifying the bearer tokens of the callers, read from JWKSFile or given
+// inline as JWKS. The issuer and the audience of the tokens are only checked when set.
+type AuthConfig struct {
+	JWKSFile string `mapstructure:"auth_jwks_file"`
+	JWKS     string `mapstructure:"auth_jwks"`
+	Issuer   string `mapstructure:"auth_issuer"`
+	Audience string `mapstructure:"auth_audience"`
+}
+
+// InvitationTokenConfig holds the keys signing the invitation tokens. Rotating the key moves the
+// current one to the previous one, its tokens stay valid for GracePeriod after RotatedAt.
+type InvitationTokenConfig struct {
+	KeyID  string `mapstructure:"invitation_token_key_id"`
+	Secret string `mapstructure:"invitation_token_secret"`
+
+	PreviousKeyID  string `mapstructure:"invitation_token_previous_key_id"`
+	PreviousSecret string `mapstructure:"invitation_token_previous_secret"`
+	// RotatedAt is the RFC 3339 time the previous key was replaced.
+	RotatedAt   string        `mapstructure:"invitation_token_rotated_at"`
+	GracePeriod time.Duration `mapstructure:"invitation_token_grace_period"`
 }
 
 func LoadConfig(path string) (Config, error) {
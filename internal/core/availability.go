@@ -0,0 +1,161 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+// Availability is the working hours of a user, in the user's preferred timezone.
+type Availability struct {
+	UserID   int32
+	Timezone string
+	// WorkingHours lists each weekday at most once, days without working hours are days off. The
+	// user is available at any time when it's empty, apart from the holidays.
+	WorkingHours []WorkingHours
+	// DefaultBuffers apply to the events of the user leaving their buffers unset.
+	DefaultBuffers Buffers
+	// HolidayCalendarID is the holiday calendar of the user, its holidays are days off. Holidays is
+	// loaded along with the availability.
+	HolidayCalendarID *string
+	Holidays          []Holiday
+}
+
+func (a *Availability) Validate() error {
+	if a.UserID <= 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid user id")
+	}
+
+	if _, err := time.LoadLocation(a.Timezone); err != nil || a.Timezone == "" {
+		return internal.WrapErr(internal.ErrInvalidTimezone, a.Timezone)
+	}
+
+	if err := a.DefaultBuffers.Validate(); err != nil {
+		return err
+	}
+
+	var days []time.Weekday
+	for _, wh := range a.WorkingHours {
+		if len(wh.Days) == 0 {
+			return internal.WrapErr(internal.ErrValidationFailed, "working hours must have at least one day")
+		}
+
+		if err := wh.Validate(); err != nil {
+			return err
+		}
+
+		for _, day := range wh.Days {
+			if slices.Contains(days, day) {
+				return internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("%s has more than one working hours", day))
+			}
+			days = append(days, day)
+		}
+	}
+
+	return nil
+}
+
+// Unavailable returns the time outside the working hours, and the holidays, within [from, to),
+// ordered by start time.
+func (a *Availability) Unavailable(from, to time.Time) ([]BusyInterval, error) {
+	if len(a.WorkingHours) == 0 && len(a.Holidays) == 0 {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(a.Timezone)
+	if err != nil {
+		return nil, internal.WrapErr(internal.ErrInvalidTimezone, a.Timezone)
+	}
+
+	var intervals []BusyInterval
+	day := from.In(loc)
+	for d := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc); d.Before(to); {
+		next := time.Date(d.Year(), d.Month(), d.Day()+1, 0, 0, 0, 0, loc)
+
+		i := slices.IndexFunc(a.WorkingHours, func(wh WorkingHours) bool {
+			return slices.Contains(wh.Days, d.Weekday())
+		})
+		switch {
+		case isHoliday(a.Holidays, d) || (i < 0 && len(a.WorkingHours) > 0):
+			intervals = append(intervals, BusyInterval{Start: d, End: next})
+		case i >= 0:
+			wh := a.WorkingHours[i]
+			start := time.Date(d.Year(), d.Month(), d.Day(), 0, wh.StartMinute, 0, 0, loc)
+			end := time.Date(d.Year(), d.Month(), d.Day(), 0, wh.EndMinute, 0, 0, loc)
+			if start.After(d) {
+				intervals = append(intervals, BusyInterval{Start: d, End: start})
+			}
+			if end.Before(next) {
+				intervals = append(intervals, BusyInterval{Start: end, End: next})
+			}
+		}
+		d = next
+	}
+
+	var clipped []BusyInterval
+	for _, interval := range mergeIntervals(intervals) {
+		interval.Start, interval.End = maxTime(interval.Start, from), minTime(interval.End, to)
+		if interval.Start.Before(interval.End) {
+			clipped = append(clipped, interval)
+		}
+	}
+	return clipped, nil
+}
+
+// OutsideWorkingHours is the first occurrence of an event falling outside the working hours of
+// an attendee.
+type OutsideWorkingHours struct {
+	UserID int32
+	Start  time.Time
+	End    time.Time
+}
+
+// OutsideWorkingHours returns, for every attendee with working hours or holidays, the first
+// occurrence of the event within [from, to) falling outside of them.
+func (e *Event) OutsideWorkingHours(availabilities []Availability, from, to time.Time) ([]OutsideWorkingHours, error) {
+	attendees := e.AttendeeIDs()
+
+	var list *OccurrenceList
+	var result []OutsideWorkingHours
+	for _, availability := range availabilities {
+		if !slices.Contains(attendees, availability.UserID) {
+			continue
+		}
+
+		unavailable, err := availability.Unavailable(from, to)
+		if err != nil {
+			return nil, err
+		}
+		if len(unavailable) == 0 {
+			continue
+		}
+
+		if list == nil {
+			list, err = e.windowOccurrences(from, to)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		others := make([]Occurrence, len(unavailable))
+		for i, interval := range unavailable {
+			others[i] = Occurrence{Start: interval.Start, End: interval.End}
+		}
+
+		// The occurrences go last for firstOverlap to return one of them rather than an interval.
+		if occ, ok := firstOverlap(others, attendedBy(list.Occurrences, availability.UserID), nil); ok {
+			result = append(result, OutsideWorkingHours{UserID: availability.UserID, Start: occ.Start, End: occ.End})
+		}
+	}
+	return result, nil
+}
+
+//go:generate go tool -modfile=../../go.tool.mod mockgen -destination=../mock/mock_availability_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core AvailabilityRepository
+type AvailabilityRepository interface {
+	// FindByUserIDs returns the availability of the users, skipping the unknown ones.
+	FindByUserIDs(ctx context.Context, userIDs []int32) ([]Availability, error)
+	Update(ctx context.Context, availability *Availability) error
+}
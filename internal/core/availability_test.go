@@ -0,0 +1,135 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailability_Unavailable(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	availability := core.Availability{
+		UserID:   1,
+		Timezone: "Asia/Jakarta",
+		WorkingHours: []core.WorkingHours{
+			{Days: []time.Weekday{time.Monday, time.Tuesday}, StartMinute: 9 * 60, EndMinute: 17 * 60},
+		},
+	}
+
+	// From Tuesday 4 January 2022 12:00 to Thursday 6 January 2022 00:00, in Asia/Jakarta.
+	from := time.Date(2022, 1, 4, 12, 0, 0, 0, jakarta)
+	to := time.Date(2022, 1, 6, 0, 0, 0, 0, jakarta)
+
+	got, err := availability.Unavailable(from, to)
+	require.NoError(t, err)
+
+	// Wednesday is a day off, merged with the end of Tuesday.
+	want := []core.BusyInterval{
+		{Start: time.Date(2022, 1, 4, 17, 0, 0, 0, jakarta), End: to},
+	}
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.True(t, want[i].Start.Equal(got[i].Start), "got %s", got[i].Start)
+		assert.True(t, want[i].End.Equal(got[i].End), "got %s", got[i].End)
+	}
+
+	always := core.Availability{UserID: 1, Timezone: "Asia/Jakarta"}
+	got, err = always.Unavailable(from, to)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestFreeBusyOf_WorkingHours(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	availabilities := []core.Availability{
+		{
+			UserID:       1,
+			Timezone:     "Asia/Jakarta",
+			WorkingHours: []core.WorkingHours{{Days: []time.Weekday{time.Tuesday}, StartMinute: 9 * 60, EndMinute: 17 * 60}},
+		},
+	}
+	events := []core.Event{
+		{
+			ID:       "late",
+			Timezone: "Asia/Jakarta",
+			Schedules: []core.Schedule{
+				{ID: "sch", StartTime: time.Date(2022, 1, 4, 16, 0, 0, 0, jakarta).Unix(), DurationInMinutes: 120},
+			},
+			Invitations: []core.Invitation{{UserID: 1, Status: core.InvitationStatus_Confirmed}},
+		},
+	}
+
+	from := time.Date(2022, 1, 4, 0, 0, 0, 0, jakarta)
+	to := from.AddDate(0, 0, 1)
+	got, err := core.FreeBusyOf(events, availabilities, nil, []int32{1}, from, to, jakarta)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	want := []core.BusyInterval{
+		{Start: from, End: time.Date(2022, 1, 4, 9, 0, 0, 0, jakarta)},
+		{Start: time.Date(2022, 1, 4, 16, 0, 0, 0, jakarta), End: to},
+	}
+	require.Len(t, got[0].Busy, len(want))
+	for i := range want {
+		assert.True(t, want[i].Start.Equal(got[0].Busy[i].Start))
+		assert.True(t, want[i].End.Equal(got[0].Busy[i].End))
+	}
+}
+
+func TestAvailability_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		availability core.Availability
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			availability: core.Availability{
+				UserID:       1,
+				Timezone:     "Asia/Jakarta",
+				WorkingHours: []core.WorkingHours{{Days: []time.Weekday{time.Monday}, StartMinute: 9 * 60, EndMinute: 17 * 60}},
+			},
+		},
+		{
+			name:         "Not OK - empty timezone",
+			availability: core.Availability{UserID: 1},
+			wantErr:      internal.ErrInvalidTimezone,
+		},
+		{
+			name: "Not OK - no days",
+			availability: core.Availability{
+				UserID:       1,
+				Timezone:     "Asia/Jakarta",
+				WorkingHours: []core.WorkingHours{{StartMinute: 9 * 60, EndMinute: 17 * 60}},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - invalid day",
+			availability: core.Availability{
+				UserID:       1,
+				Timezone:     "Asia/Jakarta",
+				WorkingHours: []core.WorkingHours{{Days: []time.Weekday{7}, StartMinute: 9 * 60, EndMinute: 17 * 60}},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.availability.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+// MaxBuffer is the longest buffer before or after an occurrence.
+const MaxBuffer = 4 * time.Hour
+
+// Buffers is the time an attendee is kept busy before and after a meeting, i.e: to travel.
+type Buffers struct {
+	Before time.Duration
+	After  time.Duration
+}
+
+func (b Buffers) Validate() error {
+	for _, d := range []time.Duration{b.Before, b.After} {
+		if d < 0 || d > MaxBuffer || d%time.Minute != 0 {
+			return internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("buffers must be whole minutes up to %s", MaxBuffer))
+		}
+	}
+	return nil
+}
+
+// DefaultBuffers returns the default buffers of every user by user ID.
+func DefaultBuffers(availabilities []Availability) map[int32]Buffers {
+	buffers := make(map[int32]Buffers, len(availabilities))
+	for _, availability := range availabilities {
+		buffers[availability.UserID] = availability.DefaultBuffers
+	}
+	return buffers
+}
+
+// Busy returns the time the occurrence keeps an attendee busy, from the start of its buffer before
+// to the end of its buffer after. The defaults apply to the buffers the schedule leaves unset.
+func (o Occurrence) Busy(defaults Buffers) BusyInterval {
+	before, after := defaults.Before, defaults.After
+	if o.BufferBefore != nil {
+		before = *o.BufferBefore
+	}
+	if o.BufferAfter != nil {
+		after = *o.BufferAfter
+	}
+	return BusyInterval{Start: o.Start.Add(-before), End: o.End.Add(after)}
+}
+
+// meeting is the time of the occurrence itself, without its buffers.
+func meeting(o Occurrence) BusyInterval {
+	return BusyInterval{Start: o.Start, End: o.End}
+}
+
+func minutes(m *int64) *time.Duration {
+	if m == nil {
+		return nil
+	}
+	d := time.Duration(*m) * time.Minute
+	return &d
+}
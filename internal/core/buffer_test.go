@@ -0,0 +1,164 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuffers_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		buffers core.Buffers
+		wantErr bool
+	}{
+		{name: "OK", buffers: core.Buffers{Before: 15 * time.Minute, After: core.MaxBuffer}},
+		{name: "OK - no buffers"},
+		{name: "Not OK - negative", buffers: core.Buffers{Before: -time.Minute}, wantErr: true},
+		{name: "Not OK - too long", buffers: core.Buffers{After: core.MaxBuffer + time.Minute}, wantErr: true},
+		{name: "Not OK - not whole minutes", buffers: core.Buffers{After: 90 * time.Second}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.buffers.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, internal.ErrValidationFailed)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOccurrence_Busy(t *testing.T) {
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	defaults := core.Buffers{Before: 10 * time.Minute, After: 5 * time.Minute}
+	before := 30 * time.Minute
+
+	// The schedule sets the buffer before only, the default buffer after applies.
+	occ := core.Occurrence{Start: start, End: start.Add(time.Hour), BufferBefore: &before}
+	got := occ.Busy(defaults)
+	assert.True(t, start.Add(-30*time.Minute).Equal(got.Start), "got %s", got.Start)
+	assert.True(t, start.Add(65*time.Minute).Equal(got.End), "got %s", got.End)
+
+	// A buffer set to zero isn't replaced by the default one.
+	none := time.Duration(0)
+	occ = core.Occurrence{Start: start, End: start.Add(time.Hour), BufferBefore: &none, BufferAfter: &none}
+	got = occ.Busy(defaults)
+	assert.True(t, start.Equal(got.Start), "got %s", got.Start)
+	assert.True(t, start.Add(time.Hour).Equal(got.End), "got %s", got.End)
+}
+
+func TestEvent_Conflicts_Buffers(t *testing.T) {
+	// Tuesday, 4 January 2022 09:00 in Asia/Jakarta
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	travel := int64(30)
+
+	// On site from 09:00 to 10:00, with 30 minutes to travel back.
+	onSite := core.Event{
+		ID:       "on-site",
+		Title:    "on-site",
+		Timezone: "Asia/Jakarta",
+		Schedules: []core.Schedule{
+			{ID: "sch1", StartTime: start.Unix(), DurationInMinutes: 60, BufferAfterInMinutes: &travel},
+		},
+		Invitations: []core.Invitation{{UserID: 1}, {UserID: 2}},
+	}
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		buffers map[int32]core.Buffers
+		want    []int32
+	}{
+		{
+			name:  "starting during the buffer after",
+			start: start.Add(70 * time.Minute),
+			want:  []int32{1, 2},
+		},
+		{
+			name:  "starting after the buffer after",
+			start: start.Add(90 * time.Minute),
+		},
+		{
+			name:    "buffers overlapping each other",
+			start:   start.Add(90 * time.Minute),
+			buffers: map[int32]core.Buffers{2: {Before: 15 * time.Minute}},
+		},
+		{
+			name:    "default buffer before reaching the occurrence",
+			start:   start.Add(90 * time.Minute),
+			buffers: map[int32]core.Buffers{2: {Before: 45 * time.Minute}},
+			want:    []int32{2},
+		},
+		{
+			name:    "ending within a default buffer after",
+			start:   start.Add(-70 * time.Minute),
+			buffers: map[int32]core.Buffers{1: {After: 15 * time.Minute}},
+			want:    []int32{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := core.Event{
+				ID:       "new",
+				Timezone: "Asia/Jakarta",
+				Schedules: []core.Schedule{
+					{ID: "sch2", StartTime: tt.start.Unix(), DurationInMinutes: 60},
+				},
+				Invitations: []core.Invitation{{UserID: 1}, {UserID: 2}},
+			}
+
+			from, to := event.ConflictWindow(tt.start)
+			got, err := event.Conflicts([]core.Event{onSite}, tt.buffers, from, to)
+			require.NoError(t, err)
+			if len(tt.want) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+			require.Len(t, got, 1)
+			assert.Equal(t, "on-site", got[0].EventID)
+			assert.Equal(t, tt.want, got[0].UserIDs)
+			assert.True(t, start.Equal(got[0].Start), "got %s", got[0].Start)
+		})
+	}
+}
+
+func TestFreeBusyOf_Buffers(t *testing.T) {
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	travel := int64(30)
+
+	events := []core.Event{
+		{
+			ID:       "on-site",
+			Timezone: "UTC",
+			Schedules: []core.Schedule{
+				{ID: "sch1", StartTime: start.Unix(), DurationInMinutes: 60, BufferBeforeInMinutes: &travel},
+			},
+			Invitations: []core.Invitation{{UserID: 1}, {UserID: 2}},
+		},
+	}
+	buffers := map[int32]core.Buffers{2: {Before: 10 * time.Minute, After: 10 * time.Minute}}
+
+	// The window starts after the occurrence, which only reaches into it with its buffer after.
+	got, err := core.FreeBusyOf(events, nil, buffers, []int32{1, 2}, start.Add(65*time.Minute), start.Add(3*time.Hour), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Empty(t, got[0].Busy)
+	require.Len(t, got[1].Busy, 1)
+	assert.True(t, start.Add(65*time.Minute).Equal(got[1].Busy[0].Start), "got %s", got[1].Busy[0].Start)
+	assert.True(t, start.Add(70*time.Minute).Equal(got[1].Busy[0].End), "got %s", got[1].Busy[0].End)
+
+	got, err = core.FreeBusyOf(events, nil, buffers, []int32{1, 2}, start.Add(-time.Hour), start.Add(3*time.Hour), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, got[0].Busy, 1)
+	assert.True(t, start.Add(-30*time.Minute).Equal(got[0].Busy[0].Start), "got %s", got[0].Busy[0].Start)
+	assert.True(t, start.Add(time.Hour).Equal(got[0].Busy[0].End), "got %s", got[0].Busy[0].End)
+	require.Len(t, got[1].Busy, 1)
+	assert.True(t, start.Add(-30*time.Minute).Equal(got[1].Busy[0].Start), "got %s", got[1].Busy[0].Start)
+	assert.True(t, start.Add(70*time.Minute).Equal(got[1].Busy[0].End), "got %s", got[1].Busy[0].End)
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+)
+
+// Notifier tells the attendees about the changes of their invitations made without them.
+//
+//go:generate go tool -modfile=../../go.tool.mod mockgen -destination=../mock/mock_notifier.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core Notifier
+type Notifier interface {
+	// NotifyPromoted tells the attendee the invitation moved from the waitlist to confirmed.
+	NotifyPromoted(ctx context.Context, event *Event, inv *Invitation) error
+	// NotifyInvited hands the attendee the token just issued to the invitation, to respond with.
+	NotifyInvited(ctx context.Context, event *Event, inv *Invitation) error
+}
+
+// IsFull tells whether the event has as many confirmed attendees as it accepts.
+func (e *Event) IsFull() bool {
+	if e.MaxAttendees == 0 {
+		return false
+	}
+
+	var confirmed int32
+	for _, inv := range e.Invitations {
+		if inv.Status == InvitationStatus_Confirmed {
+			confirmed++
+		}
+	}
+	return confirmed >= e.MaxAttendees
+}
+
+// Respond sets the status of the invitation, one of the event, and returns the invitations of the
+// attendees promoted from the waitlist as a consequence. A confirmation of a full event is
+// waitlisted instead, and a confirmed attendee leaving makes room for the oldest waitlisted ones.
+func (e *Event) Respond(inv *Invitation, status InvitationStatus, now time.Time) []*Invitation {
+	wasConfirmed := inv.Status == InvitationStatus_Confirmed
+
+	switch {
+	case status == InvitationStatus_Confirmed && !wasConfirmed && e.IsFull():
+		inv.Status = InvitationStatus_Waitlisted
+		if inv.WaitlistedAt == nil {
+			inv.WaitlistedAt = &now
+		}
+	default:
+		inv.Status = status
+		inv.WaitlistedAt = nil
+	}
+	inv.UpdatedAt = &now
+
+	if wasConfirmed && inv.Status != InvitationStatus_Confirmed {
+		return e.PromoteWaitlisted(now)
+	}
+	return nil
+}
+
+// PromoteWaitlisted confirms the waitlisted attendees, the oldest first, until the event is full,
+// and returns their invitations.
+func (e *Event) PromoteWaitlisted(now time.Time) []*Invitation {
+	var waitlisted []*Invitation
+	for i := range e.Invitations {
+		if e.Invitations[i].Status == InvitationStatus_Waitlisted {
+			waitlisted = append(waitlisted, &e.Invitations[i])
+		}
+	}
+	slices.SortFunc(waitlisted, func(a, b *Invitation) int {
+		return cmp.Or(compareTime(a.WaitlistedAt, b.WaitlistedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	var promoted []*Invitation
+	for _, inv := range waitlisted {
+		if e.IsFull() {
+			break
+		}
+		inv.Status = InvitationStatus_Confirmed
+		inv.WaitlistedAt = nil
+		inv.UpdatedAt = &now
+		promoted = append(promoted, inv)
+	}
+	return promoted
+}
+
+// compareTime orders the times, a missing one first.
+func compareTime(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return a.Compare(*b)
+}
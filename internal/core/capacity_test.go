@@ -0,0 +1,105 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvent_Respond(t *testing.T) {
+	now := time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)
+	earlier, later := now.Add(-2*time.Hour), now.Add(-time.Hour)
+	newEvent := func(maxAttendees int32) *core.Event {
+		return &core.Event{ID: "123", MaxAttendees: maxAttendees, Invitations: []core.Invitation{
+			{ID: "a", UserID: 2, Status: core.InvitationStatus_Confirmed},
+			{ID: "b", UserID: 3},
+			{ID: "c", UserID: 4, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &later},
+			{ID: "d", UserID: 5, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &earlier},
+		}}
+	}
+
+	tests := []struct {
+		name         string
+		maxAttendees int32
+		idx          int
+		status       core.InvitationStatus
+		wantStatus   core.InvitationStatus
+		wantPromoted []string
+	}{
+		{
+			name:       "OK - confirmed under the limit",
+			idx:        1,
+			status:     core.InvitationStatus_Confirmed,
+			wantStatus: core.InvitationStatus_Confirmed,
+		},
+		{
+			name:         "OK - waitlisted on a full event",
+			maxAttendees: 1,
+			idx:          1,
+			status:       core.InvitationStatus_Confirmed,
+			wantStatus:   core.InvitationStatus_Waitlisted,
+		},
+		{
+			name:         "OK - declining promotes the oldest waitlisted",
+			maxAttendees: 1,
+			idx:          0,
+			status:       core.InvitationStatus_Declined,
+			wantStatus:   core.InvitationStatus_Declined,
+			wantPromoted: []string{"d"},
+		},
+		{
+			name:         "OK - leaving the waitlist",
+			maxAttendees: 1,
+			idx:          3,
+			status:       core.InvitationStatus_Declined,
+			wantStatus:   core.InvitationStatus_Declined,
+		},
+		{
+			name:         "OK - confirming again while confirmed",
+			maxAttendees: 1,
+			idx:          0,
+			status:       core.InvitationStatus_Confirmed,
+			wantStatus:   core.InvitationStatus_Confirmed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := newEvent(tt.maxAttendees)
+			inv := &event.Invitations[tt.idx]
+
+			promoted := event.Respond(inv, tt.status, now)
+			assert.Equal(t, tt.wantStatus, inv.Status)
+			assert.Equal(t, &now, inv.UpdatedAt)
+			assert.Equal(t, tt.wantStatus == core.InvitationStatus_Waitlisted, inv.WaitlistedAt != nil)
+
+			var promotedIDs []string
+			for _, p := range promoted {
+				assert.Equal(t, core.InvitationStatus_Confirmed, p.Status)
+				assert.Nil(t, p.WaitlistedAt)
+				promotedIDs = append(promotedIDs, p.ID)
+			}
+			assert.Equal(t, tt.wantPromoted, promotedIDs)
+		})
+	}
+}
+
+func TestEvent_PromoteWaitlisted(t *testing.T) {
+	now := time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)
+	event := &core.Event{ID: "123", MaxAttendees: 3, Invitations: []core.Invitation{
+		{ID: "a", UserID: 2, Status: core.InvitationStatus_Confirmed},
+		{ID: "c", UserID: 4, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &now},
+		{ID: "b", UserID: 3, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &now},
+		{ID: "d", Guest: &core.Guest{Email: "guest@example.com"}, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &now},
+	}}
+
+	promoted := event.PromoteWaitlisted(now)
+	require.Len(t, promoted, 2)
+	assert.Equal(t, "b", promoted[0].ID)
+	assert.Equal(t, "c", promoted[1].ID)
+	assert.True(t, event.IsFull())
+	assert.Equal(t, core.InvitationStatus_Waitlisted, event.Invitations[3].Status)
+	assert.Empty(t, event.PromoteWaitlisted(now))
+}
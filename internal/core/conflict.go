@@ -0,0 +1,192 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+// ConflictHorizon bounds how far recurring schedules are expanded when looking for conflicts.
+const ConflictHorizon = 365 * 24 * time.Hour
+
+// Conflict is an event already booking some attendees of another event at the same time.
+type Conflict struct {
+	EventID string
+	Title   string
+	UserIDs []int32
+	// Start and End are the first occurrence of the event overlapping the other event.
+	Start time.Time
+	End   time.Time
+}
+
+// ConflictError lists the conflicts preventing an event from being stored.
+type ConflictError struct {
+	Conflicts []Conflict
+	// OutsideWorkingHours is set when the working hours of the attendees are respected.
+	OutsideWorkingHours []OutsideWorkingHours
+	// ReservedResources is the resources already reserved by another event at the same time.
+	ReservedResources []string
+}
+
+func (c *ConflictError) Error() string {
+	var reasons []string
+	if len(c.Conflicts) > 0 {
+		events := make([]string, len(c.Conflicts))
+		for i, conflict := range c.Conflicts {
+			events[i] = conflict.EventID
+		}
+		reasons = append(reasons, "attendees are already booked by "+strings.Join(events, ", "))
+	}
+	if len(c.OutsideWorkingHours) > 0 {
+		users := make([]string, len(c.OutsideWorkingHours))
+		for i, outside := range c.OutsideWorkingHours {
+			users[i] = strconv.Itoa(int(outside.UserID))
+		}
+		reasons = append(reasons, "outside the working hours of "+strings.Join(users, ", "))
+	}
+	if len(c.ReservedResources) > 0 {
+		reasons = append(reasons, "resources are already reserved: "+strings.Join(c.ReservedResources, ", "))
+	}
+	return fmt.Sprintf("%s: %s", internal.ErrConflict.Error(), strings.Join(reasons, "; "))
+}
+
+func (c *ConflictError) Unwrap() error {
+	return internal.ErrConflict
+}
+
+// AttendeeIDs returns the users invited to the event who haven't declined it nor are waitlisted, or
+// who accepted some of its occurrences. The guests have no busy time to account for, they are left out.
+func (e *Event) AttendeeIDs() []int32 {
+	var ids []int32
+	for _, inv := range e.Invitations {
+		if inv.Guest == nil && inv.attends() && !slices.Contains(ids, inv.UserID) {
+			ids = append(ids, inv.UserID)
+		}
+	}
+	return ids
+}
+
+func (i *Invitation) attends() bool {
+	if i.Status.isAttending() {
+		return true
+	}
+	return slices.ContainsFunc(i.OccurrenceResponses, func(r OccurrenceResponse) bool {
+		return r.Status.isAttending()
+	})
+}
+
+// maxWindowOccurrences caps the occurrences of an event expanded over a conflict window, i.e:
+// several daily schedules over the whole window. The checks fail past it rather than missing the
+// occurrences left out.
+const maxWindowOccurrences = 20 * MaxOccurrences
+
+// ConflictWindow returns the time range checked for conflicts: from the first occurrence of the
+// event, or from now when the event already started, up to ConflictHorizon later. The past
+// occurrences can't conflict anymore.
+func (e *Event) ConflictWindow(now time.Time) (time.Time, time.Time) {
+	var from time.Time
+	for _, sch := range e.Schedules {
+		start := time.Unix(sch.StartTime, 0)
+		if from.IsZero() || start.Before(from) {
+			from = start
+		}
+	}
+	from = maxTime(from, now)
+	return from, from.Add(ConflictHorizon)
+}
+
+// windowOccurrences expands the event into every occurrence overlapping [from, to), failing with
+// internal.ErrValidationFailed when there are more than maxWindowOccurrences of them.
+func (e *Event) windowOccurrences(from, to time.Time) (*OccurrenceList, error) {
+	list, err := e.Occurrences(from, to, maxWindowOccurrences)
+	if err != nil {
+		return nil, err
+	}
+	if list.Truncated {
+		return nil, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("event %s has more than %d occurrences to check", e.ID, maxWindowOccurrences))
+	}
+	return list, nil
+}
+
+// Conflicts returns the other events booking an attendee of the event during one of its
+// occurrences within [from, to), or during their buffers. The buffers of the schedules leaving
+// them unset are the default buffers of each attendee. It fails when either event has too many
+// occurrences within the window to check them all.
+func (e *Event) Conflicts(others []Event, buffers map[int32]Buffers, from, to time.Time) ([]Conflict, error) {
+	attendees := e.AttendeeIDs()
+	if len(attendees) == 0 {
+		return nil, nil
+	}
+
+	list, err := e.windowOccurrences(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []Conflict
+	for _, other := range others {
+		var shared []int32
+		for _, userID := range other.AttendeeIDs() {
+			if slices.Contains(attendees, userID) {
+				shared = append(shared, userID)
+			}
+		}
+		if len(shared) == 0 {
+			continue
+		}
+
+		// The buffers of the occurrences around the window can reach into it.
+		otherList, err := other.windowOccurrences(from.Add(-MaxBuffer), to.Add(MaxBuffer))
+		if err != nil {
+			return nil, err
+		}
+
+		conflict := Conflict{EventID: other.ID, Title: other.Title}
+		for _, userID := range shared {
+			defaults := buffers[userID]
+			occ, ok := firstOverlap(attendedBy(list.Occurrences, userID), attendedBy(otherList.Occurrences, userID), &defaults)
+			if !ok {
+				continue
+			}
+			if len(conflict.UserIDs) == 0 || occ.Start.Before(conflict.Start) {
+				conflict.Start, conflict.End = occ.Start, occ.End
+			}
+			conflict.UserIDs = append(conflict.UserIDs, userID)
+		}
+		if len(conflict.UserIDs) > 0 {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts, nil
+}
+
+// firstOverlap returns the first occurrence of others overlapping an occurrence of occurrences,
+// both ordered by their start time. With default buffers, an occurrence overlapping the buffers of
+// another one overlaps it too, whereas buffers overlapping each other don't.
+func firstOverlap(occurrences, others []Occurrence, defaults *Buffers) (Occurrence, bool) {
+	busy := meeting
+	if defaults != nil {
+		busy = func(o Occurrence) BusyInterval {
+			return o.Busy(*defaults)
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(occurrences) && j < len(others) {
+		a, b := occurrences[i], others[j]
+		if meeting(a).overlaps(busy(b)) || busy(a).overlaps(meeting(b)) {
+			return b, true
+		}
+
+		if busy(a).End.Before(busy(b).End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return Occurrence{}, false
+}
@@ -0,0 +1,186 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvent_Conflicts(t *testing.T) {
+	// Tuesday, 4 January 2022 09:00 in Asia/Jakarta
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	weekly := core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1, WeekStart: time.Monday}
+
+	event := core.Event{
+		ID:       "new",
+		Timezone: "Asia/Jakarta",
+		Schedules: []core.Schedule{
+			// Once, on Tuesday 18 January 2022 09:30 for an hour.
+			{ID: "sch", StartTime: start.AddDate(0, 0, 14).Add(30 * time.Minute).Unix(), DurationInMinutes: 60},
+		},
+		Invitations: []core.Invitation{
+			{UserID: 1, Status: core.InvitationStatus_Unknown},
+			{UserID: 2, Status: core.InvitationStatus_Unknown},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		others []core.Event
+		want   []core.Conflict
+	}{
+		{
+			name: "overlapping occurrence of a recurring event",
+			others: []core.Event{
+				{
+					ID:       "standup",
+					Title:    "standup",
+					Timezone: "Asia/Jakarta",
+					Schedules: []core.Schedule{
+						{ID: "sch", StartTime: start.Unix(), DurationInMinutes: 60, RecurrenceRule: weekly},
+					},
+					Invitations: []core.Invitation{
+						{UserID: 2, Status: core.InvitationStatus_Confirmed},
+						{UserID: 3, Status: core.InvitationStatus_Confirmed},
+					},
+				},
+			},
+			want: []core.Conflict{
+				{
+					EventID: "standup",
+					Title:   "standup",
+					UserIDs: []int32{2},
+					Start:   start.AddDate(0, 0, 14),
+					End:     start.AddDate(0, 0, 14).Add(time.Hour),
+				},
+			},
+		},
+		{
+			name: "declined invitation",
+			others: []core.Event{
+				{
+					ID:       "standup",
+					Timezone: "Asia/Jakarta",
+					Schedules: []core.Schedule{
+						{ID: "sch", StartTime: start.Unix(), DurationInMinutes: 60, RecurrenceRule: weekly},
+					},
+					Invitations: []core.Invitation{
+						{UserID: 2, Status: core.InvitationStatus_Declined},
+					},
+				},
+			},
+		},
+		{
+			name: "back-to-back occurrences",
+			others: []core.Event{
+				{
+					ID:       "review",
+					Timezone: "Asia/Jakarta",
+					Schedules: []core.Schedule{
+						{ID: "sch", StartTime: start.AddDate(0, 0, 14).Add(90 * time.Minute).Unix(), DurationInMinutes: 30},
+					},
+					Invitations: []core.Invitation{
+						{UserID: 1, Status: core.InvitationStatus_Confirmed},
+					},
+				},
+			},
+		},
+		{
+			name: "recurrence ended before the event",
+			others: []core.Event{
+				{
+					ID:       "standup",
+					Timezone: "Asia/Jakarta",
+					Schedules: []core.Schedule{
+						{
+							ID:                "sch",
+							StartTime:         start.Unix(),
+							DurationInMinutes: 60,
+							RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1, Count: 2},
+							Count:             2,
+						},
+					},
+					Invitations: []core.Invitation{
+						{UserID: 1, Status: core.InvitationStatus_Confirmed},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := event.ConflictWindow(start)
+			got, err := event.Conflicts(tt.others, nil, from, to)
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].EventID, got[i].EventID)
+				assert.Equal(t, tt.want[i].Title, got[i].Title)
+				assert.Equal(t, tt.want[i].UserIDs, got[i].UserIDs)
+				assert.True(t, tt.want[i].Start.Equal(got[i].Start))
+				assert.True(t, tt.want[i].End.Equal(got[i].End))
+			}
+		})
+	}
+}
+
+func TestEvent_ConflictWindow(t *testing.T) {
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	event := core.Event{
+		Schedules: []core.Schedule{
+			{ID: "sch2", StartTime: start.AddDate(0, 0, 7).Unix()},
+			{ID: "sch1", StartTime: start.Unix()},
+		},
+	}
+
+	from, to := event.ConflictWindow(start.AddDate(0, 0, -7))
+	assert.True(t, from.Equal(start), "got %s", from)
+	assert.True(t, to.Equal(start.Add(core.ConflictHorizon)), "got %s", to)
+
+	// The occurrences already past can't conflict anymore.
+	now := start.AddDate(0, 1, 0)
+	from, to = event.ConflictWindow(now)
+	assert.True(t, from.Equal(now), "got %s", from)
+	assert.True(t, to.Equal(now.Add(core.ConflictHorizon)), "got %s", to)
+}
+
+func TestEvent_Conflicts_TooManyOccurrences(t *testing.T) {
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	daily := core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1, WeekStart: time.Monday}
+
+	event := core.Event{
+		ID:          "new",
+		Timezone:    "Asia/Jakarta",
+		Schedules:   []core.Schedule{{ID: "sch", StartTime: start.Unix(), DurationInMinutes: 60}},
+		Invitations: []core.Invitation{{UserID: 1, Status: core.InvitationStatus_Confirmed}},
+	}
+	shifts := core.Event{
+		ID:          "shifts",
+		Timezone:    "Asia/Jakarta",
+		Invitations: []core.Invitation{{UserID: 1, Status: core.InvitationStatus_Confirmed}},
+	}
+	for i := range 30 {
+		shifts.Schedules = append(shifts.Schedules, core.Schedule{
+			ID:                "sch",
+			StartTime:         start.AddDate(0, 0, 1).Add(time.Duration(i) * 30 * time.Minute).Unix(),
+			DurationInMinutes: 30,
+			RecurringType:     core.RecurringType_Custom,
+			RecurrenceRule:    daily,
+		})
+	}
+
+	// The occurrences past the cap aren't left unchecked.
+	from, to := event.ConflictWindow(start)
+	_, err := event.Conflicts([]core.Event{shifts}, nil, from, to)
+	assert.ErrorIs(t, err, internal.ErrValidationFailed)
+}
+
+func TestConflictError(t *testing.T) {
+	err := &core.ConflictError{Conflicts: []core.Conflict{{EventID: "a"}, {EventID: "b"}}}
+	assert.ErrorIs(t, err, internal.ErrConflict)
+	assert.Equal(t, "conflict: attendees are already booked by a, b", err.Error())
+}
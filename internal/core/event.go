@@ -23,9 +23,25 @@ type Event struct {
 	CreatedBy   string     `db:"created_by"`
 	CreatedAt   time.Time  `db:"created_at"`
 	UpdatedAt   *time.Time `db:"updated_at"`
+	// ParentEventID is the event this event was split from, by updating an occurrence of a
+	// recurring series and the ones following it.
+	ParentEventID *string `db:"parent_event_id"`
 
 	Schedules   []Schedule   `validate:"required,dive,required"`
 	Invitations []Invitation `validate:"dive"`
+	// ResourceIDs is the resources reserved for every occurrence of the event, which must all start
+	// within the reservation window.
+	ResourceIDs []string `validate:"unique,dive,required"`
+	// HolidayCalendarID is the holiday calendar of the event, the occurrences falling on its holidays
+	// are skipped when SkipHolidays is set. Holidays is loaded along with the event.
+	HolidayCalendarID *string `db:"holiday_calendar_id"`
+	SkipHolidays      bool    `db:"skip_holidays"`
+	Holidays          []Holiday
+	// MaxAttendees caps the confirmed attendees, the confirmations past it are waitlisted. Zero is
+	// no limit.
+	MaxAttendees int32 `db:"max_attendees"`
+	// Groups is the groups invited to the event, their members being among the invitations.
+	Groups []EventGroup `validate:"unique=GroupID,dive"`
 }
 
 func (e *Event) Validate() error {
@@ -33,6 +49,47 @@ func (e *Event) Validate() error {
 	if err != nil {
 		return internal.WrapErr(internal.ErrInvalidTimezone, e.Timezone)
 	}
+
+	if e.MaxAttendees < 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, "the maximum number of attendees must not be negative")
+	}
+
+	if e.SkipHolidays && e.HolidayCalendarID == nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "a holiday calendar is required to skip holidays")
+	}
+
+	for i := range e.Schedules {
+		err = e.Schedules[i].Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, inv := range e.Invitations {
+		if inv.Role > AttendeeRole_Organizer {
+			return internal.WrapErr(internal.ErrValidationFailed, "invalid attendee role")
+		}
+
+		if inv.Guest == nil {
+			continue
+		}
+
+		if inv.UserID != 0 {
+			return internal.WrapErr(internal.ErrValidationFailed, "an invitation is either for a user or for a guest")
+		}
+
+		err = inv.Guest.Validate()
+		if err != nil {
+			return err
+		}
+
+		for _, other := range e.Invitations[:i] {
+			if inv.SameAttendee(&other) {
+				return internal.WrapErr(internal.ErrValidationFailed, "the guest "+inv.Guest.Email+" is invited twice")
+			}
+		}
+	}
+
 	return validate.Struct(e)
 }
 
@@ -56,6 +113,14 @@ func NewEvent(createdBy string) *Event {
 type EventRepository interface {
 	Store(ctx context.Context, e *Event) error
 	DeleteByID(ctx context.Context, id string) error
+	// Update replaces the event, deleting the schedules and the invitations it doesn't hold anymore.
 	Update(ctx context.Context, e *Event) error
 	FindByID(ctx context.Context, id string) (*Event, error)
+	// FindByIDs returns the events of the IDs, in the same order, skipping the unknown ones.
+	FindByIDs(ctx context.Context, ids []string) ([]Event, error)
+	SplitSeries(ctx context.Context, e *Event, following *Event) error
+	// FindByAttendees returns the events any of the users is invited to, without having declined all of it,
+	// with a schedule that may occur within [from, to).
+	FindByAttendees(ctx context.Context, userIDs []int32, from, to time.Time) ([]Event, error)
+	StoreOccurrenceException(ctx context.Context, exc *OccurrenceException) error
 }
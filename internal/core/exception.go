@@ -0,0 +1,54 @@
+package core
+
+import (
+	"time"
+)
+
+// OccurrenceException cancels or overrides a single occurrence of a recurring schedule, identified
+// by the start time the occurrence has according to the recurrence rule.
+type OccurrenceException struct {
+	ScheduleID    string `db:"schedule_id" validate:"required"`
+	OriginalStart int64  `db:"original_start" validate:"required"`
+	IsCancelled   bool   `db:"is_cancelled"`
+	// StartTime and DurationInMinutes move the occurrence when set, zero keeps the original value.
+	StartTime         int64 `db:"start_time"`
+	DurationInMinutes int64 `db:"duration"`
+	// Title and Description override the event's when set.
+	Title       string    `db:"title"`
+	Description string    `db:"description"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (o *OccurrenceException) Validate() error {
+	return validate.Struct(o)
+}
+
+// apply returns the occurrence as modified by the exception.
+func (o *OccurrenceException) apply(occ Occurrence) Occurrence {
+	duration := occ.End.Sub(occ.Start)
+	if o.DurationInMinutes > 0 {
+		duration = time.Duration(o.DurationInMinutes) * time.Minute
+	}
+	if o.StartTime != 0 {
+		occ.Start = time.Unix(o.StartTime, 0).In(occ.Start.Location())
+	}
+	occ.End = occ.Start.Add(duration)
+
+	if o.Title != "" {
+		occ.Title = o.Title
+	}
+	if o.Description != "" {
+		occ.Description = o.Description
+	}
+	occ.IsModified = true
+
+	return occ
+}
+
+// shift is how far the exception moves its occurrence.
+func (o *OccurrenceException) shift() time.Duration {
+	if o.StartTime == 0 {
+		return 0
+	}
+	return time.Duration(o.StartTime-o.OriginalStart) * time.Second
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"slices"
+	"time"
+)
+
+// MaxFreeBusyWindow is the longest time range of a free/busy query.
+const MaxFreeBusyWindow = 90 * 24 * time.Hour
+
+type BusyInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (b BusyInterval) overlaps(other BusyInterval) bool {
+	return b.Start.Before(other.End) && other.Start.Before(b.End)
+}
+
+// FreeBusy is the busy time of a user, without the details of the events keeping the user busy.
+type FreeBusy struct {
+	UserID int32
+	// Busy is ordered by start time, with overlapping and adjacent intervals merged.
+	Busy []BusyInterval
+}
+
+// FreeBusyOf returns the busy intervals of every user within [from, to), in the given location,
+// from the occurrences of the events they are invited to without having declined them, buffers
+// included. The buffers of the schedules leaving them unset are the default buffers of each user.
+// The time outside the working hours of the given availabilities is busy too. It fails when an
+// event has too many occurrences within the window to account for them all.
+func FreeBusyOf(events []Event, availabilities []Availability, buffers map[int32]Buffers, userIDs []int32, from, to time.Time, loc *time.Location) ([]FreeBusy, error) {
+	busy := make(map[int32][]BusyInterval, len(userIDs))
+	for _, availability := range availabilities {
+		unavailable, err := availability.Unavailable(from, to)
+		if err != nil {
+			return nil, err
+		}
+		busy[availability.UserID] = append(busy[availability.UserID], unavailable...)
+	}
+
+	for _, event := range events {
+		attendees := event.AttendeeIDs()
+		if !slices.ContainsFunc(userIDs, func(id int32) bool { return slices.Contains(attendees, id) }) {
+			continue
+		}
+
+		// The buffers of the occurrences around the window can reach into it.
+		list, err := event.windowOccurrences(from.Add(-MaxBuffer), to.Add(MaxBuffer))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, occ := range list.Occurrences {
+			for _, userID := range attendees {
+				if !occ.IsAttendedBy(userID) {
+					continue
+				}
+
+				interval := occ.Busy(buffers[userID])
+				interval.Start, interval.End = maxTime(interval.Start, from), minTime(interval.End, to)
+				if interval.Start.Before(interval.End) {
+					busy[userID] = append(busy[userID], interval)
+				}
+			}
+		}
+	}
+
+	result := make([]FreeBusy, len(userIDs))
+	for i, userID := range userIDs {
+		intervals := mergeIntervals(busy[userID])
+		for j := range intervals {
+			intervals[j].Start = intervals[j].Start.In(loc)
+			intervals[j].End = intervals[j].End.In(loc)
+		}
+		result[i] = FreeBusy{UserID: userID, Busy: intervals}
+	}
+	return result, nil
+}
+
+func mergeIntervals(intervals []BusyInterval) []BusyInterval {
+	if len(intervals) == 0 {
+		return []BusyInterval{}
+	}
+
+	slices.SortFunc(intervals, func(a, b BusyInterval) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	merged := []BusyInterval{intervals[0]}
+	for _, interval := range intervals[1:] {
+		last := &merged[len(merged)-1]
+		if interval.Start.After(last.End) {
+			merged = append(merged, interval)
+			continue
+		}
+		last.End = maxTime(last.End, interval.End)
+	}
+	return merged
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,149 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeBusyOf(t *testing.T) {
+	// Tuesday, 4 January 2022 09:00 in Asia/Jakarta
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	events := []core.Event{
+		{
+			ID:       "standup",
+			Timezone: "Asia/Jakarta",
+			Schedules: []core.Schedule{
+				{ID: "sch", StartTime: start.Unix(), DurationInMinutes: 60, RecurrenceRule: core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1}},
+			},
+			Invitations: []core.Invitation{
+				{UserID: 1, Status: core.InvitationStatus_Confirmed},
+				{UserID: 2, Status: core.InvitationStatus_Declined},
+			},
+		},
+		{
+			ID:       "review",
+			Timezone: "Asia/Jakarta",
+			Schedules: []core.Schedule{
+				// Overlaps the second standup and keeps going.
+				{ID: "sch", StartTime: start.AddDate(0, 0, 1).Add(30 * time.Minute).Unix(), DurationInMinutes: 60},
+				// Right after the third standup.
+				{ID: "sch2", StartTime: start.AddDate(0, 0, 2).Add(time.Hour).Unix(), DurationInMinutes: 30},
+			},
+			Invitations: []core.Invitation{
+				{UserID: 1, Status: core.InvitationStatus_Unknown},
+				{UserID: 2, Status: core.InvitationStatus_Unknown},
+			},
+		},
+	}
+
+	// The window starts in the middle of the first standup.
+	from := start.Add(30 * time.Minute)
+	to := start.AddDate(0, 0, 3)
+
+	got, err := core.FreeBusyOf(events, nil, nil, []int32{1, 2, 3}, from, to, jakarta)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	want := []core.FreeBusy{
+		{
+			UserID: 1,
+			Busy: []core.BusyInterval{
+				{Start: from, End: start.Add(time.Hour)},
+				{Start: start.AddDate(0, 0, 1), End: start.AddDate(0, 0, 1).Add(90 * time.Minute)},
+				{Start: start.AddDate(0, 0, 2), End: start.AddDate(0, 0, 2).Add(90 * time.Minute)},
+			},
+		},
+		{
+			UserID: 2,
+			Busy: []core.BusyInterval{
+				{Start: start.AddDate(0, 0, 1).Add(30 * time.Minute), End: start.AddDate(0, 0, 1).Add(90 * time.Minute)},
+				{Start: start.AddDate(0, 0, 2).Add(time.Hour), End: start.AddDate(0, 0, 2).Add(90 * time.Minute)},
+			},
+		},
+		{
+			UserID: 3,
+			Busy:   []core.BusyInterval{},
+		},
+	}
+	for i := range want {
+		assert.Equal(t, want[i].UserID, got[i].UserID)
+		require.Len(t, got[i].Busy, len(want[i].Busy))
+		for j, interval := range want[i].Busy {
+			assert.True(t, interval.Start.Equal(got[i].Busy[j].Start))
+			assert.True(t, interval.End.Equal(got[i].Busy[j].End))
+			assert.Equal(t, jakarta, got[i].Busy[j].Start.Location())
+		}
+	}
+}
+
+func TestFreeBusyOf_OccurrenceResponses(t *testing.T) {
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+
+	events := []core.Event{
+		{
+			ID:       "standup",
+			Timezone: "UTC",
+			Schedules: []core.Schedule{
+				{ID: "sch", StartTime: start.Unix(), DurationInMinutes: 60, RecurrenceRule: core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1}},
+			},
+			Invitations: []core.Invitation{
+				{
+					UserID: 1, Status: core.InvitationStatus_Confirmed,
+					OccurrenceResponses: []core.OccurrenceResponse{
+						{ScheduleID: "sch", OriginalStart: start.AddDate(0, 0, 1).Unix(), Status: core.InvitationStatus_Declined},
+					},
+				},
+				{
+					UserID: 2, Status: core.InvitationStatus_Declined,
+					OccurrenceResponses: []core.OccurrenceResponse{
+						{ScheduleID: "sch", OriginalStart: start.AddDate(0, 0, 2).Unix(), Status: core.InvitationStatus_Tentative},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := core.FreeBusyOf(events, nil, nil, []int32{1, 2}, start, start.AddDate(0, 0, 3), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, []core.BusyInterval{
+		{Start: start, End: start.Add(time.Hour)},
+		{Start: start.AddDate(0, 0, 2), End: start.AddDate(0, 0, 2).Add(time.Hour)},
+	}, got[0].Busy)
+	assert.Equal(t, []core.BusyInterval{
+		{Start: start.AddDate(0, 0, 2), End: start.AddDate(0, 0, 2).Add(time.Hour)},
+	}, got[1].Busy)
+}
+
+func TestFreeBusyOf_TooManyOccurrences(t *testing.T) {
+	start := time.Date(2022, 1, 4, 0, 0, 0, 0, time.UTC)
+	daily := core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1, WeekStart: time.Monday}
+
+	shifts := core.Event{
+		ID:          "shifts",
+		Timezone:    "UTC",
+		Invitations: []core.Invitation{{UserID: 1, Status: core.InvitationStatus_Confirmed}},
+	}
+	for i := range 120 {
+		shifts.Schedules = append(shifts.Schedules, core.Schedule{
+			ID:                "sch",
+			StartTime:         start.Add(time.Duration(i) * 10 * time.Minute).Unix(),
+			DurationInMinutes: 10,
+			RecurringType:     core.RecurringType_Custom,
+			RecurrenceRule:    daily,
+		})
+	}
+
+	// The occurrences past the cap aren't reported as free time.
+	_, err := core.FreeBusyOf([]core.Event{shifts}, nil, nil, []int32{1}, start, start.Add(core.MaxFreeBusyWindow), time.UTC)
+	assert.ErrorIs(t, err, internal.ErrValidationFailed)
+}
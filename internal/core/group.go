@@ -0,0 +1,110 @@
+package core
+
+import (
+	"context"
+	"slices"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/satori/uuid"
+)
+
+// Group is a named list of users, i.e: a team, invited to the events as a whole. The members of
+// its subgroups are members of the group as well.
+type Group struct {
+	ID        string `validate:"required" db:"id"`
+	Name      string `validate:"required,max=100" db:"name"`
+	CreatedBy string `db:"created_by"`
+	// MemberIDs and SubgroupIDs are the direct members of the group.
+	MemberIDs   []int32  `validate:"unique,dive,gt=0"`
+	SubgroupIDs []string `validate:"unique,dive,required"`
+}
+
+func NewGroup(createdBy string) *Group {
+	return &Group{
+		ID:        uuid.NewV4().String(),
+		CreatedBy: createdBy,
+	}
+}
+
+func (g *Group) Validate() error {
+	err := validate.Struct(g)
+	if err != nil {
+		return internal.WrapErr(internal.ErrValidationFailed, err.Error())
+	}
+
+	if slices.Contains(g.SubgroupIDs, g.ID) {
+		return internal.WrapErr(internal.ErrValidationFailed, "a group can't be its own subgroup")
+	}
+
+	return nil
+}
+
+// EventGroup is a group invited to an event. Its members are invited one by one when the group is
+// invited, each invitation remembering the group.
+type EventGroup struct {
+	GroupID string `validate:"required"`
+	// Sync invites the members joining the group later on, and withdraws the invitations not
+	// answered yet of the members leaving it, as long as the event has occurrences to come.
+	Sync bool
+}
+
+// InviteGroup invites the members of the group who aren't invited to the event yet, and returns
+// their invitations.
+func (e *Event) InviteGroup(groupID string, memberIDs []int32) []Invitation {
+	var invited []Invitation
+	for _, id := range memberIDs {
+		if slices.ContainsFunc(e.Invitations, func(inv Invitation) bool { return inv.IsFor(id, "") }) {
+			continue
+		}
+
+		inv := NewInvitation(e.ID, id)
+		inv.GroupID = &groupID
+		e.Invitations = append(e.Invitations, inv)
+		invited = append(invited, inv)
+	}
+	return invited
+}
+
+// SyncGroup brings the invitations through the group in line with its current members: the
+// invitations not answered yet of the users who left the group are withdrawn, and the users who
+// joined it are invited. It returns the new invitations and the IDs of the withdrawn ones.
+func (e *Event) SyncGroup(groupID string, memberIDs []int32) ([]Invitation, []string) {
+	var withdrawnIDs []string
+	e.Invitations = slices.DeleteFunc(e.Invitations, func(inv Invitation) bool {
+		withdrawn := inv.GroupID != nil && *inv.GroupID == groupID && inv.Status == InvitationStatus_Unknown &&
+			len(inv.OccurrenceResponses) == 0 && !slices.Contains(memberIDs, inv.UserID)
+		if withdrawn {
+			withdrawnIDs = append(withdrawnIDs, inv.ID)
+		}
+		return withdrawn
+	})
+
+	return e.InviteGroup(groupID, memberIDs), withdrawnIDs
+}
+
+// SyncedGroupIDs returns the groups of the event following the changes of their members.
+func (e *Event) SyncedGroupIDs() []string {
+	var ids []string
+	for _, g := range e.Groups {
+		if g.Sync {
+			ids = append(ids, g.GroupID)
+		}
+	}
+	return ids
+}
+
+//go:generate go tool -modfile=../../go.tool.mod mockgen -destination=../mock/mock_group_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core GroupRepository
+type GroupRepository interface {
+	Store(ctx context.Context, g *Group) error
+	// FindByID returns the group along with its direct members.
+	FindByID(ctx context.Context, id string) (*Group, error)
+	// UpdateMembers replaces the direct members of the group.
+	UpdateMembers(ctx context.Context, g *Group) error
+	// FindMemberIDs returns the members of the group and of its subgroups, at any depth.
+	FindMemberIDs(ctx context.Context, id string) ([]int32, error)
+	// FindSubgroupIDs returns the subgroups of the group, at any depth.
+	FindSubgroupIDs(ctx context.Context, id string) ([]string, error)
+	// FindSyncedEventIDs returns the events inviting the group, or a group containing it at any
+	// depth, with Sync set.
+	FindSyncedEventIDs(ctx context.Context, id string) ([]string, error)
+}
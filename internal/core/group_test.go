@@ -0,0 +1,89 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   core.Group
+		wantErr error
+	}{
+		{
+			name:  "OK",
+			group: core.Group{ID: "platform", Name: "Platform", MemberIDs: []int32{4, 5}, SubgroupIDs: []string{"sre"}},
+		},
+		{
+			name:    "missing name",
+			group:   core.Group{ID: "platform"},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name:    "duplicated member",
+			group:   core.Group{ID: "platform", Name: "Platform", MemberIDs: []int32{4, 4}},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name:    "invalid member",
+			group:   core.Group{ID: "platform", Name: "Platform", MemberIDs: []int32{0}},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name:    "own subgroup",
+			group:   core.Group{ID: "platform", Name: "Platform", SubgroupIDs: []string{"platform"}},
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.group.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEvent_InviteGroup(t *testing.T) {
+	event := &core.Event{ID: "123", Invitations: []core.Invitation{core.NewInvitation("123", 4)}}
+
+	invited := event.InviteGroup("platform", []int32{4, 5, 6})
+	require.Len(t, invited, 2)
+	require.Len(t, event.Invitations, 3)
+
+	assert.Nil(t, event.Invitations[0].GroupID, "the user invited directly stays so")
+	for i, inv := range event.Invitations[1:] {
+		assert.Equal(t, invited[i], inv)
+		assert.Equal(t, "platform", *inv.GroupID)
+		assert.Equal(t, "123", inv.EventID)
+	}
+	assert.Equal(t, int32(5), invited[0].UserID)
+	assert.Equal(t, int32(6), invited[1].UserID)
+}
+
+func TestEvent_SyncGroup(t *testing.T) {
+	event := &core.Event{ID: "123", Groups: []core.EventGroup{{GroupID: "platform", Sync: true}, {GroupID: "sales"}}}
+	event.InviteGroup("platform", []int32{4, 5, 6})
+	event.Invitations[1].Status = core.InvitationStatus_Confirmed
+	left := event.Invitations[2].ID
+
+	invited, withdrawnIDs := event.SyncGroup("platform", []int32{4, 7})
+	require.Len(t, invited, 1)
+	assert.Equal(t, int32(7), invited[0].UserID)
+	assert.Equal(t, []string{left}, withdrawnIDs, "only the pending invitation of the user who left is withdrawn")
+
+	var userIDs []int32
+	for _, inv := range event.Invitations {
+		userIDs = append(userIDs, inv.UserID)
+	}
+	assert.Equal(t, []int32{4, 5, 7}, userIDs)
+	assert.Equal(t, []string{"platform"}, event.SyncedGroupIDs())
+}
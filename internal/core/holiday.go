@@ -0,0 +1,240 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/satori/uuid"
+)
+
+// HolidayCalendar is a set of holidays, i.e: the public holidays of a country.
+type HolidayCalendar struct {
+	ID       string    `validate:"required" db:"id"`
+	Name     string    `validate:"required,max=100" db:"name"`
+	Holidays []Holiday `validate:"required,dive"`
+}
+
+func NewHolidayCalendar() *HolidayCalendar {
+	return &HolidayCalendar{
+		ID: uuid.NewV4().String(),
+	}
+}
+
+func (c *HolidayCalendar) Validate() error {
+	err := validate.Struct(c)
+	if err != nil {
+		return internal.WrapErr(internal.ErrValidationFailed, err.Error())
+	}
+
+	return nil
+}
+
+// Holiday is a whole day off, whatever the timezone it's observed in.
+type Holiday struct {
+	// Date is the day of the holiday, at midnight UTC.
+	Date time.Time `db:"date"`
+	Name string    `validate:"max=200" db:"name"`
+}
+
+// HolidayCalendarFormat is the format of a file listing holidays.
+type HolidayCalendarFormat string
+
+const (
+	// HolidayCalendarFormat_ICS is an RFC 5545 calendar, every VEVENT is a holiday. The VEVENTs
+	// must be all-day ones, their dates having the VALUE=DATE parameter.
+	HolidayCalendarFormat_ICS HolidayCalendarFormat = "ICS"
+	// HolidayCalendarFormat_JSON is an array of {"date": "2006-01-02", "name": "..."} objects.
+	HolidayCalendarFormat_JSON HolidayCalendarFormat = "JSON"
+)
+
+const (
+	// maxHolidayCalendarSize bounds the size of a file listing holidays.
+	maxHolidayCalendarSize = 1 << 20
+	// maxHolidayDays bounds the days of a single holiday, i.e: a VEVENT spanning several days.
+	maxHolidayDays = 31
+)
+
+// ParseHolidays reads the holidays listed by the file, ordered by date. The holidays falling on the
+// same day are merged into one.
+func ParseHolidays(format HolidayCalendarFormat, content string) ([]Holiday, error) {
+	if len(content) > maxHolidayCalendarSize {
+		return nil, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("holiday calendar larger than %d bytes", maxHolidayCalendarSize))
+	}
+
+	var holidays []Holiday
+	var err error
+	switch format {
+	case HolidayCalendarFormat_ICS:
+		holidays, err = parseHolidaysICS(content)
+	case HolidayCalendarFormat_JSON:
+		holidays, err = parseHolidaysJSON(content)
+	default:
+		return nil, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("invalid holiday calendar format %q", format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(holidays, func(a, b Holiday) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	var merged []Holiday
+	for _, h := range holidays {
+		if n := len(merged); n > 0 && merged[n-1].Date.Equal(h.Date) {
+			if h.Name != "" && !slices.Contains(strings.Split(merged[n-1].Name, ", "), h.Name) {
+				merged[n-1].Name = strings.TrimPrefix(merged[n-1].Name+", "+h.Name, ", ")
+			}
+			continue
+		}
+		merged = append(merged, h)
+	}
+	return merged, nil
+}
+
+func parseHolidaysJSON(content string) ([]Holiday, error) {
+	var entries []struct {
+		Date string `json:"date"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(content), &entries); err != nil {
+		return nil, internal.WrapErr(internal.ErrValidationFailed, "invalid JSON holiday calendar: "+err.Error())
+	}
+
+	holidays := make([]Holiday, len(entries))
+	for i, entry := range entries {
+		date, err := time.Parse(time.DateOnly, entry.Date)
+		if err != nil {
+			return nil, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("invalid holiday date %q", entry.Date))
+		}
+		holidays[i] = Holiday{Date: date, Name: entry.Name}
+	}
+	return holidays, nil
+}
+
+// parseHolidaysICS reads the all-day VEVENTs of the calendar, an event spanning several days, up to
+// maxHolidayDays, is a holiday on each of them. Recurring events aren't supported, holiday
+// calendars list every year.
+func parseHolidaysICS(content string) ([]Holiday, error) {
+	var holidays []Holiday
+	var inEvent bool
+	var start, end time.Time
+	var name string
+
+	for _, line := range unfoldICS(content) {
+		property, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		property, params, _ := strings.Cut(property, ";")
+
+		switch strings.ToUpper(property) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end, name = true, time.Time{}, time.Time{}, ""
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+
+			if start.IsZero() {
+				return nil, internal.WrapErr(internal.ErrValidationFailed, "invalid ICS holiday calendar: VEVENT without DTSTART")
+			}
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			if end.After(start.AddDate(0, 0, maxHolidayDays)) {
+				return nil, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("invalid ICS holiday calendar: a holiday can't last more than %d days", maxHolidayDays))
+			}
+			for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+				holidays = append(holidays, Holiday{Date: d, Name: name})
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			date, err := parseICSDate(value, params)
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(property, "DTSTART") {
+				start = date
+			} else {
+				end = date
+			}
+		case "SUMMARY":
+			if inEvent {
+				name = unescapeICS(value)
+			}
+		case "RRULE", "RDATE":
+			if inEvent {
+				return nil, internal.WrapErr(internal.ErrValidationFailed, "invalid ICS holiday calendar: recurring holidays aren't supported")
+			}
+		}
+	}
+
+	if len(holidays) == 0 && !strings.Contains(strings.ToUpper(content), "BEGIN:VCALENDAR") {
+		return nil, internal.WrapErr(internal.ErrValidationFailed, "invalid ICS holiday calendar: missing VCALENDAR")
+	}
+	return holidays, nil
+}
+
+// parseICSDate returns the day of a DATE value, at midnight UTC. The DATE-TIME values, of the
+// events not lasting all day, are rejected.
+func parseICSDate(value, params string) (time.Time, error) {
+	if !slices.ContainsFunc(strings.Split(params, ";"), func(p string) bool {
+		return strings.EqualFold(p, "VALUE=DATE")
+	}) {
+		return time.Time{}, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("invalid ICS holiday calendar: %q isn't the date of an all-day VEVENT", value))
+	}
+
+	date, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("invalid ICS date %q", value))
+	}
+	return date, nil
+}
+
+// unfoldICS splits the content into its logical lines, joining the lines folded by a leading
+// space or tab.
+func unfoldICS(content string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if n := len(lines); n > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[n-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func unescapeICS(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+// isHoliday reports whether t falls on one of the holidays, in the location of t.
+func isHoliday(holidays []Holiday, t time.Time) bool {
+	year, month, day := t.Date()
+	return slices.ContainsFunc(holidays, func(h Holiday) bool {
+		y, m, d := h.Date.Date()
+		return y == year && m == month && d == day
+	})
+}
+
+//go:generate go tool -modfile=../../go.tool.mod mockgen -destination=../mock/mock_holiday_calendar_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core HolidayCalendarRepository
+type HolidayCalendarRepository interface {
+	Store(ctx context.Context, c *HolidayCalendar) error
+	FindByID(ctx context.Context, id string) (*HolidayCalendar, error)
+}
@@ -0,0 +1,195 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseHolidays(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  core.HolidayCalendarFormat
+		content string
+		want    []core.Holiday
+		wantErr error
+	}{
+		{
+			name:   "ICS",
+			format: core.HolidayCalendarFormat_ICS,
+			content: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20220201\r\n" +
+				"DTEND;VALUE=DATE:20220203\r\n" +
+				"SUMMARY:Lunar New\r\n" +
+				"  Year\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20220101\r\n" +
+				"SUMMARY:New Year's Day\\, observed\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []core.Holiday{
+				{Date: date(2022, time.January, 1), Name: "New Year's Day, observed"},
+				{Date: date(2022, time.February, 1), Name: "Lunar New Year"},
+				{Date: date(2022, time.February, 2), Name: "Lunar New Year"},
+			},
+		},
+		{
+			name:    "JSON, merging the holidays of the same day",
+			format:  core.HolidayCalendarFormat_JSON,
+			content: `[{"date": "2022-12-25", "name": "Christmas Day"}, {"date": "2022-01-01", "name": "New Year's Day"}, {"date": "2022-12-25", "name": "Bank Holiday"}]`,
+			want: []core.Holiday{
+				{Date: date(2022, time.January, 1), Name: "New Year's Day"},
+				{Date: date(2022, time.December, 25), Name: "Christmas Day, Bank Holiday"},
+			},
+		},
+		{
+			name:    "invalid JSON date",
+			format:  core.HolidayCalendarFormat_JSON,
+			content: `[{"date": "25/12/2022", "name": "Christmas Day"}]`,
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name:    "recurring ICS holiday",
+			format:  core.HolidayCalendarFormat_ICS,
+			content: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20221225\nRRULE:FREQ=YEARLY\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name:    "ICS holiday not lasting all day",
+			format:  core.HolidayCalendarFormat_ICS,
+			content: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20221225T090000Z\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name:    "ICS holiday lasting too long",
+			format:  core.HolidayCalendarFormat_ICS,
+			content: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20000101\nDTEND;VALUE=DATE:99991231\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name:    "too large",
+			format:  core.HolidayCalendarFormat_JSON,
+			content: "[" + strings.Repeat(`{"date": "2022-12-25"},`, 1<<16) + `{"date": "2022-12-25"}]`,
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name:    "not an ICS file",
+			format:  core.HolidayCalendarFormat_ICS,
+			content: `[{"date": "2022-12-25"}]`,
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name:    "unknown format",
+			format:  "CSV",
+			content: "2022-12-25,Christmas Day",
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := core.ParseHolidays(tt.format, tt.content)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvent_Occurrences_SkipHolidays(t *testing.T) {
+	// Tuesday, 4 January 2022 06:00 in Asia/Jakarta, still Monday in UTC
+	start := time.Date(2022, 1, 3, 23, 0, 0, 0, time.UTC)
+	calendarID := "id-holidays"
+
+	event := core.Event{
+		ID:                "sync",
+		Timezone:          "Asia/Jakarta",
+		HolidayCalendarID: &calendarID,
+		SkipHolidays:      true,
+		// The holidays are days of the event's timezone.
+		Holidays: []core.Holiday{
+			{Date: date(2022, time.January, 11), Name: "Holiday"},
+			{Date: date(2022, time.January, 18), Name: "Holiday"},
+			{Date: date(2022, time.January, 25), Name: "Holiday"},
+		},
+		Schedules: []core.Schedule{
+			{
+				ID:                "sch1",
+				EventID:           "sync",
+				StartTime:         start.Unix(),
+				DurationInMinutes: 30,
+				RecurringType:     core.RecurringType_Custom,
+				RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1, WeekStart: time.Monday},
+				Exceptions: []core.OccurrenceException{
+					// Moved out of the holiday, the occurrence is kept.
+					{ScheduleID: "sch1", OriginalStart: start.AddDate(0, 0, 14).Unix(), StartTime: start.AddDate(0, 0, 15).Unix()},
+				},
+			},
+		},
+	}
+
+	from, to := start, start.AddDate(0, 0, 28)
+	list, err := event.Occurrences(from, to, core.MaxOccurrences)
+	require.NoError(t, err)
+
+	starts := make([]time.Time, len(list.Occurrences))
+	for i, occ := range list.Occurrences {
+		starts[i] = occ.Start.UTC()
+	}
+	assert.Equal(t, []time.Time{start, start.AddDate(0, 0, 15)}, starts)
+
+	t.Run("holidays aren't skipped unless asked", func(t *testing.T) {
+		event := event
+		event.SkipHolidays = false
+
+		list, err := event.Occurrences(from, to, core.MaxOccurrences)
+		require.NoError(t, err)
+		assert.Len(t, list.Occurrences, 4)
+	})
+}
+
+func TestEvent_Validate_SkipHolidays(t *testing.T) {
+	event := core.Event{
+		ID:           "sync",
+		Title:        "sync",
+		Description:  "sync",
+		Timezone:     "Asia/Jakarta",
+		SkipHolidays: true,
+		Schedules: []core.Schedule{
+			{ID: "sch1", EventID: "sync", StartTime: time.Now().Unix(), DurationInMinutes: 30},
+		},
+	}
+	assert.ErrorIs(t, event.Validate(), internal.ErrValidationFailed)
+}
+
+func TestAvailability_Unavailable_Holidays(t *testing.T) {
+	availability := core.Availability{
+		UserID:   1,
+		Timezone: "Asia/Jakarta",
+		Holidays: []core.Holiday{{Date: date(2022, time.January, 5), Name: "Holiday"}},
+	}
+
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	from := time.Date(2022, 1, 4, 0, 0, 0, 0, jakarta)
+	got, err := availability.Unavailable(from, from.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	assert.Equal(t, []core.BusyInterval{
+		{Start: time.Date(2022, 1, 5, 0, 0, 0, 0, jakarta), End: time.Date(2022, 1, 6, 0, 0, 0, 0, jakarta)},
+	}, got)
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"encoding/base64"
+	"strconv"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+// DefaultPageSize and MaxPageSize bound the number of items of a single page.
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// InvitationFilter selects the invitations of a user by their status and by the time of their
+// events. A zero From or To leaves the time range open on that side.
+type InvitationFilter struct {
+	UserID int32
+	// Statuses is every status when empty.
+	Statuses []InvitationStatus
+	From     time.Time
+	To       time.Time
+	Limit    int
+	Offset   int
+}
+
+// InvitationSummary is an invitation listed in the inbox of the attendee, along with its event.
+type InvitationSummary struct {
+	Invitation Invitation
+	Event      *Event
+	// NextOccurrence is the first occurrence of the event not over yet within the requested time
+	// range, nil when there's none.
+	NextOccurrence *Occurrence
+}
+
+type InvitationPage struct {
+	Summaries []InvitationSummary
+	// NextPageToken is the token of the following page, empty on the last page.
+	NextPageToken string
+}
+
+// NextOccurrence returns the first occurrence of the event ending after from and starting before
+// to, nil when there's none. A zero to looks for the occurrence without bound.
+func (e *Event) NextOccurrence(from, to time.Time) (*Occurrence, error) {
+	if to.IsZero() {
+		to = maxOccurrenceTime
+	}
+
+	list, err := e.Occurrences(from, to, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Occurrences) == 0 {
+		return nil, nil
+	}
+	return &list.Occurrences[0], nil
+}
+
+// PageToken returns the opaque token of the page starting at the offset.
+func PageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func pageOffset(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, internal.WrapErr(internal.ErrValidationFailed, "invalid page token")
+	}
+
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, internal.WrapErr(internal.ErrValidationFailed, "invalid page token")
+	}
+	return offset, nil
+}
@@ -1,9 +1,11 @@
 package core
 
 import (
-	"encoding/base64"
+	"context"
+	"strings"
 	"time"
 
+	"github.com/dzakaammar/event-scheduling-example/internal"
 	"github.com/satori/uuid"
 )
 
@@ -13,24 +15,197 @@ const (
 	InvitationStatus_Unknown InvitationStatus = iota
 	InvitationStatus_Confirmed
 	InvitationStatus_Declined
+	InvitationStatus_Tentative
+	// InvitationStatus_Waitlisted is a confirmation of an event already full, waiting for a
+	// confirmed attendee to leave.
+	InvitationStatus_Waitlisted
 )
 
+// isAttending tells whether the attendee is expected at the event: neither declined nor waitlisted.
+func (s InvitationStatus) isAttending() bool {
+	return s != InvitationStatus_Declined && s != InvitationStatus_Waitlisted
+}
+
 type Invitation struct {
-	ID        string           `validate:"required" db:"id"`
-	EventID   string           `validate:"required" db:"event_id"`
-	UserID    int32            `validate:"required" db:"user_id"`
-	Status    InvitationStatus `db:"status"`
-	Token     string           `validate:"required" db:"token"`
-	UpdatedAt *time.Time       `db:"updated_at"`
+	ID      string `validate:"required" db:"id"`
+	EventID string `validate:"required" db:"event_id"`
+	// UserID is the invited user, zero when the invitation is for a guest.
+	UserID int32 `validate:"required_without=Guest" db:"user_id"`
+	// Guest is the invited person outside of the users, nil when the invitation is for a user.
+	Guest  *Guest
+	Status InvitationStatus `db:"status"`
+	Role   AttendeeRole     `db:"role"`
+	// Permissions is what the attendee can do with the event besides responding to it.
+	Permissions AttendeePermissions
+	// TokenHash is the hash of the token of the invitation, the token itself is only known when
+	// it's issued.
+	TokenHash string     `db:"token_hash"`
+	UpdatedAt *time.Time `db:"updated_at"`
+	// Comment is the free text left by the attendee along with the response.
+	Comment string `validate:"max=1000" db:"comment"`
+	// Proposal is a new time the attendee proposed along with the response, if any.
+	Proposal *TimeProposal
+	// WaitlistedAt is when the attendee joined the waitlist, nil unless waitlisted.
+	WaitlistedAt *time.Time `db:"waitlisted_at"`
+	// GroupID is the group the attendee was invited through, nil when invited directly.
+	GroupID *string `db:"group_id"`
+	// OccurrenceResponses override the response for single occurrences of a recurring schedule.
+	OccurrenceResponses []OccurrenceResponse
+
+	// Token and TokenExpiresAt are set by IssueToken, to be sent to the attendee.
+	Token          string    `db:"-"`
+	TokenExpiresAt time.Time `db:"-"`
 }
 
 func NewInvitation(eventID string, userID int32) Invitation {
-	id := uuid.NewV4().String()
 	return Invitation{
-		ID:      id,
-		EventID: eventID,
-		UserID:  userID,
-		Status:  InvitationStatus_Unknown,
-		Token:   base64.StdEncoding.EncodeToString([]byte(id)), // use base64-encoded id for simplicity sake
+		ID:          uuid.NewV4().String(),
+		EventID:     eventID,
+		UserID:      userID,
+		Status:      InvitationStatus_Unknown,
+		Role:        AttendeeRole_Required,
+		Permissions: DefaultAttendeePermissions,
+	}
+}
+
+// NewGuestInvitation invites a guest to the event, identified by the email address.
+func NewGuestInvitation(eventID string, guest Guest) Invitation {
+	guest.Email = normalizeEmail(guest.Email)
+	inv := NewInvitation(eventID, 0)
+	inv.Guest = &guest
+	return inv
+}
+
+// Guest is an attendee who isn't one of the users, invited by email. The guest responds with the
+// token of the invitation like any attendee.
+type Guest struct {
+	Email string
+	Name  string
+}
+
+func (g *Guest) Validate() error {
+	if validate.Var(g.Email, "required,email,max=254") != nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid guest email "+g.Email)
+	}
+
+	if len(g.Name) > 200 {
+		return internal.WrapErr(internal.ErrValidationFailed, "the name of a guest must not exceed 200 characters")
 	}
+
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// IsFor tells whether the invitation is for the user, or for the guest with the email address when
+// the user ID is zero.
+func (i *Invitation) IsFor(userID int32, guestEmail string) bool {
+	if i.Guest != nil {
+		return userID == 0 && i.Guest.Email == normalizeEmail(guestEmail)
+	}
+	return userID != 0 && i.UserID == userID
+}
+
+// SameAttendee tells whether both invitations are for the same user or guest.
+func (i *Invitation) SameAttendee(other *Invitation) bool {
+	if other.Guest != nil {
+		return i.IsFor(0, other.Guest.Email)
+	}
+	return i.IsFor(other.UserID, "")
+}
+
+// IssueToken gives the invitation a new token expiring at expiresAt, revoking the previous one.
+func (i *Invitation) IssueToken(signer *InvitationTokenSigner, expiresAt time.Time) error {
+	token, err := signer.Issue(expiresAt)
+	if err != nil {
+		return err
+	}
+
+	i.Token = token
+	i.TokenHash = HashInvitationToken(token)
+	i.TokenExpiresAt = expiresAt
+	return nil
+}
+
+// StatusAt returns the response to the occurrence of the schedule starting at originalStart, the
+// response to the invitation unless the attendee answered that occurrence alone.
+func (i *Invitation) StatusAt(scheduleID string, originalStart time.Time) InvitationStatus {
+	for _, r := range i.OccurrenceResponses {
+		if r.ScheduleID == scheduleID && r.OriginalStart == originalStart.Unix() {
+			return r.Status
+		}
+	}
+	return i.Status
+}
+
+// RespondToOccurrence stores the response in the invitation, replacing the previous response to the
+// same occurrence.
+func (i *Invitation) RespondToOccurrence(resp OccurrenceResponse) {
+	for j, r := range i.OccurrenceResponses {
+		if r.ScheduleID == resp.ScheduleID && r.OriginalStart == resp.OriginalStart {
+			i.OccurrenceResponses[j] = resp
+			return
+		}
+	}
+	i.OccurrenceResponses = append(i.OccurrenceResponses, resp)
+}
+
+// OccurrenceResponse is the response of an attendee to a single occurrence of a recurring schedule,
+// identified by its original start time. It overrides the response to the invitation.
+type OccurrenceResponse struct {
+	InvitationID  string
+	ScheduleID    string
+	OriginalStart int64
+	Status        InvitationStatus
+	Comment       string
+	UpdatedAt     time.Time
+}
+
+// TimeProposal is a new time of one of the schedules of the event, proposed by an attendee to the
+// organizer. Accepting it moves the schedule, and every occurrence of a recurring one.
+type TimeProposal struct {
+	ScheduleID string
+	Start      time.Time
+	End        time.Time
+}
+
+func (p *TimeProposal) Validate() error {
+	if p.ScheduleID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid proposed schedule id")
+	}
+
+	if p.Start.IsZero() || p.End.Sub(p.Start) < time.Minute {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid proposed time range")
+	}
+
+	return nil
+}
+
+// Apply moves the schedule to the proposed time.
+func (p *TimeProposal) Apply(sch *Schedule) {
+	sch.StartTime = p.Start.Unix()
+	sch.DurationInMinutes = int64(p.End.Sub(p.Start) / time.Minute)
+}
+
+//go:generate go tool -modfile=../../go.tool.mod mockgen -destination=../mock/mock_invitation_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core InvitationRepository
+type InvitationRepository interface {
+	FindByTokenHash(ctx context.Context, hash string) (*Invitation, error)
+	// Respond stores a response within a transaction locking the event, so that its attendees
+	// respond one at a time. respond is given the event as read within the transaction, holding
+	// its capacity and its invitations, and returns the invitations to store: their status,
+	// comment, proposal, waitlist and update times.
+	Respond(ctx context.Context, eventID string, respond func(event *Event) ([]*Invitation, error)) error
+	// UpdateTokenHash stores the hash of the new token of the invitation.
+	UpdateTokenHash(ctx context.Context, inv *Invitation) error
+	// StoreOccurrenceResponse stores the response, replacing the previous response to the same
+	// occurrence.
+	StoreOccurrenceResponse(ctx context.Context, resp *OccurrenceResponse) error
+	// FindByFilter returns the invitations of the user matching the filter, the events created last
+	// first.
+	FindByFilter(ctx context.Context, filter InvitationFilter) ([]Invitation, error)
+	// SyncGroup stores the invitations of the users who joined a group and withdraws the ones of
+	// the users who left it, unless they answered them in the meantime.
+	SyncGroup(ctx context.Context, invited []Invitation, withdrawnIDs []string) error
 }
@@ -0,0 +1,87 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_Validate_Guests(t *testing.T) {
+	newEvent := func(invitations ...core.Invitation) *core.Event {
+		e := core.NewEvent("1")
+		e.Title = "planning"
+		e.Description = "planning"
+		e.Timezone = "UTC"
+		e.Schedules = []core.Schedule{{ID: "sch1", EventID: e.ID, StartTime: time.Now().Unix(), DurationInMinutes: 60}}
+		e.Invitations = invitations
+		return e
+	}
+
+	tests := []struct {
+		name    string
+		event   *core.Event
+		wantErr error
+	}{
+		{
+			name:  "OK",
+			event: newEvent(core.NewInvitation("id", 2), core.NewGuestInvitation("id", core.Guest{Email: "Jane@Example.com ", Name: "Jane"})),
+		},
+		{
+			name:    "Not OK - invalid email",
+			event:   newEvent(core.NewGuestInvitation("id", core.Guest{Email: "jane"})),
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - both a user and a guest",
+			event: newEvent(core.Invitation{
+				ID: "id", EventID: "id", UserID: 2, Guest: &core.Guest{Email: "jane@example.com"},
+			}),
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - guest invited twice",
+			event: newEvent(
+				core.NewGuestInvitation("id", core.Guest{Email: "jane@example.com"}),
+				core.NewGuestInvitation("id", core.Guest{Email: "JANE@example.com"}),
+			),
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.event.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEvent_Validate_NoAttendee(t *testing.T) {
+	e := core.NewEvent("1")
+	e.Title = "planning"
+	e.Description = "planning"
+	e.Timezone = "UTC"
+	e.Schedules = []core.Schedule{{ID: "sch1", EventID: e.ID, StartTime: time.Now().Unix(), DurationInMinutes: 60}}
+	e.Invitations = []core.Invitation{core.NewInvitation(e.ID, 0)}
+	assert.Error(t, e.Validate())
+}
+
+func TestInvitation_IsFor(t *testing.T) {
+	user := core.NewInvitation("id", 2)
+	guest := core.NewGuestInvitation("id", core.Guest{Email: "Jane@Example.com"})
+
+	assert.True(t, user.IsFor(2, ""))
+	assert.False(t, user.IsFor(3, ""))
+	assert.False(t, user.IsFor(0, "jane@example.com"))
+	assert.True(t, guest.IsFor(0, "JANE@example.com"))
+	assert.False(t, guest.IsFor(0, "john@example.com"))
+	assert.False(t, guest.IsFor(2, ""))
+	assert.False(t, user.SameAttendee(&guest))
+	assert.Equal(t, "jane@example.com", guest.Guest.Email)
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+// MinInvitationTokenSecretLength is the minimum length of the secrets signing the invitation
+// tokens, the size of an HMAC-SHA256 output.
+const MinInvitationTokenSecretLength = sha256.Size
+
+// invitationTokenNonceLength is the number of random bytes of a token, making every token unique
+// even when they expire at the same time.
+const invitationTokenNonceLength = 16
+
+// InvitationTokenKey is a secret signing the invitation tokens. Its ID is carried by the tokens it
+// signs, to verify them with the same key after a rotation.
+type InvitationTokenKey struct {
+	ID     string
+	Secret []byte
+}
+
+// InvitationTokenSigner issues the tokens of the invitations, signed with HMAC-SHA256 and expiring
+// at a given time. After a rotation, the tokens signed with the previous key stay valid until the
+// end of the grace period.
+type InvitationTokenSigner struct {
+	current            InvitationTokenKey
+	previous           *InvitationTokenKey
+	previousValidUntil time.Time
+}
+
+func NewInvitationTokenSigner(cfg internal.InvitationTokenConfig) (*InvitationTokenSigner, error) {
+	current, err := invitationTokenKey(cfg.KeyID, cfg.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &InvitationTokenSigner{current: current}
+	if cfg.PreviousKeyID == "" && cfg.PreviousSecret == "" {
+		return s, nil
+	}
+
+	previous, err := invitationTokenKey(cfg.PreviousKeyID, cfg.PreviousSecret)
+	if err != nil {
+		return nil, err
+	}
+	if previous.ID == current.ID {
+		return nil, fmt.Errorf("invalid invitation token config: the previous key id must differ from %q", current.ID)
+	}
+
+	rotatedAt, err := time.Parse(time.RFC3339, cfg.RotatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invitation token config: invalid rotation time %q", cfg.RotatedAt)
+	}
+
+	s.previous = &previous
+	s.previousValidUntil = rotatedAt.Add(cfg.GracePeriod)
+	return s, nil
+}
+
+func invitationTokenKey(id, secret string) (InvitationTokenKey, error) {
+	if id == "" || strings.Contains(id, ".") {
+		return InvitationTokenKey{}, fmt.Errorf("invalid invitation token config: invalid key id %q", id)
+	}
+	if len(secret) < MinInvitationTokenSecretLength {
+		return InvitationTokenKey{}, fmt.Errorf("invalid invitation token config: the secret of key %q must be at least %d bytes long", id, MinInvitationTokenSecretLength)
+	}
+	return InvitationTokenKey{ID: id, Secret: []byte(secret)}, nil
+}
+
+// Issue returns a new token expiring at expiresAt, signed with the current key. A token is made of
+// the key ID, the payload holding the expiry and a random nonce, and the signature of both.
+func (s *InvitationTokenSigner) Issue(expiresAt time.Time) (string, error) {
+	payload := make([]byte, 8+invitationTokenNonceLength)
+	binary.BigEndian.PutUint64(payload, uint64(expiresAt.Unix())) //nolint:gosec
+	if _, err := rand.Read(payload[8:]); err != nil {
+		return "", err
+	}
+
+	signed := s.current.ID + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign(s.current.Secret, signed)), nil
+}
+
+// Verify checks the token is signed by the current key, or by the previous one during the grace
+// period, and isn't expired at now.
+func (s *InvitationTokenSigner) Verify(token string, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return internal.WrapErr(internal.ErrInvalidToken, "malformed invitation token")
+	}
+
+	var key InvitationTokenKey
+	switch {
+	case parts[0] == s.current.ID:
+		key = s.current
+	case s.previous != nil && parts[0] == s.previous.ID && now.Before(s.previousValidUntil):
+		key = *s.previous
+	default:
+		return internal.WrapErr(internal.ErrInvalidToken, "invitation token signed by an unknown key")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(key.Secret, parts[0]+"."+parts[1])) {
+		return internal.WrapErr(internal.ErrInvalidToken, "invalid invitation token signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || len(payload) != 8+invitationTokenNonceLength {
+		return internal.WrapErr(internal.ErrInvalidToken, "malformed invitation token")
+	}
+	expiresAt := binary.BigEndian.Uint64(payload)
+	if expiresAt > math.MaxInt64 || !now.Before(time.Unix(int64(expiresAt), 0)) {
+		return internal.WrapErr(internal.ErrInvalidToken, "expired invitation token")
+	}
+	return nil
+}
+
+func sign(secret []byte, signed string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
+
+// HashInvitationToken returns the hash an invitation token is stored as, the token itself is only
+// known by the attendee.
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// InvitationTokenExpiry returns when the tokens of the invitations to the event expire: at the end
+// of its last occurrence, or ConflictHorizon after now when one of its schedules recurs forever, in
+// the timezone of the event.
+func (e *Event) InvitationTokenExpiry(now time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.Time{}, internal.WrapErr(internal.ErrInvalidTimezone, e.Timezone)
+	}
+
+	var end time.Time
+	for _, sch := range e.Schedules {
+		if !sch.RecurrenceRule.IsZero() && sch.RecurrenceRule.Count == 0 && sch.RecurrenceRule.Until == nil {
+			end = maxTime(end, now.Add(ConflictHorizon))
+			continue
+		}
+
+		start := time.Unix(sch.StartTime, 0)
+		end = maxTime(end, sch.EndTimeFrom(start))
+		for _, o := range sch.Occurrences(loc, start, maxOccurrenceTime, math.MaxInt) {
+			end = maxTime(end, o.End)
+		}
+	}
+	return end.In(loc), nil
+}
+
+// maxOccurrenceTime bounds the expansion of the schedules having an end.
+var maxOccurrenceTime = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
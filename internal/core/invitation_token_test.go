@@ -0,0 +1,180 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	currentSecret  = "current-invitation-token-secret-32b"
+	previousSecret = "previous-invitation-token-secret-32"
+)
+
+func TestNewInvitationTokenSigner(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     internal.InvitationTokenConfig
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			cfg:  internal.InvitationTokenConfig{KeyID: "2024", Secret: currentSecret},
+		},
+		{
+			name: "OK - rotated",
+			cfg: internal.InvitationTokenConfig{
+				KeyID: "2024", Secret: currentSecret,
+				PreviousKeyID: "2023", PreviousSecret: previousSecret,
+				RotatedAt: "2024-01-01T00:00:00Z", GracePeriod: 24 * time.Hour,
+			},
+		},
+		{
+			name:    "Not OK - short secret",
+			cfg:     internal.InvitationTokenConfig{KeyID: "2024", Secret: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - missing key id",
+			cfg:     internal.InvitationTokenConfig{Secret: currentSecret},
+			wantErr: true,
+		},
+		{
+			name: "Not OK - same key ids",
+			cfg: internal.InvitationTokenConfig{
+				KeyID: "2024", Secret: currentSecret,
+				PreviousKeyID: "2024", PreviousSecret: previousSecret,
+				RotatedAt: "2024-01-01T00:00:00Z",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not OK - missing rotation time",
+			cfg: internal.InvitationTokenConfig{
+				KeyID: "2024", Secret: currentSecret,
+				PreviousKeyID: "2023", PreviousSecret: previousSecret,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := core.NewInvitationTokenSigner(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestInvitationTokenSigner_Verify(t *testing.T) {
+	now := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	previous, err := core.NewInvitationTokenSigner(internal.InvitationTokenConfig{KeyID: "2023", Secret: previousSecret})
+	require.NoError(t, err)
+	rotated, err := core.NewInvitationTokenSigner(internal.InvitationTokenConfig{
+		KeyID: "2024", Secret: currentSecret,
+		PreviousKeyID: "2023", PreviousSecret: previousSecret,
+		RotatedAt: "2024-01-01T00:00:00Z", GracePeriod: 48 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	current, err := rotated.Issue(expiresAt)
+	require.NoError(t, err)
+	old, err := previous.Issue(expiresAt)
+	require.NoError(t, err)
+	forged, err := core.NewInvitationTokenSigner(internal.InvitationTokenConfig{KeyID: "2024", Secret: strings.Repeat("x", 32)})
+	require.NoError(t, err)
+	forgedToken, err := forged.Issue(expiresAt)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		now     time.Time
+		wantErr bool
+	}{
+		{name: "OK", token: current, now: now},
+		{name: "OK - previous key during the grace period", token: old, now: now},
+		{name: "Not OK - previous key after the grace period", token: old, now: now.Add(24 * time.Hour), wantErr: true},
+		{name: "Not OK - expired", token: current, now: expiresAt, wantErr: true},
+		{name: "Not OK - forged", token: forgedToken, now: now, wantErr: true},
+		{name: "Not OK - tampered", token: strings.Replace(current, ".", ".A", 1), now: now, wantErr: true},
+		{name: "Not OK - malformed", token: "token", now: now, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rotated.Verify(tt.token, tt.now)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, internal.ErrInvalidToken)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	other, err := rotated.Issue(expiresAt)
+	require.NoError(t, err)
+	assert.NotEqual(t, current, other)
+	assert.NotEqual(t, core.HashInvitationToken(current), core.HashInvitationToken(other))
+}
+
+func TestEvent_InvitationTokenExpiry(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule core.Schedule
+		want     time.Time
+	}{
+		{
+			name:     "single occurrence",
+			schedule: core.Schedule{ID: "sch1", StartTime: start.Unix(), DurationInMinutes: 60},
+			want:     start.Add(time.Hour),
+		},
+		{
+			name: "last occurrence of the series",
+			schedule: core.Schedule{
+				ID: "sch1", StartTime: start.Unix(), DurationInMinutes: 60,
+				RecurrenceRule: core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1, Count: 5}, Count: 5,
+			},
+			want: start.AddDate(0, 0, 4).Add(time.Hour),
+		},
+		{
+			name: "last occurrence moved later",
+			schedule: core.Schedule{
+				ID: "sch1", StartTime: start.Unix(), DurationInMinutes: 60,
+				RecurrenceRule: core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1, Count: 2}, Count: 2,
+				Exceptions: []core.OccurrenceException{
+					{ScheduleID: "sch1", OriginalStart: start.AddDate(0, 0, 1).Unix(), StartTime: start.AddDate(0, 0, 3).Unix()},
+				},
+			},
+			want: start.AddDate(0, 0, 3).Add(time.Hour),
+		},
+		{
+			name: "recurring forever",
+			schedule: core.Schedule{
+				ID: "sch1", StartTime: start.Unix(), DurationInMinutes: 60,
+				RecurrenceRule: core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1},
+			},
+			want: now.Add(core.ConflictHorizon),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &core.Event{Timezone: "UTC", Schedules: []core.Schedule{tt.schedule}}
+			got, err := e.InvitationTokenExpiry(now)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
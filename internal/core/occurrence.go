@@ -0,0 +1,446 @@
+package core
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+// MaxOccurrences is the hard cap of occurrences returned by a single expansion.
+const MaxOccurrences = 500
+
+// maxRecurrencePeriods stops the expansion of rules that rarely or never produce an occurrence,
+// i.e: "FREQ=YEARLY;BYMONTHDAY=31;BYDAY=MO".
+const maxRecurrencePeriods = 50000
+
+type Occurrence struct {
+	ScheduleID string
+	EventID    string
+	// OriginalStart is the start time given by the recurrence rule, it identifies the occurrence
+	// even after an exception moves it.
+	OriginalStart time.Time
+	Start         time.Time
+	End           time.Time
+	IsFullDay     bool
+	Title         string
+	Description   string
+	// IsModified is true when an exception overrides the occurrence.
+	IsModified bool
+	// BufferBefore and BufferAfter are the buffers of the schedule, nil when they are left to the
+	// default buffers of the attendees.
+	BufferBefore *time.Duration
+	BufferAfter  *time.Duration
+	// Attendees is the users invited to the occurrence, with their response to it.
+	Attendees []Attendee
+}
+
+// Attendee is a user invited to an occurrence. Status is the response to the occurrence alone when
+// there's one, the response to the invitation otherwise.
+type Attendee struct {
+	// UserID is zero for a guest.
+	UserID int32
+	Guest  *Guest
+	Status InvitationStatus
+}
+
+// IsAttendedBy tells whether the user is invited to the occurrence without having declined it, nor
+// being waitlisted.
+func (o *Occurrence) IsAttendedBy(userID int32) bool {
+	return slices.ContainsFunc(o.Attendees, func(a Attendee) bool {
+		return a.Guest == nil && a.UserID == userID && a.Status.isAttending()
+	})
+}
+
+// attendedBy returns the occurrences the user attends.
+func attendedBy(occurrences []Occurrence, userID int32) []Occurrence {
+	var result []Occurrence
+	for _, o := range occurrences {
+		if o.IsAttendedBy(userID) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+type OccurrenceList struct {
+	Occurrences []Occurrence
+	// Truncated is true when there are more occurrences in the requested window than returned.
+	Truncated bool
+}
+
+// Occurrences expands every schedule of the event into the occurrences overlapping [from, to),
+// ordered by their start time and capped to the given limit. The occurrences falling on a holiday
+// are skipped when the event skips holidays, unless an exception overrides them.
+func (e *Event) Occurrences(from, to time.Time, limit int) (*OccurrenceList, error) {
+	loc, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return nil, internal.WrapErr(internal.ErrInvalidTimezone, e.Timezone)
+	}
+
+	var holidays []Holiday
+	if e.SkipHolidays {
+		holidays = e.Holidays
+	}
+
+	var occurrences []Occurrence
+	for _, sch := range e.Schedules {
+		for _, o := range sch.occurrences(loc, from, to, limit+1, holidays) {
+			if o.Title == "" {
+				o.Title = e.Title
+			}
+			if o.Description == "" {
+				o.Description = e.Description
+			}
+			o.Attendees = e.attendeesOf(o)
+			occurrences = append(occurrences, o)
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Start.Before(occurrences[j].Start)
+	})
+
+	list := &OccurrenceList{Occurrences: occurrences}
+	if len(occurrences) > limit {
+		list.Occurrences = occurrences[:limit]
+		list.Truncated = true
+	}
+	return list, nil
+}
+
+func (e *Event) attendeesOf(o Occurrence) []Attendee {
+	attendees := make([]Attendee, len(e.Invitations))
+	for i, inv := range e.Invitations {
+		attendees[i] = Attendee{UserID: inv.UserID, Guest: inv.Guest, Status: inv.StatusAt(o.ScheduleID, o.OriginalStart)}
+	}
+	return attendees
+}
+
+// Occurrences expands the schedule in the given location into, at most, limit occurrences
+// overlapping [from, to), with its exceptions applied.
+func (s *Schedule) Occurrences(loc *time.Location, from, to time.Time, limit int) []Occurrence {
+	return s.occurrences(loc, from, to, limit, nil)
+}
+
+// occurrences expands the schedule like Occurrences, skipping the occurrences starting on one of
+// the holidays, in the given location, when no exception overrides them.
+func (s *Schedule) occurrences(loc *time.Location, from, to time.Time, limit int, holidays []Holiday) []Occurrence {
+	exceptions := make(map[int64]*OccurrenceException, len(s.Exceptions))
+	horizon := to
+	for i := range s.Exceptions {
+		exc := &s.Exceptions[i]
+		exceptions[exc.OriginalStart] = exc
+
+		// An occurrence after the window can be moved back into it.
+		if h := to.Add(-exc.shift()); h.After(horizon) {
+			horizon = h
+		}
+	}
+
+	before, after := minutes(s.BufferBeforeInMinutes), minutes(s.BufferAfterInMinutes)
+
+	var occurrences []Occurrence
+	s.eachStart(loc, func(start time.Time) bool {
+		if !start.Before(horizon) {
+			return false
+		}
+
+		occ := Occurrence{
+			ScheduleID:    s.ID,
+			EventID:       s.EventID,
+			OriginalStart: start,
+			Start:         start,
+			End:           s.EndTimeFrom(start),
+			IsFullDay:     s.IsFullDay,
+			BufferBefore:  before,
+			BufferAfter:   after,
+		}
+		if exc, ok := exceptions[start.Unix()]; ok {
+			if exc.IsCancelled {
+				return true
+			}
+			occ = exc.apply(occ)
+		} else if isHoliday(holidays, start) {
+			return true
+		}
+
+		if occ.Start.Before(to) && occ.End.After(from) {
+			occurrences = append(occurrences, occ)
+		}
+		return len(occurrences) < limit
+	})
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Start.Before(occurrences[j].Start)
+	})
+	return occurrences
+}
+
+// HasOccurrenceAt reports whether the recurrence rule of the schedule produces an occurrence
+// starting at t, regardless of its exceptions.
+func (s *Schedule) HasOccurrenceAt(loc *time.Location, t time.Time) bool {
+	found := false
+	s.eachStart(loc, func(start time.Time) bool {
+		found = start.Equal(t)
+		return start.Before(t)
+	})
+	return found
+}
+
+// eachStart calls fn with the start time of every occurrence of the schedule, in order,
+// until fn returns false or the recurrence ends.
+func (s *Schedule) eachStart(loc *time.Location, fn func(time.Time) bool) {
+	dtstart := time.Unix(s.StartTime, 0).In(loc)
+	if s.RecurrenceRule.IsZero() {
+		fn(dtstart)
+		return
+	}
+
+	s.RecurrenceRule.each(dtstart, fn)
+}
+
+func (r RecurrenceRule) each(dtstart time.Time, fn func(time.Time) bool) {
+	emitted := 0
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		for _, date := range r.candidates(dtstart, period) {
+			start := wallClock(date, dtstart)
+			if start.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && start.After(*r.Until) {
+				return
+			}
+			if !fn(start) {
+				return
+			}
+
+			emitted++
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// wallClock returns the instant the date reaches the local time of day of dtstart, in the location
+// of dtstart. Following RFC 5545, a local time skipped by a DST transition (spring-forward gap) is
+// interpreted with the UTC offset before the gap, so it is moved forward by the length of the gap,
+// and a local time occurring twice (fall-back overlap) refers to its first occurrence.
+func wallClock(date, dtstart time.Time) time.Time {
+	loc := dtstart.Location()
+	naive := time.Date(date.Year(), date.Month(), date.Day(),
+		dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, time.UTC)
+
+	// The offsets in effect half a day before and after the wall-clock time are the only ones
+	// that could apply to it, as long as a location doesn't change its offset twice within a day.
+	_, before := naive.Add(-12 * time.Hour).In(loc).Zone()
+	_, after := naive.Add(12 * time.Hour).In(loc).Zone()
+	withBefore := naive.Add(-time.Duration(before) * time.Second)
+	withAfter := naive.Add(-time.Duration(after) * time.Second)
+
+	switch {
+	case isWallClock(withBefore, naive, loc):
+		// Either the only valid instant, or the first of the two in an overlap.
+		return withBefore.In(loc)
+	case isWallClock(withAfter, naive, loc):
+		return withAfter.In(loc)
+	default:
+		// The local time doesn't exist, shift it by the offset before the gap.
+		return withBefore.In(loc)
+	}
+}
+
+func isWallClock(t, naive time.Time, loc *time.Location) bool {
+	local := t.In(loc)
+	return local.Year() == naive.Year() && local.YearDay() == naive.YearDay() &&
+		local.Hour() == naive.Hour() && local.Minute() == naive.Minute() && local.Second() == naive.Second()
+}
+
+// candidates returns the dates of the n-th period of the rule, as UTC midnights so the calendar
+// arithmetic isn't affected by the location of dtstart.
+func (r RecurrenceRule) candidates(dtstart time.Time, n int) []time.Time {
+	anchor := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, time.UTC)
+	step := n * r.Interval
+
+	var dates []time.Time
+	switch r.Freq {
+	case Frequency_Daily:
+		dates = r.daily(anchor.AddDate(0, 0, step))
+	case Frequency_Weekly:
+		dates = r.weekly(anchor, step)
+	case Frequency_Monthly:
+		dates = r.monthly(anchor, anchor.AddDate(0, 0, 1-anchor.Day()).AddDate(0, step, 0))
+	case Frequency_Yearly:
+		dates = r.yearly(anchor, anchor.Year()+step)
+	}
+
+	if len(r.ByMonth) > 0 && r.Freq != Frequency_Yearly {
+		dates = slices.DeleteFunc(dates, func(d time.Time) bool {
+			return !slices.Contains(r.ByMonth, int(d.Month()))
+		})
+	}
+
+	return r.setPos(dates)
+}
+
+func (r RecurrenceRule) daily(date time.Time) []time.Time {
+	if len(r.ByMonthDay) > 0 && !slices.Contains(monthDays(date.AddDate(0, 0, 1-date.Day()), r.ByMonthDay), date) {
+		return nil
+	}
+	return r.limit([]time.Time{date})
+}
+
+func (r RecurrenceRule) weekly(anchor time.Time, step int) []time.Time {
+	offset := (int(anchor.Weekday()) - int(r.WeekStart) + 7) % 7
+	weekStart := anchor.AddDate(0, 0, 7*step-offset)
+
+	if len(r.ByDay) == 0 {
+		return []time.Time{weekStart.AddDate(0, 0, offset)}
+	}
+
+	var dates []time.Time
+	for i := range 7 {
+		date := weekStart.AddDate(0, 0, i)
+		if r.hasWeekday(date.Weekday()) {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+func (r RecurrenceRule) monthly(anchor, month time.Time) []time.Time {
+	switch {
+	case len(r.ByMonthDay) > 0:
+		return r.limit(monthDays(month, r.ByMonthDay))
+	case len(r.ByDay) > 0:
+		return weekdaysIn(month, month.AddDate(0, 1, 0), r.ByDay)
+	default:
+		return monthDays(month, []int{anchor.Day()})
+	}
+}
+
+func (r RecurrenceRule) yearly(anchor time.Time, year int) []time.Time {
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	if len(r.ByMonth) > 0 {
+		// BYMONTH expands the year into its months, then the other rule parts apply within each month.
+		var dates []time.Time
+		for _, m := range slices.Sorted(slices.Values(r.ByMonth)) {
+			month := time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.UTC)
+			switch {
+			case len(r.ByMonthDay) > 0:
+				dates = append(dates, r.limit(monthDays(month, r.ByMonthDay))...)
+			case len(r.ByDay) > 0:
+				dates = append(dates, weekdaysIn(month, month.AddDate(0, 1, 0), r.ByDay)...)
+			default:
+				dates = append(dates, monthDays(month, []int{anchor.Day()})...)
+			}
+		}
+		return slices.Compact(dates)
+	}
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		var dates []time.Time
+		for m := range 12 {
+			dates = append(dates, monthDays(yearStart.AddDate(0, m, 0), r.ByMonthDay)...)
+		}
+		return r.limit(dates)
+	case len(r.ByDay) > 0:
+		return weekdaysIn(yearStart, yearStart.AddDate(1, 0, 0), r.ByDay)
+	default:
+		return monthDays(time.Date(year, anchor.Month(), 1, 0, 0, 0, 0, time.UTC), []int{anchor.Day()})
+	}
+}
+
+// limit drops the dates that don't match the BYDAY rule part, when the rule part isn't used to
+// expand the period.
+func (r RecurrenceRule) limit(dates []time.Time) []time.Time {
+	if len(r.ByDay) == 0 {
+		return dates
+	}
+	return slices.DeleteFunc(dates, func(d time.Time) bool {
+		return !r.hasWeekday(d.Weekday())
+	})
+}
+
+func (r RecurrenceRule) setPos(dates []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 || len(dates) == 0 {
+		return dates
+	}
+
+	var selected []time.Time
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(dates) + pos
+		}
+		if i >= 0 && i < len(dates) && !slices.Contains(selected, dates[i]) {
+			selected = append(selected, dates[i])
+		}
+	}
+	slices.SortFunc(selected, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	return selected
+}
+
+func (r RecurrenceRule) hasWeekday(wd time.Weekday) bool {
+	return slices.ContainsFunc(r.ByDay, func(w WeekdayNum) bool {
+		return w.Weekday == wd
+	})
+}
+
+// monthDays returns the given days of the month, counting from the end of the month for negative
+// days. Days the month doesn't have are skipped.
+func monthDays(month time.Time, days []int) []time.Time {
+	lastDay := month.AddDate(0, 1, -1).Day()
+
+	var dates []time.Time
+	for _, d := range days {
+		if d < 0 {
+			d = lastDay + d + 1
+		}
+		if d < 1 || d > lastDay {
+			continue
+		}
+		date := month.AddDate(0, 0, d-1)
+		if !slices.Contains(dates, date) {
+			dates = append(dates, date)
+		}
+	}
+	slices.SortFunc(dates, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	return dates
+}
+
+// weekdaysIn returns the dates within [start, end) matching the BYDAY entries, where a position
+// selects the n-th (or n-th last) matching weekday of the range.
+func weekdaysIn(start, end time.Time, byDay []WeekdayNum) []time.Time {
+	var dates []time.Time
+	for _, wd := range byDay {
+		var matches []time.Time
+		for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+			if d.Weekday() == wd.Weekday {
+				matches = append(matches, d)
+			}
+		}
+
+		switch {
+		case wd.Position > 0 && wd.Position <= len(matches):
+			dates = append(dates, matches[wd.Position-1])
+		case wd.Position < 0 && -wd.Position <= len(matches):
+			dates = append(dates, matches[len(matches)+wd.Position])
+		case wd.Position == 0:
+			dates = append(dates, matches...)
+		}
+	}
+
+	slices.SortFunc(dates, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	return slices.Compact(dates)
+}
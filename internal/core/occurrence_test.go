@@ -0,0 +1,516 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Occurrences(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	// Tuesday, 4 January 2022 09:00 in Asia/Jakarta
+	start := time.Date(2022, 1, 4, 9, 0, 0, 0, jakarta)
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, jakarta)
+	to := time.Date(2022, 3, 1, 0, 0, 0, 0, jakarta)
+
+	tests := []struct {
+		name  string
+		rule  string
+		from  time.Time
+		to    time.Time
+		limit int
+		want  []string
+	}{
+		{
+			name:  "non-recurring",
+			rule:  "",
+			from:  from,
+			to:    to,
+			limit: core.MaxOccurrences,
+			want:  []string{"2022-01-04T09:00:00+07:00"},
+		},
+		{
+			name:  "every other tuesday",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+			from:  from,
+			to:    time.Date(2022, 2, 10, 0, 0, 0, 0, jakarta),
+			limit: core.MaxOccurrences,
+			want: []string{
+				"2022-01-04T09:00:00+07:00",
+				"2022-01-18T09:00:00+07:00",
+				"2022-02-01T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "tuesday and thursday, limited by count",
+			rule:  "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=3",
+			from:  from,
+			to:    to,
+			limit: core.MaxOccurrences,
+			want: []string{
+				"2022-01-04T09:00:00+07:00",
+				"2022-01-06T09:00:00+07:00",
+				"2022-01-11T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "first monday of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=1MO",
+			from:  from,
+			to:    to,
+			limit: core.MaxOccurrences,
+			want: []string{
+				"2022-02-07T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "last weekday of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			from:  from,
+			to:    time.Date(2022, 5, 1, 0, 0, 0, 0, jakarta),
+			limit: core.MaxOccurrences,
+			want: []string{
+				"2022-01-31T09:00:00+07:00",
+				"2022-02-28T09:00:00+07:00",
+				"2022-03-31T09:00:00+07:00",
+				"2022-04-29T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "daily until",
+			rule:  "FREQ=DAILY;UNTIL=20220106T020000Z",
+			from:  from,
+			to:    to,
+			limit: core.MaxOccurrences,
+			want: []string{
+				"2022-01-04T09:00:00+07:00",
+				"2022-01-05T09:00:00+07:00",
+				"2022-01-06T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "daily within the window",
+			rule:  "FREQ=DAILY",
+			from:  time.Date(2022, 2, 1, 9, 15, 0, 0, jakarta),
+			to:    time.Date(2022, 2, 3, 0, 0, 0, 0, jakarta),
+			limit: core.MaxOccurrences,
+			want: []string{
+				"2022-02-01T09:00:00+07:00",
+				"2022-02-02T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "daily capped by limit",
+			rule:  "FREQ=DAILY",
+			from:  from,
+			to:    to,
+			limit: 2,
+			want: []string{
+				"2022-01-04T09:00:00+07:00",
+				"2022-01-05T09:00:00+07:00",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := core.ParseRecurrenceRule(tt.rule)
+			require.NoError(t, err)
+
+			sch := core.Schedule{
+				ID:                "sch1",
+				EventID:           "event1",
+				StartTime:         start.Unix(),
+				DurationInMinutes: 30,
+				RecurrenceRule:    rule,
+			}
+
+			got := sch.Occurrences(jakarta, tt.from, tt.to, tt.limit)
+			starts := make([]string, len(got))
+			for i, o := range got {
+				starts[i] = o.Start.Format(time.RFC3339)
+				assert.Equal(t, 30*time.Minute, o.End.Sub(o.Start))
+				assert.Equal(t, "sch1", o.ScheduleID)
+			}
+			assert.Equal(t, tt.want, starts)
+		})
+	}
+}
+
+func TestSchedule_Occurrences_MonthlyAndYearly(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		start string
+		rt    core.RecurringType
+		rule  string
+		to    time.Time
+		want  []string
+	}{
+		{
+			name:  "monthly preset on the 31st",
+			start: "2022-01-31T09:00:00+07:00",
+			rt:    core.RecurringType_Monthly,
+			to:    time.Date(2022, 5, 1, 0, 0, 0, 0, jakarta),
+			want: []string{
+				"2022-01-31T09:00:00+07:00",
+				"2022-02-28T09:00:00+07:00",
+				"2022-03-31T09:00:00+07:00",
+				"2022-04-30T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "monthly preset on the 30th",
+			start: "2024-01-30T09:00:00+07:00",
+			rt:    core.RecurringType_Monthly,
+			to:    time.Date(2024, 4, 1, 0, 0, 0, 0, jakarta),
+			want: []string{
+				"2024-01-30T09:00:00+07:00",
+				"2024-02-29T09:00:00+07:00",
+				"2024-03-30T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "monthly rule on the 31st skips short months",
+			start: "2022-01-31T09:00:00+07:00",
+			rule:  "FREQ=MONTHLY",
+			to:    time.Date(2022, 5, 1, 0, 0, 0, 0, jakarta),
+			want: []string{
+				"2022-01-31T09:00:00+07:00",
+				"2022-03-31T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "quarterly on the last friday",
+			start: "2022-01-28T09:00:00+07:00",
+			rule:  "FREQ=MONTHLY;INTERVAL=3;BYDAY=-1FR",
+			to:    time.Date(2023, 1, 1, 0, 0, 0, 0, jakarta),
+			want: []string{
+				"2022-01-28T09:00:00+07:00",
+				"2022-04-29T09:00:00+07:00",
+				"2022-07-29T09:00:00+07:00",
+				"2022-10-28T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "yearly preset on february 29th",
+			start: "2024-02-29T09:00:00+07:00",
+			rt:    core.RecurringType_Yearly,
+			to:    time.Date(2029, 1, 1, 0, 0, 0, 0, jakarta),
+			want: []string{
+				"2024-02-29T09:00:00+07:00",
+				"2025-02-28T09:00:00+07:00",
+				"2026-02-28T09:00:00+07:00",
+				"2027-02-28T09:00:00+07:00",
+				"2028-02-29T09:00:00+07:00",
+			},
+		},
+		{
+			name:  "yearly on the last friday of march and september",
+			start: "2022-03-25T09:00:00+07:00",
+			rule:  "FREQ=YEARLY;BYMONTH=3,9;BYDAY=-1FR",
+			to:    time.Date(2024, 1, 1, 0, 0, 0, 0, jakarta),
+			want: []string{
+				"2022-03-25T09:00:00+07:00",
+				"2022-09-30T09:00:00+07:00",
+				"2023-03-31T09:00:00+07:00",
+				"2023-09-29T09:00:00+07:00",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, err := time.Parse(time.RFC3339, tt.start)
+			require.NoError(t, err)
+
+			sch, err := core.NewSchedule("event1", tt.start, st.Add(time.Hour).Format(time.RFC3339), false, tt.rt, tt.rule, "", 0)
+			require.NoError(t, err)
+
+			got := sch.Occurrences(jakarta, st, tt.to, core.MaxOccurrences)
+			starts := make([]string, len(got))
+			for i, o := range got {
+				starts[i] = o.Start.Format(time.RFC3339)
+			}
+			assert.Equal(t, tt.want, starts)
+		})
+	}
+}
+
+func TestEvent_Occurrences(t *testing.T) {
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	event := core.Event{
+		ID:       "event1",
+		Timezone: "Asia/Jakarta",
+		Schedules: []core.Schedule{
+			{
+				ID:                "weekly",
+				StartTime:         start.Unix(),
+				DurationInMinutes: 60,
+				RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1},
+			},
+			{
+				ID:                "once",
+				StartTime:         start.Add(24 * time.Hour).Unix(),
+				DurationInMinutes: 60,
+			},
+		},
+	}
+
+	list, err := event.Occurrences(start, start.AddDate(0, 0, 21), 3)
+	require.NoError(t, err)
+	assert.True(t, list.Truncated)
+	require.Len(t, list.Occurrences, 3)
+	assert.Equal(t, "weekly", list.Occurrences[0].ScheduleID)
+	assert.Equal(t, "once", list.Occurrences[1].ScheduleID)
+	assert.Equal(t, "weekly", list.Occurrences[2].ScheduleID)
+
+	event.Timezone = "invalid"
+	_, err = event.Occurrences(start, start.AddDate(0, 0, 21), 3)
+	assert.Error(t, err)
+}
+
+func TestEvent_Occurrences_Attendees(t *testing.T) {
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	event := core.Event{
+		ID:       "event1",
+		Timezone: "UTC",
+		Schedules: []core.Schedule{
+			{
+				ID:                "weekly",
+				StartTime:         start.Unix(),
+				DurationInMinutes: 60,
+				RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1},
+			},
+		},
+		Invitations: []core.Invitation{
+			{UserID: 1, Status: core.InvitationStatus_Confirmed},
+			{
+				UserID: 2, Status: core.InvitationStatus_Confirmed,
+				OccurrenceResponses: []core.OccurrenceResponse{
+					{ScheduleID: "weekly", OriginalStart: start.AddDate(0, 0, 7).Unix(), Status: core.InvitationStatus_Declined},
+				},
+			},
+		},
+	}
+
+	list, err := event.Occurrences(start, start.AddDate(0, 0, 14), core.MaxOccurrences)
+	require.NoError(t, err)
+	require.Len(t, list.Occurrences, 2)
+
+	assert.Equal(t, []core.Attendee{
+		{UserID: 1, Status: core.InvitationStatus_Confirmed},
+		{UserID: 2, Status: core.InvitationStatus_Confirmed},
+	}, list.Occurrences[0].Attendees)
+	assert.True(t, list.Occurrences[0].IsAttendedBy(2))
+
+	assert.Equal(t, []core.Attendee{
+		{UserID: 1, Status: core.InvitationStatus_Confirmed},
+		{UserID: 2, Status: core.InvitationStatus_Declined},
+	}, list.Occurrences[1].Attendees)
+	assert.False(t, list.Occurrences[1].IsAttendedBy(2))
+	assert.False(t, list.Occurrences[1].IsAttendedBy(3))
+}
+
+func TestSchedule_Occurrences_Exceptions(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	start := time.Date(2022, 1, 4, 9, 0, 0, 0, jakarta)
+	sch := core.Schedule{
+		ID:                "daily",
+		StartTime:         start.Unix(),
+		DurationInMinutes: 60,
+		RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1, Count: 5},
+		Exceptions: []core.OccurrenceException{
+			{ScheduleID: "daily", OriginalStart: start.AddDate(0, 0, 1).Unix(), IsCancelled: true},
+			{
+				// The last occurrence is moved before the window's end, and gets a new title.
+				ScheduleID:        "daily",
+				OriginalStart:     start.AddDate(0, 0, 4).Unix(),
+				StartTime:         start.AddDate(0, 0, 2).Add(3 * time.Hour).Unix(),
+				DurationInMinutes: 30,
+				Title:             "moved",
+			},
+		},
+	}
+
+	got := sch.Occurrences(jakarta, start, start.AddDate(0, 0, 3), core.MaxOccurrences)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, start, got[0].Start)
+	assert.False(t, got[0].IsModified)
+
+	assert.Equal(t, start.AddDate(0, 0, 2), got[1].Start)
+
+	assert.Equal(t, start.AddDate(0, 0, 2).Add(3*time.Hour), got[2].Start)
+	assert.Equal(t, start.AddDate(0, 0, 2).Add(3*time.Hour+30*time.Minute), got[2].End)
+	assert.Equal(t, start.AddDate(0, 0, 4), got[2].OriginalStart)
+	assert.Equal(t, "moved", got[2].Title)
+	assert.True(t, got[2].IsModified)
+
+	assert.True(t, sch.HasOccurrenceAt(jakarta, start.AddDate(0, 0, 1)))
+	assert.False(t, sch.HasOccurrenceAt(jakarta, start.AddDate(0, 0, 5)))
+}
+
+func TestSchedule_EndBefore(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	start := time.Date(2022, 1, 4, 9, 0, 0, 0, jakarta)
+	split := start.AddDate(0, 0, 14)
+
+	t.Run("with count", func(t *testing.T) {
+		sch := core.Schedule{
+			StartTime:         start.Unix(),
+			DurationInMinutes: 60,
+			RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1, Count: 10, WeekStart: time.Monday},
+		}
+
+		assert.Equal(t, 2, sch.EndBefore(jakarta, split))
+		assert.Equal(t, "FREQ=WEEKLY;COUNT=2", sch.RecurrenceRule.String())
+		assert.Len(t, sch.Occurrences(jakarta, start, start.AddDate(1, 0, 0), core.MaxOccurrences), 2)
+	})
+
+	t.Run("without count", func(t *testing.T) {
+		sch := core.Schedule{
+			StartTime:         start.Unix(),
+			DurationInMinutes: 60,
+			RecurringType:     core.RecurringType_Every_Week,
+			RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1, WeekStart: time.Monday},
+		}
+
+		assert.Equal(t, 2, sch.EndBefore(jakarta, split))
+		assert.Equal(t, "FREQ=WEEKLY;UNTIL=20220118T015959Z", sch.RecurrenceRule.String())
+		assert.Equal(t, core.RecurringType_Custom, sch.RecurringType)
+		assert.Len(t, sch.Occurrences(jakarta, start, start.AddDate(1, 0, 0), core.MaxOccurrences), 2)
+	})
+}
+
+func TestEvent_KeepSchedules(t *testing.T) {
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	cancelled := []core.OccurrenceException{{ScheduleID: "sch1", OriginalStart: start.Unix(), IsCancelled: true}}
+	moved := []core.OccurrenceException{{ScheduleID: "sch2", OriginalStart: start.Unix(), StartTime: start.Add(time.Hour).Unix()}}
+	original := []core.Schedule{
+		{ID: "sch1", StartTime: start.Unix(), DurationInMinutes: 60, Exceptions: cancelled},
+		{ID: "sch2", StartTime: start.Unix(), DurationInMinutes: 30, Exceptions: moved},
+	}
+
+	event := core.Event{
+		Schedules: []core.Schedule{
+			// Changed, but kept by its ID.
+			{ID: "sch1", StartTime: start.Unix(), DurationInMinutes: 90},
+			// Unchanged, kept even under another ID.
+			{ID: "generated", StartTime: start.Unix(), DurationInMinutes: 30},
+			// The ID of a schedule of another event isn't taken over.
+			{ID: "other", StartTime: start.Unix(), DurationInMinutes: 60},
+		},
+	}
+	event.KeepSchedules(original)
+
+	assert.Equal(t, "sch1", event.Schedules[0].ID)
+	assert.Equal(t, int64(90), event.Schedules[0].DurationInMinutes)
+	assert.Equal(t, cancelled, event.Schedules[0].Exceptions)
+	assert.Equal(t, "sch2", event.Schedules[1].ID)
+	assert.Equal(t, moved, event.Schedules[1].Exceptions)
+	assert.NotContains(t, []string{"sch1", "sch2", "other"}, event.Schedules[2].ID)
+	assert.Empty(t, event.Schedules[2].Exceptions)
+}
+
+func TestSchedule_Occurrences_DST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		start time.Time
+		rule  string
+		from  time.Time
+		to    time.Time
+		want  []string
+	}{
+		{
+			name:  "keeps the local time across spring-forward",
+			start: time.Date(2022, 3, 8, 9, 0, 0, 0, newYork),
+			rule:  "FREQ=WEEKLY",
+			from:  time.Date(2022, 3, 1, 0, 0, 0, 0, newYork),
+			to:    time.Date(2022, 3, 20, 0, 0, 0, 0, newYork),
+			want: []string{
+				"2022-03-08T09:00:00-05:00",
+				"2022-03-15T09:00:00-04:00",
+			},
+		},
+		{
+			name:  "keeps the local time across fall-back",
+			start: time.Date(2022, 11, 4, 9, 0, 0, 0, newYork),
+			rule:  "FREQ=DAILY;COUNT=3",
+			from:  time.Date(2022, 11, 1, 0, 0, 0, 0, newYork),
+			to:    time.Date(2022, 11, 10, 0, 0, 0, 0, newYork),
+			want: []string{
+				"2022-11-04T09:00:00-04:00",
+				"2022-11-05T09:00:00-04:00",
+				"2022-11-06T09:00:00-05:00",
+			},
+		},
+		{
+			name:  "moves a nonexistent local time forward by the gap",
+			start: time.Date(2022, 3, 12, 2, 30, 0, 0, newYork),
+			rule:  "FREQ=DAILY;COUNT=3",
+			from:  time.Date(2022, 3, 1, 0, 0, 0, 0, newYork),
+			to:    time.Date(2022, 3, 20, 0, 0, 0, 0, newYork),
+			want: []string{
+				"2022-03-12T02:30:00-05:00",
+				"2022-03-13T03:30:00-04:00",
+				"2022-03-14T02:30:00-04:00",
+			},
+		},
+		{
+			name:  "uses the first occurrence of an ambiguous local time",
+			start: time.Date(2022, 11, 5, 1, 30, 0, 0, newYork),
+			rule:  "FREQ=DAILY;COUNT=3",
+			from:  time.Date(2022, 11, 1, 0, 0, 0, 0, newYork),
+			to:    time.Date(2022, 11, 10, 0, 0, 0, 0, newYork),
+			want: []string{
+				"2022-11-05T01:30:00-04:00",
+				"2022-11-06T01:30:00-04:00",
+				"2022-11-07T01:30:00-05:00",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := core.ParseRecurrenceRule(tt.rule)
+			require.NoError(t, err)
+
+			sch := core.Schedule{
+				ID:                "sch1",
+				StartTime:         tt.start.Unix(),
+				DurationInMinutes: 60,
+				RecurrenceRule:    rule,
+			}
+
+			got := sch.Occurrences(newYork, tt.from, tt.to, core.MaxOccurrences)
+			starts := make([]string, len(got))
+			for i, o := range got {
+				starts[i] = o.Start.Format(time.RFC3339)
+			}
+			assert.Equal(t, tt.want, starts)
+		})
+	}
+}
+
+func TestSchedule_StartTimeIn(t *testing.T) {
+	sch := core.Schedule{StartTime: time.Date(2022, 1, 1, 2, 0, 0, 0, time.UTC).Unix()}
+
+	got, err := sch.StartTimeIn("Asia/Jakarta")
+	require.NoError(t, err)
+	assert.Equal(t, "2022-01-01T09:00:00+07:00", got.Format(time.RFC3339))
+
+	_, err = sch.StartTimeIn("invalid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+// AttendeeRole is the part an attendee takes in the event.
+type AttendeeRole uint
+
+const (
+	AttendeeRole_Required AttendeeRole = iota
+	AttendeeRole_Optional
+	// AttendeeRole_FYI is an attendee kept informed, who isn't expected to attend.
+	AttendeeRole_FYI
+	// AttendeeRole_Organizer co-organizes the event along with its creator, holding every
+	// permission.
+	AttendeeRole_Organizer
+)
+
+// AttendeePermissions is what an attendee can do with the event besides responding to it.
+type AttendeePermissions struct {
+	CanModifyEvent  bool
+	CanInviteOthers bool
+	CanSeeGuestList bool
+}
+
+// DefaultAttendeePermissions let the attendees see who else is invited, and nothing more.
+var DefaultAttendeePermissions = AttendeePermissions{CanSeeGuestList: true}
+
+var allPermissions = AttendeePermissions{CanModifyEvent: true, CanInviteOthers: true, CanSeeGuestList: true}
+
+// IsOrganizedBy tells whether the actor created the event or is one of its co-organizers.
+func (e *Event) IsOrganizedBy(actorID string) bool {
+	if e.CreatedBy == actorID {
+		return true
+	}
+	inv := e.invitationOf(actorID)
+	return inv != nil && inv.Role == AttendeeRole_Organizer
+}
+
+// PermissionsOf returns the permissions of the actor: every permission for an organizer, the
+// permissions of the invitation for an attendee, none otherwise.
+func (e *Event) PermissionsOf(actorID string) AttendeePermissions {
+	if e.IsOrganizedBy(actorID) {
+		return allPermissions
+	}
+	if inv := e.invitationOf(actorID); inv != nil {
+		return inv.Permissions
+	}
+	return AttendeePermissions{}
+}
+
+// AuthorizeUpdate fails with internal.ErrPermissionDenied unless the actor can update the event
+// into updated. The organizers can change anything. An attendee allowed to modify the event can
+// change anything but the roles and permissions, and one only allowed to invite others can only
+// add attendees and groups. Neither can grant more than seeing the guest list to the attendees they
+// add.
+func (e *Event) AuthorizeUpdate(actorID string, updated *Event) error {
+	if e.IsOrganizedBy(actorID) {
+		return nil
+	}
+
+	permissions := e.PermissionsOf(actorID)
+	switch {
+	case permissions.CanModifyEvent:
+	case permissions.CanInviteOthers:
+		if !e.sameDetails(updated) {
+			return internal.WrapErr(internal.ErrPermissionDenied, "the user can only invite others to the event")
+		}
+		for _, inv := range e.Invitations {
+			if updated.invitationLike(&inv) == nil {
+				return internal.WrapErr(internal.ErrPermissionDenied, "the user can't remove attendees from the event")
+			}
+		}
+		for _, g := range e.Groups {
+			if !slices.Contains(updated.Groups, g) {
+				return internal.WrapErr(internal.ErrPermissionDenied, "the user can't remove or change the groups of the event")
+			}
+		}
+	default:
+		return internal.WrapErr(internal.ErrPermissionDenied, "the user can't modify the event")
+	}
+
+	for _, inv := range updated.Invitations {
+		if existing := e.invitationLike(&inv); existing != nil {
+			if inv.Role != existing.Role || inv.Permissions != existing.Permissions {
+				return internal.WrapErr(internal.ErrPermissionDenied, "only the organizers can change the role or the permissions of an attendee")
+			}
+			continue
+		}
+
+		if inv.Role == AttendeeRole_Organizer || inv.Permissions.CanModifyEvent || inv.Permissions.CanInviteOthers {
+			return internal.WrapErr(internal.ErrPermissionDenied, "only the organizers can grant permissions to an attendee")
+		}
+	}
+	return nil
+}
+
+// HideGuestListFrom leaves the event with the invitation of the actor alone, unless the actor can
+// see the guest list. The occurrences of the event only list the attendees left.
+func (e *Event) HideGuestListFrom(actorID string) {
+	if e.PermissionsOf(actorID).CanSeeGuestList {
+		return
+	}
+
+	var own []Invitation
+	if inv := e.invitationOf(actorID); inv != nil {
+		own = append(own, *inv)
+	}
+	e.Invitations = own
+}
+
+// AuthorizeOccurrenceUpdate fails with internal.ErrPermissionDenied unless the actor can cancel or
+// change a single occurrence of the event: an organizer, or an attendee allowed to modify it.
+func (e *Event) AuthorizeOccurrenceUpdate(actorID string) error {
+	if !e.PermissionsOf(actorID).CanModifyEvent {
+		return internal.WrapErr(internal.ErrPermissionDenied, "the user can't modify the occurrences of the event")
+	}
+	return nil
+}
+
+// invitationOf returns the invitation of the user acting, nil when the user isn't invited.
+func (e *Event) invitationOf(actorID string) *Invitation {
+	userID, err := strconv.ParseInt(actorID, 10, 32)
+	if err != nil || userID <= 0 {
+		return nil
+	}
+	for i := range e.Invitations {
+		if e.Invitations[i].IsFor(int32(userID), "") {
+			return &e.Invitations[i]
+		}
+	}
+	return nil
+}
+
+// invitationLike returns the invitation of the same attendee as inv, nil when there's none.
+func (e *Event) invitationLike(inv *Invitation) *Invitation {
+	for i := range e.Invitations {
+		if e.Invitations[i].SameAttendee(inv) {
+			return &e.Invitations[i]
+		}
+	}
+	return nil
+}
+
+// sameDetails tells whether both events only differ by their attendees. The IDs of the schedules
+// are ignored, an update gives new ones.
+func (e *Event) sameDetails(other *Event) bool {
+	if e.Title != other.Title || e.Description != other.Description || e.Timezone != other.Timezone ||
+		e.SkipHolidays != other.SkipHolidays || !equalPtr(e.HolidayCalendarID, other.HolidayCalendarID) || e.MaxAttendees != other.MaxAttendees ||
+		len(e.Schedules) != len(other.Schedules) {
+		return false
+	}
+
+	resourceIDs, otherResourceIDs := slices.Sorted(slices.Values(e.ResourceIDs)), slices.Sorted(slices.Values(other.ResourceIDs))
+	if !slices.Equal(resourceIDs, otherResourceIDs) {
+		return false
+	}
+
+	for i := range e.Schedules {
+		if !e.Schedules[i].sameAs(&other.Schedules[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,183 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_AuthorizeUpdate(t *testing.T) {
+	start := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+	invitation := func(userID int32, role core.AttendeeRole, permissions core.AttendeePermissions) core.Invitation {
+		inv := core.NewInvitation("123", userID)
+		inv.Role = role
+		inv.Permissions = permissions
+		return inv
+	}
+	newEvent := func(invitations ...core.Invitation) *core.Event {
+		return &core.Event{
+			ID:          "123",
+			Title:       "planning",
+			Description: "planning",
+			Timezone:    "UTC",
+			CreatedBy:   "1",
+			Schedules:   []core.Schedule{{ID: "sch1", EventID: "123", StartTime: start.Unix(), DurationInMinutes: 60}},
+			Invitations: invitations,
+		}
+	}
+	stored := newEvent(
+		invitation(2, core.AttendeeRole_Organizer, core.DefaultAttendeePermissions),
+		invitation(3, core.AttendeeRole_Required, core.AttendeePermissions{CanModifyEvent: true, CanSeeGuestList: true}),
+		invitation(4, core.AttendeeRole_Optional, core.AttendeePermissions{CanInviteOthers: true}),
+		invitation(5, core.AttendeeRole_FYI, core.DefaultAttendeePermissions),
+	)
+	retitled := func(invitations ...core.Invitation) *core.Event {
+		e := newEvent(append(stored.Invitations, invitations...)...)
+		e.Title = "retro"
+		return e
+	}
+
+	tests := []struct {
+		name    string
+		actorID string
+		updated *core.Event
+		wantErr error
+	}{
+		{
+			name:    "OK - creator",
+			actorID: "1",
+			updated: newEvent(invitation(2, core.AttendeeRole_Required, core.DefaultAttendeePermissions)),
+		},
+		{
+			name:    "OK - co-organizer",
+			actorID: "2",
+			updated: retitled(invitation(6, core.AttendeeRole_Organizer, core.DefaultAttendeePermissions)),
+		},
+		{
+			name:    "OK - attendee allowed to modify",
+			actorID: "3",
+			updated: retitled(invitation(6, core.AttendeeRole_Optional, core.DefaultAttendeePermissions)),
+		},
+		{
+			name:    "OK - attendee allowed to invite others",
+			actorID: "4",
+			updated: newEvent(append(stored.Invitations, invitation(6, core.AttendeeRole_Required, core.DefaultAttendeePermissions))...),
+		},
+		{
+			name:    "Not OK - attendee without permission",
+			actorID: "5",
+			updated: retitled(),
+			wantErr: internal.ErrPermissionDenied,
+		},
+		{
+			name:    "Not OK - not invited",
+			actorID: "7",
+			updated: retitled(),
+			wantErr: internal.ErrPermissionDenied,
+		},
+		{
+			name:    "Not OK - changing the permissions of an attendee",
+			actorID: "3",
+			updated: newEvent(stored.Invitations[0], stored.Invitations[1], stored.Invitations[2],
+				invitation(5, core.AttendeeRole_FYI, core.AttendeePermissions{CanModifyEvent: true})),
+			wantErr: internal.ErrPermissionDenied,
+		},
+		{
+			name:    "Not OK - granting permissions to a new attendee",
+			actorID: "3",
+			updated: retitled(invitation(6, core.AttendeeRole_Organizer, core.DefaultAttendeePermissions)),
+			wantErr: internal.ErrPermissionDenied,
+		},
+		{
+			name:    "Not OK - inviting others along with another change",
+			actorID: "4",
+			updated: retitled(invitation(6, core.AttendeeRole_Required, core.DefaultAttendeePermissions)),
+			wantErr: internal.ErrPermissionDenied,
+		},
+		{
+			name:    "Not OK - removing an attendee while inviting others",
+			actorID: "4",
+			updated: newEvent(stored.Invitations[1:]...),
+			wantErr: internal.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := stored.AuthorizeUpdate(tt.actorID, tt.updated)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEvent_AuthorizeUpdate_Groups(t *testing.T) {
+	inviter := core.NewInvitation("123", 4)
+	inviter.Permissions = core.AttendeePermissions{CanInviteOthers: true}
+	stored := &core.Event{ID: "123", CreatedBy: "1", Invitations: []core.Invitation{inviter}, Groups: []core.EventGroup{{GroupID: "platform"}}}
+
+	added := &core.Event{ID: "123", CreatedBy: "1", Invitations: []core.Invitation{inviter}, Groups: []core.EventGroup{{GroupID: "platform"}, {GroupID: "sales"}}}
+	assert.NoError(t, stored.AuthorizeUpdate("4", added))
+
+	synced := &core.Event{ID: "123", CreatedBy: "1", Invitations: []core.Invitation{inviter}, Groups: []core.EventGroup{{GroupID: "platform", Sync: true}}}
+	assert.ErrorIs(t, stored.AuthorizeUpdate("4", synced), internal.ErrPermissionDenied)
+	assert.NoError(t, stored.AuthorizeUpdate("1", synced))
+}
+
+func TestEvent_AuthorizeOccurrenceUpdate(t *testing.T) {
+	editor := core.NewInvitation("123", 2)
+	editor.Permissions = core.AttendeePermissions{CanModifyEvent: true}
+	inviter := core.NewInvitation("123", 3)
+	inviter.Permissions = core.AttendeePermissions{CanInviteOthers: true}
+	event := &core.Event{ID: "123", CreatedBy: "1", Invitations: []core.Invitation{editor, inviter}}
+
+	assert.NoError(t, event.AuthorizeOccurrenceUpdate("1"))
+	assert.NoError(t, event.AuthorizeOccurrenceUpdate("2"))
+	assert.ErrorIs(t, event.AuthorizeOccurrenceUpdate("3"), internal.ErrPermissionDenied)
+	assert.ErrorIs(t, event.AuthorizeOccurrenceUpdate("4"), internal.ErrPermissionDenied)
+}
+
+func TestEvent_HideGuestListFrom(t *testing.T) {
+	guest := core.NewInvitation("123", 2)
+	hidden := core.NewInvitation("123", 3)
+	hidden.Permissions = core.AttendeePermissions{}
+	newEvent := func() *core.Event {
+		return &core.Event{ID: "123", CreatedBy: "1", Invitations: []core.Invitation{guest, hidden}}
+	}
+
+	event := newEvent()
+	event.HideGuestListFrom("1")
+	assert.Equal(t, []core.Invitation{guest, hidden}, event.Invitations)
+
+	event = newEvent()
+	event.HideGuestListFrom("2")
+	assert.Equal(t, []core.Invitation{guest, hidden}, event.Invitations)
+
+	event = newEvent()
+	event.HideGuestListFrom("3")
+	assert.Equal(t, []core.Invitation{hidden}, event.Invitations)
+
+	event = newEvent()
+	event.HideGuestListFrom("4")
+	assert.Empty(t, event.Invitations)
+}
+
+func TestEvent_PermissionsOf(t *testing.T) {
+	inv := core.NewInvitation("123", 2)
+	organizer := core.NewInvitation("123", 3)
+	organizer.Role = core.AttendeeRole_Organizer
+	event := &core.Event{ID: "123", CreatedBy: "1", Invitations: []core.Invitation{inv, organizer}}
+
+	all := core.AttendeePermissions{CanModifyEvent: true, CanInviteOthers: true, CanSeeGuestList: true}
+	assert.Equal(t, all, event.PermissionsOf("1"))
+	assert.Equal(t, all, event.PermissionsOf("3"))
+	assert.Equal(t, core.DefaultAttendeePermissions, event.PermissionsOf("2"))
+	assert.Equal(t, core.AttendeePermissions{}, event.PermissionsOf("4"))
+	assert.True(t, event.IsOrganizedBy("3"))
+	assert.False(t, event.IsOrganizedBy("2"))
+}
@@ -0,0 +1,351 @@
+package core
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+type Frequency string
+
+const (
+	Frequency_Daily   Frequency = "DAILY"
+	Frequency_Weekly  Frequency = "WEEKLY"
+	Frequency_Monthly Frequency = "MONTHLY"
+	Frequency_Yearly  Frequency = "YEARLY"
+)
+
+func (f Frequency) isValid() bool {
+	switch f {
+	case Frequency_Daily, Frequency_Weekly, Frequency_Monthly, Frequency_Yearly:
+		return true
+	default:
+		return false
+	}
+}
+
+const untilLayout = "20060102T150405Z"
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func weekdayCode(d time.Weekday) string {
+	for code, wd := range weekdayCodes {
+		if wd == d {
+			return code
+		}
+	}
+	return ""
+}
+
+// WeekdayNum is a BYDAY entry, e.g. "MO", "1MO" (first Monday) or "-1FR" (last Friday).
+// Position is zero when the entry applies to every matching weekday of the period.
+type WeekdayNum struct {
+	Position int
+	Weekday  time.Weekday
+}
+
+func (w WeekdayNum) String() string {
+	if w.Position == 0 {
+		return weekdayCode(w.Weekday)
+	}
+	return strconv.Itoa(w.Position) + weekdayCode(w.Weekday)
+}
+
+func parseWeekdayNum(s string) (WeekdayNum, error) {
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid weekday %q", s)
+	}
+
+	code := s[len(s)-2:]
+	wd, ok := weekdayCodes[code]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid weekday %q", s)
+	}
+
+	var pos int
+	if prefix := s[:len(s)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return WeekdayNum{}, fmt.Errorf("invalid weekday position %q", s)
+		}
+		pos = n
+	}
+
+	return WeekdayNum{Position: pos, Weekday: wd}, nil
+}
+
+// RecurrenceRule is the subset of an RFC 5545 RRULE supported by a schedule.
+// The zero value means the schedule doesn't repeat.
+type RecurrenceRule struct {
+	Freq       Frequency
+	Interval   int
+	ByMonth    []int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	BySetPos   []int
+	Count      int
+	Until      *time.Time
+	WeekStart  time.Weekday
+}
+
+func (r RecurrenceRule) IsZero() bool {
+	return r.Freq == ""
+}
+
+// ParseRecurrenceRule parses the value of an RRULE property, with or without the "RRULE:" prefix,
+// i.e: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU".
+func ParseRecurrenceRule(s string) (RecurrenceRule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return RecurrenceRule{}, nil
+	}
+
+	rule := RecurrenceRule{
+		Interval:  1,
+		WeekStart: time.Monday,
+	}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || value == "" {
+			return RecurrenceRule{}, internal.WrapErr(internal.ErrInvalidRecurrenceRule, fmt.Sprintf("malformed part %q", part))
+		}
+		if seen[name] {
+			return RecurrenceRule{}, internal.WrapErr(internal.ErrInvalidRecurrenceRule, fmt.Sprintf("duplicate %s", name))
+		}
+		seen[name] = true
+
+		if err := rule.set(name, strings.ToUpper(value)); err != nil {
+			return RecurrenceRule{}, internal.WrapErr(internal.ErrInvalidRecurrenceRule, err.Error())
+		}
+	}
+
+	if rule.Freq == "" {
+		return RecurrenceRule{}, internal.WrapErr(internal.ErrInvalidRecurrenceRule, "FREQ is required")
+	}
+
+	if err := rule.Validate(); err != nil {
+		return RecurrenceRule{}, err
+	}
+
+	return rule, nil
+}
+
+func (r *RecurrenceRule) set(name, value string) error { //nolint:gocyclo
+	var err error
+	switch name {
+	case "FREQ":
+		r.Freq = Frequency(value)
+		if !r.Freq.isValid() {
+			return fmt.Errorf("unsupported FREQ %q", value)
+		}
+	case "INTERVAL":
+		r.Interval, err = strconv.Atoi(value)
+		if err != nil || r.Interval < 1 {
+			return fmt.Errorf("invalid INTERVAL %q", value)
+		}
+	case "COUNT":
+		r.Count, err = strconv.Atoi(value)
+		if err != nil || r.Count < 1 {
+			return fmt.Errorf("invalid COUNT %q", value)
+		}
+	case "UNTIL":
+		until, err := parseUntil(value)
+		if err != nil {
+			return fmt.Errorf("invalid UNTIL %q", value)
+		}
+		r.Until = &until
+	case "WKST":
+		wd, ok := weekdayCodes[value]
+		if !ok {
+			return fmt.Errorf("invalid WKST %q", value)
+		}
+		r.WeekStart = wd
+	case "BYMONTH":
+		r.ByMonth, err = parseIntList(value, 12)
+		if err != nil || slices.Min(r.ByMonth) < 1 {
+			return fmt.Errorf("invalid BYMONTH %q", value)
+		}
+	case "BYDAY":
+		for _, v := range strings.Split(value, ",") {
+			wd, err := parseWeekdayNum(v)
+			if err != nil {
+				return err
+			}
+			r.ByDay = append(r.ByDay, wd)
+		}
+	case "BYMONTHDAY":
+		r.ByMonthDay, err = parseIntList(value, 31)
+		if err != nil {
+			return fmt.Errorf("invalid BYMONTHDAY %q", value)
+		}
+	case "BYSETPOS":
+		r.BySetPos, err = parseIntList(value, 366)
+		if err != nil {
+			return fmt.Errorf("invalid BYSETPOS %q", value)
+		}
+	default:
+		return fmt.Errorf("unsupported rule part %s", name)
+	}
+	return nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if len(value) == len("20060102") {
+		return time.Parse("20060102", value)
+	}
+	return time.Parse(untilLayout, value)
+}
+
+// parseIntList parses a comma separated list of non-zero integers within [-limit, limit].
+func parseIntList(value string, limit int) ([]int, error) {
+	var list []int
+	for _, v := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 || n < -limit || n > limit {
+			return nil, fmt.Errorf("%d is out of range", n)
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+func (r *RecurrenceRule) Validate() error {
+	if r.IsZero() {
+		return nil
+	}
+
+	if !r.Freq.isValid() {
+		return internal.WrapErr(internal.ErrInvalidRecurrenceRule, fmt.Sprintf("unsupported FREQ %q", r.Freq))
+	}
+
+	if r.Interval < 1 {
+		return internal.WrapErr(internal.ErrInvalidRecurrenceRule, "INTERVAL must be a positive number")
+	}
+
+	if r.Count > 0 && r.Until != nil {
+		return internal.WrapErr(internal.ErrInvalidRecurrenceRule, "COUNT and UNTIL must not both be set")
+	}
+
+	for _, m := range r.ByMonth {
+		if m < 1 || m > 12 {
+			return internal.WrapErr(internal.ErrInvalidRecurrenceRule, fmt.Sprintf("BYMONTH %d is out of range", m))
+		}
+	}
+
+	if r.Freq == Frequency_Weekly && len(r.ByMonthDay) > 0 {
+		return internal.WrapErr(internal.ErrInvalidRecurrenceRule, "BYMONTHDAY is not allowed with FREQ=WEEKLY")
+	}
+
+	if r.Freq == Frequency_Daily || r.Freq == Frequency_Weekly {
+		for _, wd := range r.ByDay {
+			if wd.Position != 0 {
+				return internal.WrapErr(internal.ErrInvalidRecurrenceRule, fmt.Sprintf("BYDAY position is not allowed with FREQ=%s", r.Freq))
+			}
+		}
+	}
+
+	if r.Freq == Frequency_Monthly || (r.Freq == Frequency_Yearly && len(r.ByMonth) > 0) {
+		for _, wd := range r.ByDay {
+			if wd.Position < -5 || wd.Position > 5 {
+				return internal.WrapErr(internal.ErrInvalidRecurrenceRule, fmt.Sprintf("BYDAY position %d is out of range within a month", wd.Position))
+			}
+		}
+	}
+
+	if len(r.BySetPos) > 0 && len(r.ByMonth) == 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		return internal.WrapErr(internal.ErrInvalidRecurrenceRule, "BYSETPOS requires another BYxxx rule part")
+	}
+
+	return nil
+}
+
+// String formats the rule back into an RRULE value, without the "RRULE:" prefix.
+func (r RecurrenceRule) String() string {
+	if r.IsZero() {
+		return ""
+	}
+
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			days[i] = wd.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCode(r.WeekStart))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func joinInts(list []int) string {
+	s := make([]string, len(list))
+	for i, n := range list {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ",")
+}
+
+// Scan implements sql.Scanner so the rule can be read straight from the recurrence_rule column.
+func (r *RecurrenceRule) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("unsupported type %T for recurrence rule", src)
+	}
+
+	rule, err := ParseRecurrenceRule(s)
+	if err != nil {
+		return err
+	}
+	*r = rule
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (r RecurrenceRule) Value() (driver.Value, error) {
+	return r.String(), nil
+}
@@ -0,0 +1,280 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	until := time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		rule    string
+		want    core.RecurrenceRule
+		wantErr bool
+	}{
+		{
+			name: "OK - empty rule",
+			rule: "",
+			want: core.RecurrenceRule{},
+		},
+		{
+			name: "OK - every other tuesday",
+			rule: "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+			want: core.RecurrenceRule{
+				Freq:      core.Frequency_Weekly,
+				Interval:  2,
+				ByDay:     []core.WeekdayNum{{Weekday: time.Tuesday}},
+				WeekStart: time.Monday,
+			},
+		},
+		{
+			name: "OK - first monday of the month until end of year",
+			rule: "FREQ=MONTHLY;BYDAY=1MO;UNTIL=20221231T000000Z;WKST=SU",
+			want: core.RecurrenceRule{
+				Freq:      core.Frequency_Monthly,
+				Interval:  1,
+				ByDay:     []core.WeekdayNum{{Position: 1, Weekday: time.Monday}},
+				Until:     &until,
+				WeekStart: time.Sunday,
+			},
+		},
+		{
+			name: "OK - last weekday of the month",
+			rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=12",
+			want: core.RecurrenceRule{
+				Freq:     core.Frequency_Monthly,
+				Interval: 1,
+				ByDay: []core.WeekdayNum{
+					{Weekday: time.Monday},
+					{Weekday: time.Tuesday},
+					{Weekday: time.Wednesday},
+					{Weekday: time.Thursday},
+					{Weekday: time.Friday},
+				},
+				BySetPos:  []int{-1},
+				Count:     12,
+				WeekStart: time.Monday,
+			},
+		},
+		{
+			name: "OK - last friday of march",
+			rule: "FREQ=YEARLY;BYMONTH=3;BYDAY=-1FR",
+			want: core.RecurrenceRule{
+				Freq:      core.Frequency_Yearly,
+				Interval:  1,
+				ByMonth:   []int{3},
+				ByDay:     []core.WeekdayNum{{Position: -1, Weekday: time.Friday}},
+				WeekStart: time.Monday,
+			},
+		},
+		{
+			name:    "Not OK - BYMONTH out of range",
+			rule:    "FREQ=YEARLY;BYMONTH=13",
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - negative BYMONTH",
+			rule:    "FREQ=YEARLY;BYMONTH=-1",
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - missing FREQ",
+			rule:    "INTERVAL=2",
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - unsupported FREQ",
+			rule:    "FREQ=HOURLY",
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - COUNT and UNTIL",
+			rule:    "FREQ=DAILY;COUNT=2;UNTIL=20221231",
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - BYDAY position with FREQ=WEEKLY",
+			rule:    "FREQ=WEEKLY;BYDAY=2TU",
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - BYMONTHDAY out of range",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=32",
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - BYSETPOS without other BYxxx",
+			rule:    "FREQ=MONTHLY;BYSETPOS=1",
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - malformed part",
+			rule:    "FREQ=DAILY;INTERVAL",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := core.ParseRecurrenceRule(tt.rule)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, internal.ErrInvalidRecurrenceRule)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRecurrenceRule_String(t *testing.T) {
+	tests := []string{
+		"",
+		"FREQ=DAILY",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;WKST=SU",
+		"FREQ=MONTHLY;BYDAY=-1FR;COUNT=10",
+		"FREQ=MONTHLY;BYMONTHDAY=1,15;UNTIL=20221231T170000Z",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29,-1;BYSETPOS=1",
+	}
+	for _, rule := range tests {
+		t.Run(rule, func(t *testing.T) {
+			r, err := core.ParseRecurrenceRule(rule)
+			require.NoError(t, err)
+			assert.Equal(t, rule, r.String())
+		})
+	}
+}
+
+func TestNewSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		rt       core.RecurringType
+		rrule    string
+		until    string
+		count    int
+		wantType core.RecurringType
+		wantRule string
+		wantErr  bool
+	}{
+		{
+			name:     "OK - preset recurring type",
+			rt:       core.RecurringType_Every_Week,
+			wantType: core.RecurringType_Every_Week,
+			wantRule: "FREQ=WEEKLY",
+		},
+		{
+			name:     "OK - recurrence rule takes precedence",
+			rt:       core.RecurringType_Daily,
+			rrule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+			wantType: core.RecurringType_Custom,
+			wantRule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+		},
+		{
+			name:     "OK - monthly preset",
+			rt:       core.RecurringType_Monthly,
+			wantType: core.RecurringType_Monthly,
+			wantRule: "FREQ=MONTHLY",
+		},
+		{
+			name:     "OK - monthly preset on the 31st falls back to the last day",
+			start:    "2022-01-31T09:00:00+07:00",
+			rt:       core.RecurringType_Monthly,
+			wantType: core.RecurringType_Monthly,
+			wantRule: "FREQ=MONTHLY;BYMONTHDAY=-1",
+		},
+		{
+			name:     "OK - monthly preset on the 30th falls back to the last day",
+			start:    "2022-01-30T09:00:00+07:00",
+			rt:       core.RecurringType_Monthly,
+			wantType: core.RecurringType_Monthly,
+			wantRule: "FREQ=MONTHLY;BYMONTHDAY=30,-1;BYSETPOS=1",
+		},
+		{
+			name:     "OK - yearly preset on february 29th",
+			start:    "2024-02-29T09:00:00+07:00",
+			rt:       core.RecurringType_Yearly,
+			wantType: core.RecurringType_Yearly,
+			wantRule: "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29,-1;BYSETPOS=1",
+		},
+		{
+			name:     "OK - recurrence rule matching a preset",
+			rt:       core.RecurringType_None,
+			rrule:    "FREQ=YEARLY",
+			wantType: core.RecurringType_Yearly,
+			wantRule: "FREQ=YEARLY",
+		},
+		{
+			name:     "OK - recurrence rule skipping short months",
+			start:    "2022-01-31T09:00:00+07:00",
+			rt:       core.RecurringType_None,
+			rrule:    "FREQ=MONTHLY",
+			wantType: core.RecurringType_Custom,
+			wantRule: "FREQ=MONTHLY",
+		},
+		{
+			name:     "OK - preset with count",
+			rt:       core.RecurringType_Every_Week,
+			count:    10,
+			wantType: core.RecurringType_Custom,
+			wantRule: "FREQ=WEEKLY;COUNT=10",
+		},
+		{
+			name:     "OK - until replaces the count of the rule",
+			rt:       core.RecurringType_None,
+			rrule:    "FREQ=DAILY;COUNT=3",
+			until:    "2022-01-31T09:00:00+07:00",
+			wantType: core.RecurringType_Custom,
+			wantRule: "FREQ=DAILY;UNTIL=20220131T020000Z",
+		},
+		{
+			name:    "Not OK - until and count",
+			rt:      core.RecurringType_Daily,
+			until:   "2022-01-31T09:00:00+07:00",
+			count:   3,
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - count without recurrence",
+			rt:      core.RecurringType_None,
+			count:   3,
+			wantErr: true,
+		},
+		{
+			name:    "Not OK - invalid recurrence rule",
+			rt:      core.RecurringType_None,
+			rrule:   "FREQ=SOMETIMES",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := "2022-01-04T09:00:00+07:00"
+			if tt.start != "" {
+				start = tt.start
+			}
+			st, err := time.Parse(time.RFC3339, start)
+			require.NoError(t, err)
+
+			got, err := core.NewSchedule("event", start, st.Add(time.Hour).Format(time.RFC3339), false, tt.rt, tt.rrule, tt.until, tt.count)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, got.RecurringType)
+			assert.Equal(t, tt.wantRule, got.RecurrenceRule.String())
+			assert.EqualValues(t, got.RecurrenceRule.Count, got.Count)
+			if got.RecurrenceRule.Until != nil {
+				assert.Equal(t, got.RecurrenceRule.Until.Unix(), got.Until)
+			}
+			assert.NoError(t, got.Validate())
+			assert.EqualValues(t, 60, got.DurationInMinutes)
+		})
+	}
+}
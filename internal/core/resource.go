@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/satori/uuid"
+)
+
+// Resource is a meeting room or a shared piece of equipment reserved by events.
+type Resource struct {
+	ID   string `validate:"required" db:"id"`
+	Name string `validate:"required,max=100" db:"name"`
+	// Capacity is the number of people fitting in the resource, zero when it doesn't apply.
+	Capacity int32  `validate:"gte=0" db:"capacity"`
+	Location string `validate:"max=200" db:"location"`
+	Timezone string `validate:"required" db:"timezone"`
+}
+
+func NewResource() *Resource {
+	return &Resource{
+		ID: uuid.NewV4().String(),
+	}
+}
+
+func (r *Resource) Validate() error {
+	err := validate.Struct(r)
+	if err != nil {
+		return internal.WrapErr(internal.ErrValidationFailed, err.Error())
+	}
+
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return internal.WrapErr(internal.ErrInvalidTimezone, r.Timezone)
+	}
+
+	return nil
+}
+
+// Reservation holds a resource during an occurrence of an event.
+type Reservation struct {
+	ResourceID    string
+	EventID       string
+	ScheduleID    string
+	OriginalStart time.Time
+	Start         time.Time
+	End           time.Time
+}
+
+// ReservationWindow returns the time range of the occurrences holding the resources of the event:
+// from the first occurrence, or from now when the event already started, up to ConflictHorizon
+// later. The past occurrences keep no resource.
+func (e *Event) ReservationWindow(now time.Time) (time.Time, time.Time) {
+	return e.ConflictWindow(now)
+}
+
+// Reservations returns a reservation of every resource of the event for each of its occurrences
+// within [from, to). It fails rather than leaving some occurrences without their resources when
+// there are too many of them, or when some of them start past the window.
+func (e *Event) Reservations(from, to time.Time) ([]Reservation, error) {
+	if len(e.ResourceIDs) == 0 {
+		return nil, nil
+	}
+
+	list, err := e.windowOccurrences(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// The occurrences past the window would hold no reservation, leaving the resources open to
+	// double bookings.
+	following, err := e.Occurrences(to, maxOccurrenceTime, MaxOccurrences)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(following.Occurrences, func(occ Occurrence) bool { return !occ.Start.Before(to) }) {
+		return nil, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("the resources can only be reserved up to %d days ahead, the schedules must end by then", int(ConflictHorizon.Hours()/24)))
+	}
+
+	reservations := make([]Reservation, 0, len(e.ResourceIDs)*len(list.Occurrences))
+	for _, resourceID := range e.ResourceIDs {
+		for _, occ := range list.Occurrences {
+			reservations = append(reservations, Reservation{
+				ResourceID:    resourceID,
+				EventID:       e.ID,
+				ScheduleID:    occ.ScheduleID,
+				OriginalStart: occ.OriginalStart,
+				Start:         occ.Start,
+				End:           occ.End,
+			})
+		}
+	}
+	return reservations, nil
+}
+
+//go:generate go tool -modfile=../../go.tool.mod mockgen -destination=../mock/mock_resource_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core ResourceRepository
+type ResourceRepository interface {
+	Store(ctx context.Context, r *Resource) error
+	FindByID(ctx context.Context, id string) (*Resource, error)
+	FindAll(ctx context.Context) ([]Resource, error)
+}
@@ -0,0 +1,157 @@
+package core_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource core.Resource
+		wantErr  error
+	}{
+		{
+			name:     "OK",
+			resource: core.Resource{ID: "room1", Name: "Room 1", Capacity: 8, Timezone: "Asia/Jakarta"},
+		},
+		{
+			name:     "missing name",
+			resource: core.Resource{ID: "room1", Timezone: "Asia/Jakarta"},
+			wantErr:  internal.ErrValidationFailed,
+		},
+		{
+			name:     "negative capacity",
+			resource: core.Resource{ID: "room1", Name: "Room 1", Capacity: -1, Timezone: "Asia/Jakarta"},
+			wantErr:  internal.ErrValidationFailed,
+		},
+		{
+			name:     "invalid timezone",
+			resource: core.Resource{ID: "room1", Name: "Room 1", Timezone: "Mars/Olympus"},
+			wantErr:  internal.ErrInvalidTimezone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.resource.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEvent_Reservations(t *testing.T) {
+	// Tuesday, 4 January 2022 09:00 in Asia/Jakarta
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	daily := core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1, WeekStart: time.Monday, Count: 2}
+
+	event := core.Event{
+		ID:          "standup",
+		Timezone:    "Asia/Jakarta",
+		ResourceIDs: []string{"room1", "projector"},
+		Schedules: []core.Schedule{
+			{ID: "sch", StartTime: start.Unix(), DurationInMinutes: 30, RecurringType: core.RecurringType_Custom, RecurrenceRule: daily},
+		},
+	}
+
+	from, to := event.ReservationWindow(start.AddDate(0, 0, -7))
+	assert.True(t, from.Equal(start), "got %s", from)
+	assert.True(t, to.Equal(start.Add(core.ConflictHorizon)), "got %s", to)
+
+	// The window starts at now for events already started.
+	from, to = event.ReservationWindow(start.AddDate(0, 1, 0))
+	assert.True(t, from.Equal(start.AddDate(0, 1, 0)), "got %s", from)
+	assert.True(t, to.Equal(start.AddDate(0, 1, 0).Add(core.ConflictHorizon)), "got %s", to)
+
+	got, err := event.Reservations(start, start.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+	for i, resourceID := range []string{"room1", "room1", "projector", "projector"} {
+		assert.Equal(t, resourceID, got[i].ResourceID)
+		assert.Equal(t, "standup", got[i].EventID)
+		assert.Equal(t, "sch", got[i].ScheduleID)
+	}
+	assert.True(t, got[1].Start.Equal(start.AddDate(0, 0, 1)), "got %s", got[1].Start)
+	assert.True(t, got[1].End.Equal(start.AddDate(0, 0, 1).Add(30*time.Minute)), "got %s", got[1].End)
+	assert.True(t, got[1].OriginalStart.Equal(got[1].Start), "got %s", got[1].OriginalStart)
+
+	event.ResourceIDs = nil
+	got, err = event.Reservations(start, start.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestEvent_Reservations_TooManyOccurrences(t *testing.T) {
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	until := start.Add(core.ConflictHorizon - time.Hour)
+	daily := core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1, WeekStart: time.Monday, Until: &until}
+
+	event := core.Event{
+		ID:          "shifts",
+		Timezone:    "Asia/Jakarta",
+		ResourceIDs: []string{"room1"},
+	}
+	addSchedules := func(n int) {
+		for range n {
+			sch := core.Schedule{ID: fmt.Sprintf("sch%d", len(event.Schedules)), StartTime: start.Add(time.Duration(len(event.Schedules)) * 30 * time.Minute).Unix(), DurationInMinutes: 30, RecurringType: core.RecurringType_Custom, RecurrenceRule: daily}
+			event.Schedules = append(event.Schedules, sch)
+		}
+	}
+
+	// Two daily schedules over the whole window exceed the cap of a single listing.
+	addSchedules(2)
+	got, err := event.Reservations(event.ReservationWindow(start))
+	require.NoError(t, err)
+	assert.Len(t, got, 2*365)
+
+	addSchedules(28)
+	_, err = event.Reservations(event.ReservationWindow(start))
+	assert.ErrorIs(t, err, internal.ErrValidationFailed)
+}
+
+func TestEvent_Reservations_PastTheWindow(t *testing.T) {
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	weekly := func(count int) core.RecurrenceRule {
+		return core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1, WeekStart: time.Monday, Count: count}
+	}
+
+	tests := []struct {
+		name    string
+		rule    core.RecurrenceRule
+		wantErr bool
+	}{
+		{name: "OK - ending within the window", rule: weekly(52)},
+		{name: "Not OK - ending past the window", rule: weekly(60), wantErr: true},
+		{name: "Not OK - without end", rule: weekly(0), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := core.Event{
+				ID:          "sync",
+				Timezone:    "Asia/Jakarta",
+				ResourceIDs: []string{"room1"},
+				Schedules: []core.Schedule{
+					{ID: "sch", StartTime: start.Unix(), DurationInMinutes: 30, RecurringType: core.RecurringType_Custom, RecurrenceRule: tt.rule},
+				},
+			}
+
+			// The occurrences past the window would keep no reservation.
+			got, err := event.Reservations(event.ReservationWindow(start))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, internal.ErrValidationFailed)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, got, 52)
+		})
+	}
+}
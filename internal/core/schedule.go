@@ -2,8 +2,10 @@ package core
 
 import (
 	"errors"
+	"slices"
 	"time"
 
+	"github.com/dzakaammar/event-scheduling-example/internal"
 	"github.com/satori/uuid"
 )
 
@@ -15,19 +17,64 @@ const (
 	RecurringType_None       RecurringType = "NONE"
 	RecurringType_Daily      RecurringType = "DAILY"
 	RecurringType_Every_Week RecurringType = "WEEK"
+	RecurringType_Monthly    RecurringType = "MONTHLY"
+	RecurringType_Yearly     RecurringType = "YEARLY"
+	RecurringType_Custom     RecurringType = "CUSTOM"
 )
 
-func (r RecurringType) interval() int64 {
+// rule returns the recurrence rule equivalent to the preset recurring type of a schedule starting
+// at start. Unlike a plain RRULE, which skips the months lacking the day of start, the monthly and
+// yearly presets fall back to the last day of those months: a monthly schedule starting on January
+// 31st repeats on February 28th (or 29th), and a yearly one starting on February 29th repeats on
+// February 28th in common years.
+func (r RecurringType) rule(start time.Time) RecurrenceRule {
+	rule := RecurrenceRule{Interval: 1, WeekStart: time.Monday}
 	switch r {
 	case RecurringType_Daily:
-		return int64(24 * time.Hour.Seconds())
+		rule.Freq = Frequency_Daily
 	case RecurringType_Every_Week:
-		return int64(24 * 7 * time.Hour.Seconds())
+		rule.Freq = Frequency_Weekly
+	case RecurringType_Monthly:
+		rule.Freq = Frequency_Monthly
+		rule.ByMonthDay, rule.BySetPos = dayOrLastDay(start.Day())
+	case RecurringType_Yearly:
+		rule.Freq = Frequency_Yearly
+		if start.Month() == time.February {
+			rule.ByMonthDay, rule.BySetPos = dayOrLastDay(start.Day())
+			if rule.ByMonthDay != nil {
+				rule.ByMonth = []int{int(time.February)}
+			}
+		}
 	default:
-		return 0
+		return RecurrenceRule{}
 	}
+	return rule
 }
 
+// dayOrLastDay returns the BYMONTHDAY and BYSETPOS rule parts selecting the given day of the month,
+// or the last day of the months lacking it. Days every month has need no rule part.
+func dayOrLastDay(day int) ([]int, []int) {
+	switch {
+	case day <= 28:
+		return nil, nil
+	case day == 31:
+		return []int{-1}, nil
+	default:
+		return []int{day, -1}, []int{1}
+	}
+}
+
+func recurringTypeOf(rule RecurrenceRule, start time.Time) RecurringType {
+	for _, rt := range []RecurringType{RecurringType_None, RecurringType_Daily, RecurringType_Every_Week, RecurringType_Monthly, RecurringType_Yearly} {
+		if rt.rule(start).String() == rule.String() {
+			return rt
+		}
+	}
+	return RecurringType_Custom
+}
+
+// Schedule is anchored in the local wall-clock time of its event's timezone: StartTime pins the
+// first occurrence, and the following occurrences keep its local time of day across DST transitions.
 type Schedule struct {
 	ID                string        `db:"id" validate:"required"`
 	EventID           string        `db:"event_id" validate:"required"`
@@ -35,18 +82,133 @@ type Schedule struct {
 	DurationInMinutes int64         `db:"duration" validate:"required"`
 	IsFullDay         bool          `db:"is_full_day"`
 	RecurringType     RecurringType `db:"recurring_type"`
-	RecurringInterval int64         `db:"recurring_interval"`
+	// Deprecated: recurrences are expanded from RecurrenceRule in the event's timezone, a fixed
+	// interval in seconds can't represent days that are 23 or 25 hours long.
+	RecurringInterval int64          `db:"recurring_interval"`
+	RecurrenceRule    RecurrenceRule `db:"recurrence_rule"`
+	// Until (a unix timestamp, inclusive) and Count end the recurrence, zero means unset. They mirror
+	// the UNTIL and COUNT parts of RecurrenceRule, at most one of them is set.
+	Until int64 `db:"until"`
+	Count int32 `db:"count"`
+	// BufferBeforeInMinutes and BufferAfterInMinutes keep the attendees busy around every occurrence,
+	// i.e: to travel. Nil leaves them to the default buffers of each attendee.
+	BufferBeforeInMinutes *int64 `db:"buffer_before" validate:"omitempty,gte=0,lte=240"`
+	BufferAfterInMinutes  *int64 `db:"buffer_after" validate:"omitempty,gte=0,lte=240"`
+
+	Exceptions []OccurrenceException `db:"-"`
+}
+
+func (s *Schedule) Validate() error {
+	if s.Until != 0 && s.Count != 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, "only one of until and count can be set")
+	}
+
+	if (s.Until != 0 || s.Count != 0) && s.RecurrenceRule.IsZero() {
+		return internal.WrapErr(internal.ErrValidationFailed, "until and count require a recurring schedule")
+	}
+
+	return validate.Struct(s)
+}
+
+// UntilTime returns the end of the recurrence, if any.
+func (s *Schedule) UntilTime() *time.Time {
+	if s.Until == 0 {
+		return nil
+	}
+	until := time.Unix(s.Until, 0).UTC()
+	return &until
+}
+
+// SetRecurrenceRule sets the rule of the schedule, keeping Until and Count in sync with it.
+func (s *Schedule) SetRecurrenceRule(rule RecurrenceRule) {
+	s.RecurrenceRule = rule
+	s.Count = int32(rule.Count) //nolint:gosec
+	s.Until = 0
+	if rule.Until != nil {
+		s.Until = rule.Until.Unix()
+	}
 }
 
 func (s *Schedule) StartTimeIn(loc string) (time.Time, error) {
-	return time.Unix(s.StartTime, 0).In(time.UTC), nil
+	l, err := time.LoadLocation(loc)
+	if err != nil {
+		return time.Time{}, internal.WrapErr(internal.ErrInvalidTimezone, loc)
+	}
+	return time.Unix(s.StartTime, 0).In(l), nil
 }
 
 func (s *Schedule) EndTimeFrom(st time.Time) time.Time {
 	return st.Add(time.Duration(s.DurationInMinutes) * time.Minute)
 }
 
-func NewSchedule(eventID string, start, end string, isFullDay bool, rt RecurringType) (Schedule, error) {
+// EndBefore ends the recurrence of the schedule right before the occurrence originally starting at
+// t, and returns the number of occurrences the schedule keeps. A rule limited by COUNT keeps its
+// COUNT form, any other rule gets an UNTIL.
+func (s *Schedule) EndBefore(loc *time.Location, t time.Time) int {
+	kept := 0
+	s.eachStart(loc, func(start time.Time) bool {
+		if !start.Before(t) {
+			return false
+		}
+		kept++
+		return true
+	})
+
+	rule := s.RecurrenceRule
+	if rule.Count > 0 {
+		rule.Count = kept
+	} else {
+		until := t.Add(-time.Second).UTC()
+		rule.Until = &until
+	}
+	s.SetRecurrenceRule(rule)
+	s.RecurringType = recurringTypeOf(rule, time.Unix(s.StartTime, 0).In(loc))
+
+	return kept
+}
+
+// KeepSchedules gives the schedules of the update, the event, the ID and the exceptions of the
+// original schedule each one keeps: the schedule of the same ID, or else an identical one. The
+// other schedules are new ones, given a new ID rather than the ID of a schedule of another event.
+func (e *Event) KeepSchedules(original []Schedule) {
+	kept := make(map[string]bool, len(original))
+	matched := make([]int, len(e.Schedules))
+	for i := range e.Schedules {
+		matched[i] = slices.IndexFunc(original, func(o Schedule) bool { return o.ID == e.Schedules[i].ID })
+		if matched[i] >= 0 {
+			kept[original[matched[i]].ID] = true
+		}
+	}
+
+	for i := range e.Schedules {
+		sch := &e.Schedules[i]
+		if matched[i] < 0 {
+			matched[i] = slices.IndexFunc(original, func(o Schedule) bool { return !kept[o.ID] && o.sameAs(sch) })
+		}
+		if matched[i] < 0 {
+			sch.ID = uuid.NewV4().String()
+			sch.Exceptions = nil
+			continue
+		}
+
+		o := original[matched[i]]
+		kept[o.ID] = true
+		sch.ID = o.ID
+		sch.Exceptions = o.Exceptions
+	}
+}
+
+// sameAs tells whether both schedules produce the same occurrences, with the same buffers.
+func (s *Schedule) sameAs(o *Schedule) bool {
+	return s.StartTime == o.StartTime && s.DurationInMinutes == o.DurationInMinutes && s.IsFullDay == o.IsFullDay &&
+		s.RecurrenceRule.String() == o.RecurrenceRule.String() && s.Until == o.Until && s.Count == o.Count &&
+		equalPtr(s.BufferBeforeInMinutes, o.BufferBeforeInMinutes) && equalPtr(s.BufferAfterInMinutes, o.BufferAfterInMinutes)
+}
+
+// NewSchedule creates a schedule of the event. The recurrence rule, when given, takes precedence
+// over the preset recurring type. The until time (RFC 3339) and the count end the recurrence,
+// replacing the UNTIL or COUNT of the rule; only one of them can be given.
+func NewSchedule(eventID string, start, end string, isFullDay bool, rt RecurringType, rrule string, until string, count int) (Schedule, error) {
 	startTime, err := time.Parse(time.RFC3339, start)
 	if err != nil {
 		return Schedule{}, err
@@ -57,6 +219,39 @@ func NewSchedule(eventID string, start, end string, isFullDay bool, rt Recurring
 		return Schedule{}, err
 	}
 
+	rule := rt.rule(startTime)
+	if rrule != "" {
+		rule, err = ParseRecurrenceRule(rrule)
+		if err != nil {
+			return Schedule{}, err
+		}
+	}
+
+	if until != "" && count != 0 {
+		return Schedule{}, internal.WrapErr(internal.ErrInvalidRecurrenceRule, "only one of until and count can be set")
+	}
+	if until != "" || count != 0 {
+		if rule.IsZero() {
+			return Schedule{}, internal.WrapErr(internal.ErrInvalidRecurrenceRule, "until and count require a recurring schedule")
+		}
+		if count < 0 {
+			return Schedule{}, internal.WrapErr(internal.ErrInvalidRecurrenceRule, "count must be a positive number")
+		}
+
+		rule.Count, rule.Until = count, nil
+		if until != "" {
+			untilTime, err := time.Parse(time.RFC3339, until)
+			if err != nil {
+				return Schedule{}, err
+			}
+			untilTime = untilTime.UTC()
+			rule.Until = &untilTime
+		}
+	}
+	if rrule != "" || until != "" || count != 0 {
+		rt = recurringTypeOf(rule, startTime)
+	}
+
 	s := Schedule{
 		ID:                uuid.NewV4().String(),
 		EventID:           eventID,
@@ -65,7 +260,7 @@ func NewSchedule(eventID string, start, end string, isFullDay bool, rt Recurring
 		IsFullDay:         isFullDay,
 		RecurringType:     rt,
 	}
-	s.RecurringInterval = rt.interval()
+	s.SetRecurrenceRule(rule)
 
 	return s, nil
 }
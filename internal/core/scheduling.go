@@ -2,6 +2,9 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/dzakaammar/event-scheduling-example/internal"
 )
@@ -9,6 +12,10 @@ import (
 type CreateEventRequest struct {
 	ActorID string
 	Event   *Event
+	// AllowConflicts stores the event even when its attendees are already booked.
+	AllowConflicts bool
+	// RespectWorkingHours treats the time outside the working hours of the attendees as a conflict.
+	RespectWorkingHours bool
 }
 
 func (c *CreateEventRequest) Validate() error {
@@ -44,10 +51,29 @@ func (d *DeleteEventByIDRequest) Validate() error {
 	return nil
 }
 
+// UpdateScope selects the occurrences of a recurring event affected by an update.
+type UpdateScope string
+
+const (
+	UpdateScope_All              UpdateScope = "ALL"
+	UpdateScope_ThisOccurrence   UpdateScope = "THIS_OCCURRENCE"
+	UpdateScope_ThisAndFollowing UpdateScope = "THIS_AND_FOLLOWING"
+)
+
 type UpdateEventRequest struct {
 	ID      string
 	ActorID string
 	Event   *Event
+	// Scope defaults to UpdateScope_All. The other scopes target the occurrence of ScheduleID
+	// originally starting at OccurrenceStart, and take the new time of the occurrence (or of the
+	// following series) from the single schedule of Event.
+	Scope           UpdateScope
+	ScheduleID      string
+	OccurrenceStart time.Time
+	// AllowConflicts stores the update even when its attendees are already booked.
+	AllowConflicts bool
+	// RespectWorkingHours treats the time outside the working hours of the attendees as a conflict.
+	RespectWorkingHours bool
 }
 
 func (u *UpdateEventRequest) Validate() error {
@@ -59,10 +85,27 @@ func (u *UpdateEventRequest) Validate() error {
 		return internal.WrapErr(internal.ErrValidationFailed, "invalid event")
 	}
 
+	switch u.Scope {
+	case "", UpdateScope_All:
+	case UpdateScope_ThisOccurrence, UpdateScope_ThisAndFollowing:
+		if u.ScheduleID == "" || u.OccurrenceStart.IsZero() {
+			return internal.WrapErr(internal.ErrValidationFailed, "invalid schedule id or occurrence start time")
+		}
+
+		if len(u.Event.Schedules) != 1 {
+			return internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("exactly one schedule is required for %s", u.Scope))
+		}
+	default:
+		return internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("invalid scope %q", u.Scope))
+	}
+
 	return u.Event.Validate()
 }
 
+// FindEventByIDRequest finds the event, leaving out the other attendees unless the actor can see
+// the guest list.
 type FindEventByIDRequest struct {
+	ActorID string
 	EventID string
 }
 
@@ -74,10 +117,495 @@ func (f *FindEventByIDRequest) Validate() error {
 	return nil
 }
 
+// ListOccurrencesRequest lists the occurrences of the event, leaving out the other attendees unless
+// the actor can see the guest list.
+type ListOccurrencesRequest struct {
+	ActorID string
+	EventID string
+	From    time.Time
+	To      time.Time
+}
+
+func (l *ListOccurrencesRequest) Validate() error {
+	if l.EventID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
+	}
+
+	if l.From.IsZero() || l.To.IsZero() || !l.From.Before(l.To) {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid time range")
+	}
+
+	return nil
+}
+
+type CancelOccurrenceRequest struct {
+	ActorID       string
+	EventID       string
+	ScheduleID    string
+	OriginalStart time.Time
+}
+
+func (c *CancelOccurrenceRequest) Validate() error {
+	if c.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if c.EventID == "" || c.ScheduleID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event or schedule id")
+	}
+
+	if c.OriginalStart.IsZero() {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid original start time")
+	}
+
+	return nil
+}
+
+type UpdateOccurrenceRequest struct {
+	ActorID       string
+	EventID       string
+	ScheduleID    string
+	OriginalStart time.Time
+	// Start and End move the occurrence, zero values keep its time.
+	Start               time.Time
+	End                 time.Time
+	Title               string
+	Description         string
+	AllowConflicts      bool
+	RespectWorkingHours bool
+}
+
+func (u *UpdateOccurrenceRequest) Validate() error {
+	if u.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if u.EventID == "" || u.ScheduleID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event or schedule id")
+	}
+
+	if u.OriginalStart.IsZero() {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid original start time")
+	}
+
+	if u.Start.IsZero() != u.End.IsZero() || (!u.Start.IsZero() && !u.Start.Before(u.End)) {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid time range")
+	}
+
+	if u.Start.IsZero() && u.Title == "" && u.Description == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "nothing to update")
+	}
+
+	return nil
+}
+
+type QueryFreeBusyRequest struct {
+	UserIDs []int32
+	From    time.Time
+	To      time.Time
+	// Timezone is the location of the returned intervals, UTC when empty.
+	Timezone string
+	// RespectWorkingHours reports the time outside the working hours of the users as busy.
+	RespectWorkingHours bool
+}
+
+func (q *QueryFreeBusyRequest) Validate() error {
+	if len(q.UserIDs) == 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, "no user ids provided")
+	}
+
+	if q.From.IsZero() || q.To.IsZero() || !q.From.Before(q.To) {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid time range")
+	}
+
+	if q.To.Sub(q.From) > MaxFreeBusyWindow {
+		return internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("the time range must not exceed %s", MaxFreeBusyWindow))
+	}
+
+	if _, err := time.LoadLocation(q.Timezone); err != nil {
+		return internal.WrapErr(internal.ErrInvalidTimezone, q.Timezone)
+	}
+
+	return nil
+}
+
+type SuggestMeetingTimesRequest struct {
+	RequiredUserIDs []int32
+	OptionalUserIDs []int32
+	Duration        time.Duration
+	From            time.Time
+	To              time.Time
+	// Timezone is the location of the suggestions and of the working hours, UTC when empty.
+	Timezone     string
+	WorkingHours *WorkingHours
+	// Limit is the number of suggestions returned, DefaultSuggestionLimit when zero.
+	Limit int
+	// RespectWorkingHours treats the time outside the working hours of the attendees as busy.
+	RespectWorkingHours bool
+}
+
+func (s *SuggestMeetingTimesRequest) Validate() error {
+	if len(s.RequiredUserIDs) == 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, "no required attendees provided")
+	}
+
+	if s.Duration <= 0 || s.Duration%time.Minute != 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid duration")
+	}
+
+	if s.From.IsZero() || s.To.IsZero() || s.To.Sub(s.From) < s.Duration {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid time range")
+	}
+
+	if s.To.Sub(s.From) > MaxFreeBusyWindow {
+		return internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("the time range must not exceed %s", MaxFreeBusyWindow))
+	}
+
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return internal.WrapErr(internal.ErrInvalidTimezone, s.Timezone)
+	}
+
+	if s.WorkingHours != nil {
+		if err := s.WorkingHours.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if s.Limit < 0 || s.Limit > MaxSuggestionLimit {
+		return internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("the limit must be between 1 and %d", MaxSuggestionLimit))
+	}
+
+	return nil
+}
+
+type GetAvailabilityRequest struct {
+	UserID int32
+}
+
+func (g *GetAvailabilityRequest) Validate() error {
+	if g.UserID <= 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid user id")
+	}
+
+	return nil
+}
+
+// UpdateAvailabilityRequest replaces the availability of a user, which only the user can do.
+type UpdateAvailabilityRequest struct {
+	ActorID      string
+	Availability *Availability
+}
+
+func (u *UpdateAvailabilityRequest) Validate() error {
+	if u.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if u.Availability == nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid availability")
+	}
+
+	return u.Availability.Validate()
+}
+
+type CreateResourceRequest struct {
+	ActorID  string
+	Resource *Resource
+}
+
+func (c *CreateResourceRequest) Validate() error {
+	if c.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if c.Resource == nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid resource")
+	}
+
+	return c.Resource.Validate()
+}
+
+type FindResourceByIDRequest struct {
+	ID string
+}
+
+func (f *FindResourceByIDRequest) Validate() error {
+	if f.ID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid resource id")
+	}
+
+	return nil
+}
+
+type CreateHolidayCalendarRequest struct {
+	ActorID         string
+	HolidayCalendar *HolidayCalendar
+}
+
+func (c *CreateHolidayCalendarRequest) Validate() error {
+	if c.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if c.HolidayCalendar == nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid holiday calendar")
+	}
+
+	return c.HolidayCalendar.Validate()
+}
+
+type FindHolidayCalendarByIDRequest struct {
+	ID string
+}
+
+func (f *FindHolidayCalendarByIDRequest) Validate() error {
+	if f.ID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid holiday calendar id")
+	}
+
+	return nil
+}
+
+type CreateGroupRequest struct {
+	ActorID string
+	Group   *Group
+}
+
+func (c *CreateGroupRequest) Validate() error {
+	if c.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if c.Group == nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid group")
+	}
+
+	return c.Group.Validate()
+}
+
+type FindGroupByIDRequest struct {
+	ID string
+}
+
+func (f *FindGroupByIDRequest) Validate() error {
+	if f.ID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid group id")
+	}
+
+	return nil
+}
+
+// UpdateGroupMembersRequest replaces the direct members of the group. The events inviting the
+// group with Sync set follow the change.
+type UpdateGroupMembersRequest struct {
+	ActorID     string
+	GroupID     string
+	MemberIDs   []int32
+	SubgroupIDs []string
+}
+
+func (u *UpdateGroupMembersRequest) Validate() error {
+	if u.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if u.GroupID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid group id")
+	}
+
+	return nil
+}
+
+// RespondToInvitationRequest answers an invitation on behalf of whoever holds its token, the
+// attendee doesn't need to be authenticated.
+type RespondToInvitationRequest struct {
+	Token    string
+	Response InvitationStatus
+	Comment  string
+	// Proposal is a new time proposed to the organizer, it replaces the previous proposal.
+	Proposal *TimeProposal
+	// ScheduleID and OriginalStart scope the response to a single occurrence when set, overriding
+	// the response to the invitation for that occurrence.
+	ScheduleID    string
+	OriginalStart time.Time
+}
+
+// IsForOccurrence tells whether the response is scoped to a single occurrence.
+func (r *RespondToInvitationRequest) IsForOccurrence() bool {
+	return r.ScheduleID != ""
+}
+
+func (r *RespondToInvitationRequest) Validate() error {
+	if r.Token == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid token")
+	}
+
+	if r.Response != InvitationStatus_Confirmed && r.Response != InvitationStatus_Declined && r.Response != InvitationStatus_Tentative {
+		return internal.WrapErr(internal.ErrValidationFailed, "the response must confirm, decline or tentatively accept the invitation")
+	}
+
+	if len(r.Comment) > 1000 {
+		return internal.WrapErr(internal.ErrValidationFailed, "the comment must not exceed 1000 characters")
+	}
+
+	if r.IsForOccurrence() != !r.OriginalStart.IsZero() {
+		return internal.WrapErr(internal.ErrValidationFailed, "the response to an occurrence needs both its schedule id and original start time")
+	}
+
+	if r.Proposal != nil {
+		if r.IsForOccurrence() {
+			return internal.WrapErr(internal.ErrValidationFailed, "a new time can only be proposed for the whole series")
+		}
+		return r.Proposal.Validate()
+	}
+
+	return nil
+}
+
+// RegenerateInvitationTokenRequest issues a new token to the invitation of the attendee, i.e: when
+// a link leaked. The previous token stops working. Only the organizers of the event can do it.
+type RegenerateInvitationTokenRequest struct {
+	ActorID string
+	EventID string
+	// UserID or GuestEmail is the attendee of the invitation.
+	UserID     int32
+	GuestEmail string
+}
+
+func (r *RegenerateInvitationTokenRequest) Validate() error {
+	if r.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if r.EventID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
+	}
+
+	if (r.UserID > 0) == (r.GuestEmail != "") || r.UserID < 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, "either a user id or a guest email is required")
+	}
+
+	return nil
+}
+
+type ListProposalsRequest struct {
+	ActorID string
+	EventID string
+}
+
+func (l *ListProposalsRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if l.EventID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
+	}
+
+	return nil
+}
+
+// AcceptProposalRequest moves a schedule of the event to the time proposed by an attendee. The
+// responses of the other attendees are reset, as they answered for the previous time, apart from
+// the ones of the organizers and the waitlisted attendees.
+type AcceptProposalRequest struct {
+	ActorID        string
+	EventID        string
+	InvitationID   string
+	AllowConflicts bool
+}
+
+func (a *AcceptProposalRequest) Validate() error {
+	if a.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if a.EventID == "" || a.InvitationID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event or invitation id")
+	}
+
+	return nil
+}
+
+// ListMyInvitationsRequest lists the invitations of the actor, optionally filtered by their status
+// and by the time range of their events.
+type ListMyInvitationsRequest struct {
+	ActorID  string
+	Statuses []InvitationStatus
+	From     time.Time
+	To       time.Time
+	// PageSize is DefaultPageSize when zero.
+	PageSize  int
+	PageToken string
+}
+
+func (l *ListMyInvitationsRequest) Validate() error {
+	if _, err := strconv.ParseInt(l.ActorID, 10, 32); err != nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if !l.From.IsZero() && !l.To.IsZero() && !l.From.Before(l.To) {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid time range")
+	}
+
+	if l.PageSize < 0 || l.PageSize > MaxPageSize {
+		return internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("the page size must be between 1 and %d", MaxPageSize))
+	}
+
+	_, err := pageOffset(l.PageToken)
+	return err
+}
+
+// Filter returns the filter of the requested page, asking for one more invitation than the page
+// size to tell whether a following page exists.
+func (l *ListMyInvitationsRequest) Filter() InvitationFilter {
+	userID, _ := strconv.ParseInt(l.ActorID, 10, 32)
+	offset, _ := pageOffset(l.PageToken)
+	return InvitationFilter{
+		UserID:   int32(userID),
+		Statuses: l.Statuses,
+		From:     l.From,
+		To:       l.To,
+		Limit:    l.pageSize() + 1,
+		Offset:   offset,
+	}
+}
+
+func (l *ListMyInvitationsRequest) pageSize() int {
+	if l.PageSize == 0 {
+		return DefaultPageSize
+	}
+	return l.PageSize
+}
+
 //go:generate go tool -modfile=../../go.tool.mod mockgen -destination=../mock/mock_scheduling_service.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core SchedulingService
 type SchedulingService interface {
-	CreateEvent(ctx context.Context, req *CreateEventRequest) error
+	// CreateEvent returns the occurrences falling outside the working hours of the attendees, as a warning.
+	CreateEvent(ctx context.Context, req *CreateEventRequest) ([]OutsideWorkingHours, error)
 	DeleteEventByID(ctx context.Context, req *DeleteEventByIDRequest) error
 	UpdateEvent(ctx context.Context, req *UpdateEventRequest) error
 	FindEventByID(ctx context.Context, req *FindEventByIDRequest) (*Event, error)
+	ListOccurrences(ctx context.Context, req *ListOccurrencesRequest) (*OccurrenceList, error)
+	CancelOccurrence(ctx context.Context, req *CancelOccurrenceRequest) error
+	UpdateOccurrence(ctx context.Context, req *UpdateOccurrenceRequest) error
+	QueryFreeBusy(ctx context.Context, req *QueryFreeBusyRequest) ([]FreeBusy, error)
+	SuggestMeetingTimes(ctx context.Context, req *SuggestMeetingTimesRequest) ([]MeetingSuggestion, error)
+	GetAvailability(ctx context.Context, req *GetAvailabilityRequest) (*Availability, error)
+	UpdateAvailability(ctx context.Context, req *UpdateAvailabilityRequest) error
+	CreateResource(ctx context.Context, req *CreateResourceRequest) error
+	FindResourceByID(ctx context.Context, req *FindResourceByIDRequest) (*Resource, error)
+	ListResources(ctx context.Context) ([]Resource, error)
+	CreateHolidayCalendar(ctx context.Context, req *CreateHolidayCalendarRequest) error
+	FindHolidayCalendarByID(ctx context.Context, req *FindHolidayCalendarByIDRequest) (*HolidayCalendar, error)
+	CreateGroup(ctx context.Context, req *CreateGroupRequest) error
+	FindGroupByID(ctx context.Context, req *FindGroupByIDRequest) (*Group, error)
+	UpdateGroupMembers(ctx context.Context, req *UpdateGroupMembersRequest) error
+	// RespondToInvitation returns the invitation holding the response.
+	RespondToInvitation(ctx context.Context, req *RespondToInvitationRequest) (*Invitation, error)
+	// RegenerateInvitationToken returns the invitation holding its new token.
+	RegenerateInvitationToken(ctx context.Context, req *RegenerateInvitationTokenRequest) (*Invitation, error)
+	// ListProposals returns the invitations of the event holding a proposal, to its organizer.
+	ListProposals(ctx context.Context, req *ListProposalsRequest) ([]Invitation, error)
+	AcceptProposal(ctx context.Context, req *AcceptProposalRequest) error
+	ListMyInvitations(ctx context.Context, req *ListMyInvitationsRequest) (*InvitationPage, error)
 }
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+const (
+	// SuggestionStep is the spacing of the candidate start times, aligned to the wall clock.
+	SuggestionStep = 30 * time.Minute
+	// DefaultSuggestionLimit is the number of suggestions returned when no limit is requested.
+	DefaultSuggestionLimit = 10
+	// MaxSuggestionLimit is the highest number of suggestions returned.
+	MaxSuggestionLimit = 50
+)
+
+// WorkingHours restricts the time of day of meetings, in minutes since midnight.
+type WorkingHours struct {
+	// Days allowed for meetings, every day when empty.
+	Days        []time.Weekday
+	StartMinute int
+	EndMinute   int
+}
+
+// ParseWorkingHours parses working hours given as "HH:MM" wall clock times.
+func ParseWorkingHours(days []time.Weekday, start, end string) (WorkingHours, error) {
+	startMinute, err := parseClock(start)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+
+	endMinute, err := parseClock(end)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+
+	wh := WorkingHours{Days: days, StartMinute: startMinute, EndMinute: endMinute}
+	return wh, wh.Validate()
+}
+
+func parseClock(s string) (int, error) {
+	hh, mm, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("invalid time of day %q", s))
+	}
+
+	hour, err := strconv.Atoi(hh)
+	if err != nil {
+		return 0, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("invalid time of day %q", s))
+	}
+
+	minute, err := strconv.Atoi(mm)
+	if err != nil || minute < 0 || minute > 59 || hour < 0 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("invalid time of day %q", s))
+	}
+
+	return hour*60 + minute, nil
+}
+
+func (w WorkingHours) Validate() error {
+	if w.StartMinute < 0 || w.EndMinute > 24*60 || w.StartMinute >= w.EndMinute {
+		return internal.WrapErr(internal.ErrValidationFailed, "working hours must start before they end")
+	}
+
+	for _, day := range w.Days {
+		if day < time.Sunday || day > time.Saturday {
+			return internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("invalid day %d", day))
+		}
+	}
+
+	return nil
+}
+
+// Contains reports whether a meeting of the given duration starting at t, in t's location, is
+// within the working hours.
+func (w WorkingHours) Contains(t time.Time, duration time.Duration) bool {
+	if len(w.Days) > 0 && !slices.Contains(w.Days, t.Weekday()) {
+		return false
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	return minute >= w.StartMinute && minute+int(duration/time.Minute) <= w.EndMinute
+}
+
+// MeetingSuggestion is a candidate time for a meeting, with the attendees already busy at that time.
+type MeetingSuggestion struct {
+	Start               time.Time
+	End                 time.Time
+	ConflictingRequired []int32
+	ConflictingOptional []int32
+}
+
+// SuggestMeetings returns the candidate slots of the given duration within [from, to), in the
+// given location, ranked by the number of busy required attendees, then the number of busy
+// optional attendees, then the start time.
+func SuggestMeetings(freeBusy []FreeBusy, required, optional []int32, duration time.Duration, from, to time.Time, loc *time.Location, workingHours *WorkingHours, limit int) []MeetingSuggestion {
+	busy := make(map[int32][]BusyInterval, len(freeBusy))
+	for _, fb := range freeBusy {
+		busy[fb.UserID] = fb.Busy
+	}
+	// next is the index of the first busy interval of every user not ending before the slot, as
+	// the slots are generated in order.
+	next := make(map[int32]int, len(freeBusy))
+	isBusy := func(userID int32, start, end time.Time) bool {
+		intervals := busy[userID]
+		i := next[userID]
+		for i < len(intervals) && !intervals[i].End.After(start) {
+			i++
+		}
+		next[userID] = i
+		return i < len(intervals) && intervals[i].Start.Before(end)
+	}
+
+	var suggestions []MeetingSuggestion
+	for _, start := range candidateStarts(from, to, duration, loc) {
+		end := start.Add(duration)
+		if workingHours != nil && !workingHours.Contains(start, duration) {
+			continue
+		}
+
+		suggestion := MeetingSuggestion{Start: start, End: end}
+		for _, userID := range required {
+			if isBusy(userID, start, end) {
+				suggestion.ConflictingRequired = append(suggestion.ConflictingRequired, userID)
+			}
+		}
+		for _, userID := range optional {
+			if isBusy(userID, start, end) {
+				suggestion.ConflictingOptional = append(suggestion.ConflictingOptional, userID)
+			}
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b MeetingSuggestion) int {
+		if c := len(a.ConflictingRequired) - len(b.ConflictingRequired); c != 0 {
+			return c
+		}
+		return len(a.ConflictingOptional) - len(b.ConflictingOptional)
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// candidateStarts returns the start times within [from, to) lying on a SuggestionStep boundary of
+// the wall clock in loc, for meetings ending by to.
+func candidateStarts(from, to time.Time, duration time.Duration, loc *time.Location) []time.Time {
+	stepMinutes := int(SuggestionStep / time.Minute)
+
+	var starts []time.Time
+	day := from.In(loc)
+	for d := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc); d.Before(to); d = time.Date(d.Year(), d.Month(), d.Day()+1, 0, 0, 0, 0, loc) {
+		for minute := 0; minute < 24*60; minute += stepMinutes {
+			start := time.Date(d.Year(), d.Month(), d.Day(), 0, minute, 0, 0, loc)
+			if start.Before(from) || start.Add(duration).After(to) {
+				continue
+			}
+			// Wall clock times skipped by a DST transition normalise to a time already listed.
+			if len(starts) > 0 && !start.After(starts[len(starts)-1]) {
+				continue
+			}
+			starts = append(starts, start)
+		}
+	}
+	return starts
+}
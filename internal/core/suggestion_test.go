@@ -0,0 +1,135 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestMeetings(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	// Tuesday, 4 January 2022 in Asia/Jakarta
+	day := time.Date(2022, 1, 4, 0, 0, 0, 0, jakarta)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	freeBusy := []core.FreeBusy{
+		{UserID: 1, Busy: []core.BusyInterval{{Start: at(9, 0), End: at(10, 0)}}},
+		{UserID: 2, Busy: []core.BusyInterval{{Start: at(10, 0), End: at(11, 0)}}},
+		{UserID: 3, Busy: []core.BusyInterval{{Start: at(11, 0), End: at(12, 0)}}},
+	}
+
+	tests := []struct {
+		name         string
+		workingHours *core.WorkingHours
+		from         time.Time
+		to           time.Time
+		limit        int
+		want         []core.MeetingSuggestion
+	}{
+		{
+			name:  "free slots first",
+			from:  at(9, 0),
+			to:    at(13, 0),
+			limit: 4,
+			want: []core.MeetingSuggestion{
+				{Start: at(12, 0), End: at(13, 0)},
+				{Start: at(11, 0), End: at(12, 0), ConflictingOptional: []int32{3}},
+				{Start: at(11, 30), End: at(12, 30), ConflictingOptional: []int32{3}},
+				{Start: at(9, 0), End: at(10, 0), ConflictingRequired: []int32{1}},
+			},
+		},
+		{
+			name:  "aligned to the wall clock",
+			from:  at(12, 10),
+			to:    at(18, 0),
+			limit: 2,
+			want: []core.MeetingSuggestion{
+				{Start: at(12, 30), End: at(13, 30)},
+				{Start: at(13, 0), End: at(14, 0)},
+			},
+		},
+		{
+			name:         "outside of working hours",
+			workingHours: &core.WorkingHours{Days: []time.Weekday{time.Tuesday}, StartMinute: 9 * 60, EndMinute: 12 * 60},
+			from:         at(9, 0),
+			to:           at(18, 0),
+			limit:        1,
+			want: []core.MeetingSuggestion{
+				{Start: at(11, 0), End: at(12, 0), ConflictingOptional: []int32{3}},
+			},
+		},
+		{
+			name:         "outside of working days",
+			workingHours: &core.WorkingHours{Days: []time.Weekday{time.Monday}, StartMinute: 0, EndMinute: 24 * 60},
+			from:         at(9, 0),
+			to:           at(18, 0),
+			limit:        1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := core.SuggestMeetings(freeBusy, []int32{1, 2}, []int32{3}, time.Hour, tt.from, tt.to, jakarta, tt.workingHours, tt.limit)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.True(t, tt.want[i].Start.Equal(got[i].Start), "got %s", got[i].Start)
+				assert.True(t, tt.want[i].End.Equal(got[i].End))
+				assert.Equal(t, tt.want[i].ConflictingRequired, got[i].ConflictingRequired)
+				assert.Equal(t, tt.want[i].ConflictingOptional, got[i].ConflictingOptional)
+			}
+		})
+	}
+}
+
+func TestParseWorkingHours(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		want    core.WorkingHours
+		wantErr error
+	}{
+		{
+			name:  "OK",
+			start: "09:00",
+			end:   "17:30",
+			want:  core.WorkingHours{StartMinute: 9 * 60, EndMinute: 17*60 + 30},
+		},
+		{
+			name:  "OK - until midnight",
+			start: "18:00",
+			end:   "24:00",
+			want:  core.WorkingHours{StartMinute: 18 * 60, EndMinute: 24 * 60},
+		},
+		{
+			name:    "Not OK - invalid time of day",
+			start:   "9am",
+			end:     "17:00",
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name:    "Not OK - ends before it starts",
+			start:   "17:00",
+			end:     "09:00",
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := core.ParseWorkingHours(nil, tt.start, tt.end)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+// User is someone who can be invited to the events by ID. A deactivated user stays on the events
+// already attended, but can't be invited anymore.
+type User struct {
+	ID       int32  `db:"id"`
+	Name     string `validate:"required,max=50" db:"name"`
+	Timezone string `db:"timezone"`
+	// DeactivatedAt is when the user was deactivated, nil while active.
+	DeactivatedAt *time.Time `db:"deactivated_at"`
+}
+
+func (u *User) Validate() error {
+	err := validate.Struct(u)
+	if err != nil {
+		return internal.WrapErr(internal.ErrValidationFailed, err.Error())
+	}
+
+	if _, err := time.LoadLocation(u.Timezone); err != nil || u.Timezone == "" {
+		return internal.WrapErr(internal.ErrInvalidTimezone, u.Timezone)
+	}
+
+	return nil
+}
+
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
+
+// UserFilter selects a page of the users, ordered by ID.
+type UserFilter struct {
+	IncludeDeactivated bool
+	Limit              int
+	Offset             int
+}
+
+// CheckAttendees fails with internal.ErrValidationFailed, naming them, when some of the users
+// invited are unknown or deactivated. users is what was found of userIDs.
+func CheckAttendees(userIDs []int32, users []User) error {
+	var unknown, deactivated []string
+	for _, id := range userIDs {
+		idx := slices.IndexFunc(users, func(u User) bool {
+			return u.ID == id
+		})
+		switch {
+		case idx < 0:
+			unknown = append(unknown, fmt.Sprint(id))
+		case !users[idx].IsActive():
+			deactivated = append(deactivated, fmt.Sprint(id))
+		}
+	}
+
+	var problems []string
+	if len(unknown) > 0 {
+		problems = append(problems, "unknown attendees "+strings.Join(unknown, ", "))
+	}
+	if len(deactivated) > 0 {
+		problems = append(problems, "deactivated attendees "+strings.Join(deactivated, ", "))
+	}
+	if len(problems) > 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// InvitedUserIDs returns the users invited to the event, leaving out the guests.
+func (e *Event) InvitedUserIDs() []int32 {
+	var ids []int32
+	for _, inv := range e.Invitations {
+		if inv.Guest == nil && !slices.Contains(ids, inv.UserID) {
+			ids = append(ids, inv.UserID)
+		}
+	}
+	return ids
+}
+
+//go:generate go tool -modfile=../../go.tool.mod mockgen -destination=../mock/mock_user_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core UserRepository
+type UserRepository interface {
+	// Store stores the new user, setting its ID.
+	Store(ctx context.Context, u *User) error
+	FindByID(ctx context.Context, id int32) (*User, error)
+	// FindByIDs returns the users found among the IDs, skipping the unknown ones.
+	FindByIDs(ctx context.Context, ids []int32) ([]User, error)
+	FindByFilter(ctx context.Context, filter UserFilter) ([]User, error)
+	// Deactivate deactivates the user at the given time, keeping the time of an earlier deactivation.
+	Deactivate(ctx context.Context, id int32, at time.Time) error
+}
+
+// CreateUserRequest adds a user to the directory, which any caller can do.
+type CreateUserRequest struct {
+	User *User
+}
+
+func (c *CreateUserRequest) Validate() error {
+	if c.User == nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid user")
+	}
+
+	return c.User.Validate()
+}
+
+type GetUserRequest struct {
+	ID int32
+}
+
+func (g *GetUserRequest) Validate() error {
+	if g.ID <= 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid user id")
+	}
+
+	return nil
+}
+
+type ListUsersRequest struct {
+	IncludeDeactivated bool
+	// PageSize is DefaultPageSize when zero.
+	PageSize  int
+	PageToken string
+}
+
+func (l *ListUsersRequest) Validate() error {
+	if l.PageSize < 0 || l.PageSize > MaxPageSize {
+		return internal.WrapErr(internal.ErrValidationFailed, fmt.Sprintf("the page size must be between 1 and %d", MaxPageSize))
+	}
+
+	_, err := pageOffset(l.PageToken)
+	return err
+}
+
+// Filter returns the filter of the requested page, asking for one more user than the page size to
+// tell whether a following page exists.
+func (l *ListUsersRequest) Filter() UserFilter {
+	offset, _ := pageOffset(l.PageToken)
+	pageSize := l.PageSize
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	return UserFilter{
+		IncludeDeactivated: l.IncludeDeactivated,
+		Limit:              pageSize + 1,
+		Offset:             offset,
+	}
+}
+
+type UserPage struct {
+	Users []User
+	// NextPageToken is the token of the following page, empty on the last page.
+	NextPageToken string
+}
+
+// DeactivateUserRequest deactivates a user, which only the user can do.
+type DeactivateUserRequest struct {
+	ActorID string
+	ID      int32
+}
+
+func (d *DeactivateUserRequest) Validate() error {
+	if d.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if d.ID <= 0 {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid user id")
+	}
+
+	return nil
+}
+
+//go:generate go tool -modfile=../../go.tool.mod mockgen -destination=../mock/mock_user_service.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core UserService
+type UserService interface {
+	CreateUser(ctx context.Context, req *CreateUserRequest) error
+	GetUser(ctx context.Context, req *GetUserRequest) (*User, error)
+	ListUsers(ctx context.Context, req *ListUsersRequest) (*UserPage, error)
+	DeactivateUser(ctx context.Context, req *DeactivateUserRequest) error
+}
@@ -0,0 +1,62 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAttendees(t *testing.T) {
+	deactivatedAt := time.Date(2022, time.January, 1, 9, 0, 0, 0, time.UTC)
+	users := []core.User{
+		{ID: 1, Name: "Foo", Timezone: "UTC"},
+		{ID: 2, Name: "Bar", Timezone: "UTC", DeactivatedAt: &deactivatedAt},
+	}
+
+	tests := []struct {
+		name    string
+		userIDs []int32
+		wantErr string
+	}{
+		{
+			name:    "OK",
+			userIDs: []int32{1},
+		},
+		{
+			name:    "Not OK - unknown",
+			userIDs: []int32{1, 7, 8},
+			wantErr: "unknown attendees 7, 8",
+		},
+		{
+			name:    "Not OK - deactivated",
+			userIDs: []int32{2},
+			wantErr: "deactivated attendees 2",
+		},
+		{
+			name:    "Not OK - unknown and deactivated",
+			userIDs: []int32{2, 7},
+			wantErr: "unknown attendees 7; deactivated attendees 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := core.CheckAttendees(tt.userIDs, users)
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, internal.ErrValidationFailed)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEvent_InvitedUserIDs(t *testing.T) {
+	guest := core.NewInvitation("123", 0)
+	guest.Guest = &core.Guest{Email: "guest@example.com"}
+	event := &core.Event{Invitations: []core.Invitation{core.NewInvitation("123", 2), guest, core.NewInvitation("123", 3)}}
+	assert.Equal(t, []int32{2, 3}, event.InvitedUserIDs())
+}
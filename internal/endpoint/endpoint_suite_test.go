@@ -1,10 +1,15 @@
 package endpoint_test
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"testing"
 
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
@@ -21,6 +26,11 @@ var (
 	postgresResource *dockertest.Resource
 
 	db *sqlx.DB
+
+	tokenSigner, _ = core.NewInvitationTokenSigner(internal.InvitationTokenConfig{
+		KeyID:  "test",
+		Secret: "test-invitation-token-secret-of-32-bytes",
+	})
 )
 
 func TestEndpoints(t *testing.T) {
@@ -34,6 +44,7 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 	Expect(err).ShouldNot(HaveOccurred())
 
 	dbURL := runDockerPostgres(pool)
+	seedUsers(dbURL)
 
 	return []byte(dbURL)
 }, func(dbURL []byte) {
@@ -43,6 +54,11 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 	db = dbConn
 })
 
+// Events are removed after every spec, so the attendees of a spec are never booked by another one.
+var _ = AfterEach(func() {
+	Expect(gen.New(db).DeleteAllEvents(context.Background())).ShouldNot(HaveOccurred())
+})
+
 var _ = SynchronizedAfterSuite(func() {
 }, func() {
 	Expect(pool.Purge(postgresResource)).ShouldNot(HaveOccurred())
@@ -90,6 +106,21 @@ func runDockerPostgres(pool *dockertest.Pool) string {
 	return dbUrl
 }
 
+// seedUsers adds the users the specs invite besides the ones seeded by the migrations, taking the
+// IDs 4 to 7.
+func seedUsers(dbURL string) {
+	dbConn, err := sqlx.Open("pgx", dbURL)
+	Expect(err).ShouldNot(HaveOccurred())
+	defer func() {
+		_ = dbConn.Close()
+	}()
+
+	userRepo := postgresql.NewUserRepository(dbConn)
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave"} {
+		Expect(userRepo.Store(context.Background(), &core.User{Name: name, Timezone: "UTC"})).ShouldNot(HaveOccurred())
+	}
+}
+
 func migrateDB(dbUrl string) error {
 	m, err := migrate.New(
 		"file://../../sql/migrations",
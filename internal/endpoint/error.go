@@ -2,8 +2,13 @@ package endpoint
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -15,9 +20,64 @@ func mapErrToStatusCode(err error) error {
 
 	if errors.Is(err, internal.ErrInvalidRequest) ||
 		errors.Is(err, internal.ErrInvalidTimezone) ||
-		errors.Is(err, internal.ErrValidationFailed) {
+		errors.Is(err, internal.ErrValidationFailed) ||
+		errors.Is(err, internal.ErrInvalidRecurrenceRule) {
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if errors.Is(err, internal.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	if errors.Is(err, internal.ErrInvalidToken) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if errors.Is(err, internal.ErrPermissionDenied) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	var conflictErr *core.ConflictError
+	if errors.As(err, &conflictErr) {
+		return conflictStatus(conflictErr)
+	}
+
 	return status.Error(codes.Internal, err.Error())
 }
+
+// conflictStatus is a FailedPrecondition status listing the conflicting events, the attendees
+// outside of their working hours and the resources already reserved as violations.
+func conflictStatus(err *core.ConflictError) error {
+	violations := make([]*errdetails.PreconditionFailure_Violation, 0, len(err.Conflicts)+len(err.OutsideWorkingHours)+len(err.ReservedResources))
+	for _, c := range err.Conflicts {
+		violations = append(violations, &errdetails.PreconditionFailure_Violation{
+			Type:    "CONFLICT",
+			Subject: c.EventID,
+			Description: fmt.Sprintf("users %v already attend %q from %s to %s",
+				c.UserIDs, c.Title, c.Start.Format(time.RFC3339), c.End.Format(time.RFC3339)),
+		})
+	}
+	for _, o := range err.OutsideWorkingHours {
+		violations = append(violations, &errdetails.PreconditionFailure_Violation{
+			Type:    "OUTSIDE_WORKING_HOURS",
+			Subject: strconv.Itoa(int(o.UserID)),
+			Description: fmt.Sprintf("the occurrence from %s to %s is outside the working hours of user %d",
+				o.Start.Format(time.RFC3339), o.End.Format(time.RFC3339), o.UserID),
+		})
+	}
+
+	for _, resourceID := range err.ReservedResources {
+		violations = append(violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "RESOURCE_RESERVED",
+			Subject:     resourceID,
+			Description: fmt.Sprintf("resource %s is already reserved at the same time", resourceID),
+		})
+	}
+
+	st, detailsErr := status.New(codes.FailedPrecondition, err.Error()).
+		WithDetails(&errdetails.PreconditionFailure{Violations: violations})
+	if detailsErr != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return st.Err()
+}
@@ -2,25 +2,28 @@ package endpoint
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
 	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal/auth"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type GRPCEndpoint struct {
 	v1.UnimplementedAPIServer
-	svc core.SchedulingService
+	svc     core.SchedulingService
+	userSvc core.UserService
 }
 
-func NewGRPCEndpoint(svc core.SchedulingService) *GRPCEndpoint {
+func NewGRPCEndpoint(svc core.SchedulingService, userSvc core.UserService) *GRPCEndpoint {
 	return &GRPCEndpoint{
-		svc: svc,
+		svc:     svc,
+		userSvc: userSvc,
 	}
 }
 
@@ -31,13 +34,24 @@ func (g *GRPCEndpoint) CreateEvent(ctx context.Context, req *v1.CreateEventReque
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	err = g.svc.CreateEvent(ctx, createReq)
+	outside, err := g.svc.CreateEvent(ctx, createReq)
 	if err != nil {
 		slog.Error(err.Error())
 		return nil, mapErrToStatusCode(err)
 	}
+
+	warnings := make([]*v1.OutsideWorkingHours, len(outside))
+	for index, o := range outside {
+		warnings[index] = &v1.OutsideWorkingHours{
+			UserId:    o.UserID,
+			StartTime: o.Start.Format(time.RFC3339),
+			EndTime:   o.End.Format(time.RFC3339),
+		}
+	}
+
 	return &v1.CreateEventResponse{
-		Id: createReq.Event.ID,
+		Id:       createReq.Event.ID,
+		Warnings: warnings,
 	}, nil
 }
 
@@ -56,7 +70,7 @@ func (g *GRPCEndpoint) DeleteEventByID(ctx context.Context, req *v1.DeleteEventB
 	return &emptypb.Empty{}, nil
 }
 
-func (g *GRPCEndpoint) UpdateEvent(ctx context.Context, req *v1.UpdateEventRequest) (*emptypb.Empty, error) {
+func (g *GRPCEndpoint) UpdateEvent(ctx context.Context, req *v1.UpdateEventRequest) (*v1.UpdateEventResponse, error) {
 	updateReq, err := parseUpdateEventByIDRequest(ctx, req)
 	if err != nil {
 		slog.Error(err.Error())
@@ -68,11 +82,16 @@ func (g *GRPCEndpoint) UpdateEvent(ctx context.Context, req *v1.UpdateEventReque
 		slog.Error(err.Error())
 		return nil, mapErrToStatusCode(err)
 	}
-	return &emptypb.Empty{}, nil
+	return &v1.UpdateEventResponse{
+		Id: updateReq.Event.ID,
+	}, nil
 }
 
 func (g *GRPCEndpoint) FindEventByID(ctx context.Context, req *v1.FindEventByIDRequest) (*v1.FindEventByIDResponse, error) {
-	event, err := g.svc.FindEventByID(ctx, &core.FindEventByIDRequest{EventID: req.GetId()})
+	event, err := g.svc.FindEventByID(ctx, &core.FindEventByIDRequest{
+		ActorID: extractAuthorization(ctx),
+		EventID: req.GetId(),
+	})
 	if err != nil {
 		slog.Error(err.Error())
 		return nil, mapErrToStatusCode(err)
@@ -88,6 +107,396 @@ func (g *GRPCEndpoint) FindEventByID(ctx context.Context, req *v1.FindEventByIDR
 	}, nil
 }
 
+func (g *GRPCEndpoint) ListOccurrences(ctx context.Context, req *v1.ListOccurrencesRequest) (*v1.ListOccurrencesResponse, error) {
+	listReq, err := parseListOccurrencesRequest(ctx, req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	list, err := g.svc.ListOccurrences(ctx, listReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	occurrences := make([]*v1.Occurrence, len(list.Occurrences))
+	for index, o := range list.Occurrences {
+		occurrences[index] = parseOccurrenceToPB(&o)
+	}
+
+	return &v1.ListOccurrencesResponse{
+		Occurrences: occurrences,
+		Truncated:   list.Truncated,
+	}, nil
+}
+
+func (g *GRPCEndpoint) CancelOccurrence(ctx context.Context, req *v1.CancelOccurrenceRequest) (*emptypb.Empty, error) {
+	cancelReq, err := parseCancelOccurrenceRequest(ctx, req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = g.svc.CancelOccurrence(ctx, cancelReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) UpdateOccurrence(ctx context.Context, req *v1.UpdateOccurrenceRequest) (*emptypb.Empty, error) {
+	updateReq, err := parseUpdateOccurrenceRequest(ctx, req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = g.svc.UpdateOccurrence(ctx, updateReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) QueryFreeBusy(ctx context.Context, req *v1.QueryFreeBusyRequest) (*v1.QueryFreeBusyResponse, error) {
+	queryReq, err := parseQueryFreeBusyRequest(req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	freeBusy, err := g.svc.QueryFreeBusy(ctx, queryReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	users := make([]*v1.FreeBusy, len(freeBusy))
+	for index, fb := range freeBusy {
+		busy := make([]*v1.BusyInterval, len(fb.Busy))
+		for i, interval := range fb.Busy {
+			busy[i] = &v1.BusyInterval{
+				StartTime: interval.Start.Format(time.RFC3339),
+				EndTime:   interval.End.Format(time.RFC3339),
+			}
+		}
+		users[index] = &v1.FreeBusy{
+			UserId: fb.UserID,
+			Busy:   busy,
+		}
+	}
+
+	return &v1.QueryFreeBusyResponse{
+		Users: users,
+	}, nil
+}
+
+func (g *GRPCEndpoint) SuggestMeetingTimes(ctx context.Context, req *v1.SuggestMeetingTimesRequest) (*v1.SuggestMeetingTimesResponse, error) {
+	suggestReq, err := parseSuggestMeetingTimesRequest(req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	suggestions, err := g.svc.SuggestMeetingTimes(ctx, suggestReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := make([]*v1.MeetingSuggestion, len(suggestions))
+	for index, s := range suggestions {
+		res[index] = &v1.MeetingSuggestion{
+			StartTime:                    s.Start.Format(time.RFC3339),
+			EndTime:                      s.End.Format(time.RFC3339),
+			ConflictingRequiredAttendees: s.ConflictingRequired,
+			ConflictingOptionalAttendees: s.ConflictingOptional,
+		}
+	}
+
+	return &v1.SuggestMeetingTimesResponse{
+		Suggestions: res,
+	}, nil
+}
+
+func (g *GRPCEndpoint) GetAvailability(ctx context.Context, req *v1.GetAvailabilityRequest) (*v1.GetAvailabilityResponse, error) {
+	availability, err := g.svc.GetAvailability(ctx, &core.GetAvailabilityRequest{
+		UserID: req.GetUserId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.GetAvailabilityResponse{
+		Availability: parseAvailabilityToPB(availability),
+	}, nil
+}
+
+func (g *GRPCEndpoint) UpdateAvailability(ctx context.Context, req *v1.UpdateAvailabilityRequest) (*emptypb.Empty, error) {
+	updateReq, err := parseUpdateAvailabilityRequest(ctx, req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = g.svc.UpdateAvailability(ctx, updateReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) CreateResource(ctx context.Context, req *v1.CreateResourceRequest) (*v1.CreateResourceResponse, error) {
+	createReq, err := parseCreateResourceRequest(ctx, req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = g.svc.CreateResource(ctx, createReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.CreateResourceResponse{
+		Id: createReq.Resource.ID,
+	}, nil
+}
+
+func (g *GRPCEndpoint) GetResource(ctx context.Context, req *v1.GetResourceRequest) (*v1.GetResourceResponse, error) {
+	resource, err := g.svc.FindResourceByID(ctx, &core.FindResourceByIDRequest{
+		ID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.GetResourceResponse{
+		Resource: parseResourceToPB(resource),
+	}, nil
+}
+
+func (g *GRPCEndpoint) ListResources(ctx context.Context, _ *emptypb.Empty) (*v1.ListResourcesResponse, error) {
+	resources, err := g.svc.ListResources(ctx)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := make([]*v1.Resource, len(resources))
+	for i := range resources {
+		res[i] = parseResourceToPB(&resources[i])
+	}
+	return &v1.ListResourcesResponse{
+		Resources: res,
+	}, nil
+}
+
+func (g *GRPCEndpoint) CreateGroup(ctx context.Context, req *v1.CreateGroupRequest) (*v1.CreateGroupResponse, error) {
+	createReq, err := parseCreateGroupRequest(ctx, req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = g.svc.CreateGroup(ctx, createReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.CreateGroupResponse{
+		Id: createReq.Group.ID,
+	}, nil
+}
+
+func (g *GRPCEndpoint) GetGroup(ctx context.Context, req *v1.GetGroupRequest) (*v1.GetGroupResponse, error) {
+	group, err := g.svc.FindGroupByID(ctx, &core.FindGroupByIDRequest{
+		ID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.GetGroupResponse{
+		Group: parseGroupToPB(group),
+	}, nil
+}
+
+func (g *GRPCEndpoint) UpdateGroupMembers(ctx context.Context, req *v1.UpdateGroupMembersRequest) (*emptypb.Empty, error) {
+	err := g.svc.UpdateGroupMembers(ctx, &core.UpdateGroupMembersRequest{
+		ActorID:     extractAuthorization(ctx),
+		GroupID:     req.GetId(),
+		MemberIDs:   req.GetMemberIds(),
+		SubgroupIDs: req.GetSubgroupIds(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) CreateHolidayCalendar(ctx context.Context, req *v1.CreateHolidayCalendarRequest) (*v1.CreateHolidayCalendarResponse, error) {
+	createReq, err := parseCreateHolidayCalendarRequest(ctx, req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = g.svc.CreateHolidayCalendar(ctx, createReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.CreateHolidayCalendarResponse{
+		Id: createReq.HolidayCalendar.ID,
+	}, nil
+}
+
+func (g *GRPCEndpoint) GetHolidayCalendar(ctx context.Context, req *v1.GetHolidayCalendarRequest) (*v1.GetHolidayCalendarResponse, error) {
+	calendar, err := g.svc.FindHolidayCalendarByID(ctx, &core.FindHolidayCalendarByIDRequest{
+		ID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.GetHolidayCalendarResponse{
+		HolidayCalendar: parseHolidayCalendarToPB(calendar),
+	}, nil
+}
+
+func (g *GRPCEndpoint) RespondToInvitation(ctx context.Context, req *v1.RespondToInvitationRequest) (*v1.RespondToInvitationResponse, error) {
+	respondReq, err := parseRespondToInvitationRequest(req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	inv, err := g.svc.RespondToInvitation(ctx, respondReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := &v1.RespondToInvitationResponse{
+		EventId: inv.EventID,
+		Status:  mapInvitationStatusToPB(inv.Status),
+	}
+	if respondReq.IsForOccurrence() {
+		res.Status = mapInvitationStatusToPB(inv.StatusAt(respondReq.ScheduleID, respondReq.OriginalStart))
+	}
+	return res, nil
+}
+
+func (g *GRPCEndpoint) RegenerateInvitationToken(ctx context.Context, req *v1.RegenerateInvitationTokenRequest) (*v1.RegenerateInvitationTokenResponse, error) {
+	inv, err := g.svc.RegenerateInvitationToken(ctx, &core.RegenerateInvitationTokenRequest{
+		ActorID:    extractAuthorization(ctx),
+		EventID:    req.GetId(),
+		UserID:     req.GetUserId(),
+		GuestEmail: req.GetGuestEmail(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.RegenerateInvitationTokenResponse{
+		Token:     inv.Token,
+		ExpiresAt: inv.TokenExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (g *GRPCEndpoint) ListProposals(ctx context.Context, req *v1.ListProposalsRequest) (*v1.ListProposalsResponse, error) {
+	invitations, err := g.svc.ListProposals(ctx, &core.ListProposalsRequest{
+		ActorID: extractAuthorization(ctx),
+		EventID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	proposals := make([]*v1.InvitationProposal, len(invitations))
+	for i, inv := range invitations {
+		proposals[i] = &v1.InvitationProposal{
+			InvitationId: inv.ID,
+			UserId:       inv.UserID,
+			Status:       mapInvitationStatusToPB(inv.Status),
+			Comment:      inv.Comment,
+			Guest:        parseGuestToPB(inv.Guest),
+			Proposal: &v1.TimeProposal{
+				ScheduleId: inv.Proposal.ScheduleID,
+				StartTime:  inv.Proposal.Start.Format(time.RFC3339),
+				EndTime:    inv.Proposal.End.Format(time.RFC3339),
+			},
+		}
+	}
+	return &v1.ListProposalsResponse{Proposals: proposals}, nil
+}
+
+func (g *GRPCEndpoint) ListMyInvitations(ctx context.Context, req *v1.ListMyInvitationsRequest) (*v1.ListMyInvitationsResponse, error) {
+	listReq, err := parseListMyInvitationsRequest(ctx, req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	page, err := g.svc.ListMyInvitations(ctx, listReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	invitations := make([]*v1.InvitationSummary, len(page.Summaries))
+	for i, summary := range page.Summaries {
+		invitations[i] = &v1.InvitationSummary{
+			InvitationId: summary.Invitation.ID,
+			Status:       mapInvitationStatusToPB(summary.Invitation.Status),
+			Comment:      summary.Invitation.Comment,
+			Event: &v1.EventSummary{
+				Id:          summary.Event.ID,
+				Title:       summary.Event.Title,
+				Description: summary.Event.Description,
+				Timezone:    summary.Event.Timezone,
+				CreatedBy:   summary.Event.CreatedBy,
+			},
+		}
+		if summary.NextOccurrence != nil {
+			invitations[i].NextOccurrence = parseOccurrenceToPB(summary.NextOccurrence)
+		}
+	}
+	return &v1.ListMyInvitationsResponse{
+		Invitations:   invitations,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+func (g *GRPCEndpoint) AcceptProposal(ctx context.Context, req *v1.AcceptProposalRequest) (*emptypb.Empty, error) {
+	err := g.svc.AcceptProposal(ctx, &core.AcceptProposalRequest{
+		ActorID:        extractAuthorization(ctx),
+		EventID:        req.GetId(),
+		InvitationID:   req.GetInvitationId(),
+		AllowConflicts: req.GetAllowConflicts(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
 func (g *GRPCEndpoint) Check(ctx context.Context, _ *v1.HealthCheckRequest) (*v1.HealthCheckResponse, error) {
 	return &v1.HealthCheckResponse{Status: v1.HealthCheckResponse_SERVING}, nil
 }
@@ -96,16 +505,14 @@ func (g *GRPCEndpoint) Watch(_ *v1.HealthCheckRequest, _ v1.API_WatchServer) err
 	return status.Error(codes.Unimplemented, "unimplemented")
 }
 
+// extractAuthorization returns the ID of the user authenticated by the auth interceptor, empty when
+// the caller isn't authenticated.
 func extractAuthorization(ctx context.Context) string {
-	m, ok := metadata.FromIncomingContext(ctx)
+	p, ok := auth.FromContext(ctx)
 	if !ok {
 		return ""
 	}
-	a := m.Get("Authorization")
-	if len(a) == 0 {
-		return ""
-	}
-	return a[0]
+	return p.Subject
 }
 
 func parseCreateEventRequest(ctx context.Context, req *v1.CreateEventRequest) (*core.CreateEventRequest, error) {
@@ -127,11 +534,21 @@ func parseCreateEventRequest(ctx context.Context, req *v1.CreateEventRequest) (*
 	}
 	event.Schedules = sch
 
-	event.Invitations = parseInvitations(req.GetEvent().GetAttendees(), event.ID)
+	event.Invitations, err = parseInvitations(req.GetEvent(), event.ID)
+	if err != nil {
+		return nil, err
+	}
+	event.ResourceIDs = req.GetEvent().GetResources()
+	event.HolidayCalendarID = parseOptionalID(req.GetEvent().GetHolidayCalendarId())
+	event.SkipHolidays = req.GetEvent().GetSkipHolidays()
+	event.MaxAttendees = req.GetEvent().GetMaxAttendees()
+	event.Groups = parseEventGroups(req.GetEvent())
 
 	return &core.CreateEventRequest{
-		ActorID: actorID,
-		Event:   event,
+		ActorID:             actorID,
+		Event:               event,
+		AllowConflicts:      req.GetAllowConflicts(),
+		RespectWorkingHours: req.GetRespectWorkingHours(),
 	}, nil
 }
 
@@ -164,16 +581,377 @@ func parseUpdateEventByIDRequest(ctx context.Context, req *v1.UpdateEventRequest
 	if err != nil {
 		return nil, err
 	}
+	// The schedules given by their ID are kept, along with their exceptions.
+	for i, pb := range req.GetEvent().GetSchedule() {
+		if pb.GetId() != "" {
+			sch[i].ID = pb.GetId()
+		}
+	}
 	event.Schedules = sch
-	event.Invitations = parseInvitations(req.GetEvent().GetAttendees(), event.ID)
+	event.Invitations, err = parseInvitations(req.GetEvent(), event.ID)
+	if err != nil {
+		return nil, err
+	}
+	event.ResourceIDs = req.GetEvent().GetResources()
+	event.HolidayCalendarID = parseOptionalID(req.GetEvent().GetHolidayCalendarId())
+	event.SkipHolidays = req.GetEvent().GetSkipHolidays()
+	event.MaxAttendees = req.GetEvent().GetMaxAttendees()
+	event.Groups = parseEventGroups(req.GetEvent())
+
+	updateReq := &core.UpdateEventRequest{
+		ID:                  req.GetId(),
+		ActorID:             extractAuthorization(ctx),
+		Event:               &event,
+		Scope:               mapUpdateScope(req.GetScope()),
+		ScheduleID:          req.GetScheduleId(),
+		AllowConflicts:      req.GetAllowConflicts(),
+		RespectWorkingHours: req.GetRespectWorkingHours(),
+	}
+
+	if req.GetOccurrenceStartTime() != "" {
+		updateReq.OccurrenceStart, err = time.Parse(time.RFC3339, req.GetOccurrenceStartTime())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return updateReq, nil
+}
+
+func parseListMyInvitationsRequest(ctx context.Context, req *v1.ListMyInvitationsRequest) (*core.ListMyInvitationsRequest, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	listReq := &core.ListMyInvitationsRequest{
+		ActorID:   extractAuthorization(ctx),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	for _, s := range req.GetStatuses() {
+		listReq.Statuses = append(listReq.Statuses, mapInvitationStatus(s))
+	}
+
+	var err error
+	if req.GetFrom() != "" {
+		listReq.From, err = time.Parse(time.RFC3339, req.GetFrom())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.GetTo() != "" {
+		listReq.To, err = time.Parse(time.RFC3339, req.GetTo())
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	return &core.UpdateEventRequest{
-		ID:      req.GetId(),
+	return listReq, nil
+}
+
+func parseListOccurrencesRequest(ctx context.Context, req *v1.ListOccurrencesRequest) (*core.ListOccurrencesRequest, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	from, err := time.Parse(time.RFC3339, req.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := time.Parse(time.RFC3339, req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.ListOccurrencesRequest{
 		ActorID: extractAuthorization(ctx),
-		Event:   &event,
+		EventID: req.GetId(),
+		From:    from,
+		To:      to,
+	}, nil
+}
+
+func parseQueryFreeBusyRequest(req *v1.QueryFreeBusyRequest) (*core.QueryFreeBusyRequest, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	from, err := time.Parse(time.RFC3339, req.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := time.Parse(time.RFC3339, req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.QueryFreeBusyRequest{
+		UserIDs:             req.GetUserIds(),
+		From:                from,
+		To:                  to,
+		Timezone:            req.GetTimezone(),
+		RespectWorkingHours: req.GetRespectWorkingHours(),
+	}, nil
+}
+
+func parseSuggestMeetingTimesRequest(req *v1.SuggestMeetingTimesRequest) (*core.SuggestMeetingTimesRequest, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	from, err := time.Parse(time.RFC3339, req.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := time.Parse(time.RFC3339, req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	suggestReq := &core.SuggestMeetingTimesRequest{
+		RequiredUserIDs:     req.GetRequiredAttendees(),
+		OptionalUserIDs:     req.GetOptionalAttendees(),
+		Duration:            time.Duration(req.GetDurationMinutes()) * time.Minute,
+		From:                from,
+		To:                  to,
+		Timezone:            req.GetTimezone(),
+		Limit:               int(req.GetLimit()),
+		RespectWorkingHours: req.GetRespectWorkingHours(),
+	}
+
+	if req.GetWorkingHours() != nil {
+		workingHours, err := parseWorkingHours(req.GetWorkingHours())
+		if err != nil {
+			return nil, err
+		}
+		suggestReq.WorkingHours = &workingHours
+	}
+
+	return suggestReq, nil
+}
+
+func parseUpdateAvailabilityRequest(ctx context.Context, req *v1.UpdateAvailabilityRequest) (*core.UpdateAvailabilityRequest, error) {
+	if req == nil || req.GetAvailability() == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	availability := &core.Availability{
+		UserID:   req.GetAvailability().GetUserId(),
+		Timezone: req.GetAvailability().GetTimezone(),
+		DefaultBuffers: core.Buffers{
+			Before: time.Duration(req.GetAvailability().GetDefaultBufferBeforeMinutes()) * time.Minute,
+			After:  time.Duration(req.GetAvailability().GetDefaultBufferAfterMinutes()) * time.Minute,
+		},
+		HolidayCalendarID: parseOptionalID(req.GetAvailability().GetHolidayCalendarId()),
+	}
+	for _, wh := range req.GetAvailability().GetWorkingHours() {
+		workingHours, err := parseWorkingHours(wh)
+		if err != nil {
+			return nil, err
+		}
+		availability.WorkingHours = append(availability.WorkingHours, workingHours)
+	}
+
+	return &core.UpdateAvailabilityRequest{
+		ActorID:      extractAuthorization(ctx),
+		Availability: availability,
+	}, nil
+}
+
+func parseWorkingHours(wh *v1.WorkingHours) (core.WorkingHours, error) {
+	days := make([]time.Weekday, len(wh.GetDays()))
+	for i, day := range wh.GetDays() {
+		days[i] = time.Weekday(day)
+	}
+
+	return core.ParseWorkingHours(days, wh.GetStart(), wh.GetEnd())
+}
+
+func parseAvailabilityToPB(availability *core.Availability) *v1.Availability {
+	workingHours := make([]*v1.WorkingHours, len(availability.WorkingHours))
+	for index, wh := range availability.WorkingHours {
+		days := make([]int32, len(wh.Days))
+		for i, day := range wh.Days {
+			days[i] = int32(day) //nolint:gosec
+		}
+
+		workingHours[index] = &v1.WorkingHours{
+			Days:  days,
+			Start: fmt.Sprintf("%02d:%02d", wh.StartMinute/60, wh.StartMinute%60),
+			End:   fmt.Sprintf("%02d:%02d", wh.EndMinute/60, wh.EndMinute%60),
+		}
+	}
+
+	a := &v1.Availability{
+		UserId:                     availability.UserID,
+		Timezone:                   availability.Timezone,
+		WorkingHours:               workingHours,
+		DefaultBufferBeforeMinutes: int32(availability.DefaultBuffers.Before / time.Minute), //nolint:gosec
+		DefaultBufferAfterMinutes:  int32(availability.DefaultBuffers.After / time.Minute),  //nolint:gosec
+	}
+	if availability.HolidayCalendarID != nil {
+		a.HolidayCalendarId = *availability.HolidayCalendarID
+	}
+	return a
+}
+
+func parseCreateResourceRequest(ctx context.Context, req *v1.CreateResourceRequest) (*core.CreateResourceRequest, error) {
+	if req == nil || req.GetResource() == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	resource := core.NewResource()
+	resource.Name = req.GetResource().GetName()
+	resource.Capacity = req.GetResource().GetCapacity()
+	resource.Location = req.GetResource().GetLocation()
+	resource.Timezone = req.GetResource().GetTimezone()
+
+	return &core.CreateResourceRequest{
+		ActorID:  extractAuthorization(ctx),
+		Resource: resource,
+	}, nil
+}
+
+func parseResourceToPB(resource *core.Resource) *v1.Resource {
+	return &v1.Resource{
+		Id:       resource.ID,
+		Name:     resource.Name,
+		Capacity: resource.Capacity,
+		Location: resource.Location,
+		Timezone: resource.Timezone,
+	}
+}
+
+func parseCreateGroupRequest(ctx context.Context, req *v1.CreateGroupRequest) (*core.CreateGroupRequest, error) {
+	if req == nil || req.GetGroup() == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	actorID := extractAuthorization(ctx)
+
+	group := core.NewGroup(actorID)
+	group.Name = req.GetGroup().GetName()
+	group.MemberIDs = req.GetGroup().GetMemberIds()
+	group.SubgroupIDs = req.GetGroup().GetSubgroupIds()
+
+	return &core.CreateGroupRequest{
+		ActorID: actorID,
+		Group:   group,
+	}, nil
+}
+
+func parseGroupToPB(group *core.Group) *v1.Group {
+	return &v1.Group{
+		Id:          group.ID,
+		Name:        group.Name,
+		MemberIds:   group.MemberIDs,
+		SubgroupIds: group.SubgroupIDs,
+		CreatedBy:   group.CreatedBy,
+	}
+}
+
+func parseCreateHolidayCalendarRequest(ctx context.Context, req *v1.CreateHolidayCalendarRequest) (*core.CreateHolidayCalendarRequest, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	holidays, err := core.ParseHolidays(mapHolidayCalendarFormat(req.GetFormat()), req.GetContent())
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := core.NewHolidayCalendar()
+	calendar.Name = req.GetName()
+	calendar.Holidays = holidays
+
+	return &core.CreateHolidayCalendarRequest{
+		ActorID:         extractAuthorization(ctx),
+		HolidayCalendar: calendar,
+	}, nil
+}
+
+func parseHolidayCalendarToPB(calendar *core.HolidayCalendar) *v1.HolidayCalendar {
+	holidays := make([]*v1.Holiday, len(calendar.Holidays))
+	for i, h := range calendar.Holidays {
+		holidays[i] = &v1.Holiday{
+			Date: h.Date.Format(time.DateOnly),
+			Name: h.Name,
+		}
+	}
+
+	return &v1.HolidayCalendar{
+		Id:       calendar.ID,
+		Name:     calendar.Name,
+		Holidays: holidays,
+	}
+}
+
+// parseOptionalID returns nil for an empty ID.
+func parseOptionalID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+func parseCancelOccurrenceRequest(ctx context.Context, req *v1.CancelOccurrenceRequest) (*core.CancelOccurrenceRequest, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	originalStart, err := time.Parse(time.RFC3339, req.GetOriginalStartTime())
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CancelOccurrenceRequest{
+		ActorID:       extractAuthorization(ctx),
+		EventID:       req.GetId(),
+		ScheduleID:    req.GetScheduleId(),
+		OriginalStart: originalStart,
 	}, nil
 }
 
+func parseUpdateOccurrenceRequest(ctx context.Context, req *v1.UpdateOccurrenceRequest) (*core.UpdateOccurrenceRequest, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	originalStart, err := time.Parse(time.RFC3339, req.GetOriginalStartTime())
+	if err != nil {
+		return nil, err
+	}
+
+	updateReq := &core.UpdateOccurrenceRequest{
+		ActorID:             extractAuthorization(ctx),
+		EventID:             req.GetId(),
+		ScheduleID:          req.GetScheduleId(),
+		OriginalStart:       originalStart,
+		Title:               req.GetTitle(),
+		Description:         req.GetDescription(),
+		AllowConflicts:      req.GetAllowConflicts(),
+		RespectWorkingHours: req.GetRespectWorkingHours(),
+	}
+
+	if req.GetStartTime() != "" || req.GetEndTime() != "" {
+		updateReq.Start, err = time.Parse(time.RFC3339, req.GetStartTime())
+		if err != nil {
+			return nil, err
+		}
+
+		updateReq.End, err = time.Parse(time.RFC3339, req.GetEndTime())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return updateReq, nil
+}
+
 func parseSchedules(sch []*v1.Schedule, eventID string) ([]core.Schedule, error) {
 	schedules := make([]core.Schedule, len(sch))
 	for index, sch := range sch {
@@ -183,22 +961,106 @@ func parseSchedules(sch []*v1.Schedule, eventID string) ([]core.Schedule, error)
 			sch.GetEndTime(),
 			sch.GetIsFullDay(),
 			mapRecurringType(sch.GetRecurringType()),
+			sch.GetRecurrenceRule(),
+			sch.GetUntil(),
+			int(sch.GetCount()),
 		)
 		if err != nil {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
+		s.BufferBeforeInMinutes = parseBuffer(sch.BufferBeforeMinutes)
+		s.BufferAfterInMinutes = parseBuffer(sch.BufferAfterMinutes)
 		schedules[index] = s
 	}
 	return schedules, nil
 }
 
-func parseInvitations(attendees []int32, eventID string) []core.Invitation {
-	invitations := make([]core.Invitation, len(attendees))
-	for index, userID := range attendees {
+func parseBuffer(minutes *int32) *int64 {
+	if minutes == nil {
+		return nil
+	}
+	m := int64(*minutes)
+	return &m
+}
+
+func parseBufferToPB(minutes *int64) *int32 {
+	if minutes == nil {
+		return nil
+	}
+	m := int32(*minutes) //nolint:gosec
+	return &m
+}
+
+func parseInvitations(event *v1.Event, eventID string) ([]core.Invitation, error) {
+	invitations := make([]core.Invitation, 0, len(event.GetAttendees())+len(event.GetGuests()))
+	for _, userID := range event.GetAttendees() {
 		i := core.NewInvitation(eventID, userID)
-		invitations[index] = i
+		invitations = append(invitations, i)
+	}
+	for _, guest := range event.GetGuests() {
+		i := core.NewGuestInvitation(eventID, core.Guest{Email: guest.GetEmail(), Name: guest.GetName()})
+		invitations = append(invitations, i)
+	}
+
+	for _, settings := range event.GetAttendeeSettings() {
+		inv := findInvitation(invitations, settings.GetUserId(), settings.GetGuestEmail())
+		if inv == nil {
+			return nil, fmt.Errorf("the settings of user %d or guest %q are for no attendee", settings.GetUserId(), settings.GetGuestEmail())
+		}
+
+		inv.Role = mapAttendeeRole(settings.GetRole())
+		if p := settings.GetPermissions(); p != nil {
+			inv.Permissions = core.AttendeePermissions{
+				CanModifyEvent:  p.GetCanModifyEvent(),
+				CanInviteOthers: p.GetCanInviteOthers(),
+				CanSeeGuestList: p.GetCanSeeGuestList(),
+			}
+		}
+	}
+	return invitations, nil
+}
+
+func parseEventGroups(event *v1.Event) []core.EventGroup {
+	var groups []core.EventGroup
+	for _, g := range event.GetGroups() {
+		groups = append(groups, core.EventGroup{GroupID: g.GetGroupId(), Sync: g.GetSync()})
+	}
+	return groups
+}
+
+func findInvitation(invitations []core.Invitation, userID int32, guestEmail string) *core.Invitation {
+	for i := range invitations {
+		if invitations[i].IsFor(userID, guestEmail) {
+			return &invitations[i]
+		}
+	}
+	return nil
+}
+
+func parseAttendeeSettingsToPB(inv *core.Invitation) *v1.AttendeeSettings {
+	settings := &v1.AttendeeSettings{
+		UserId: inv.UserID,
+		Role:   mapAttendeeRoleToPB(inv.Role),
+		Permissions: &v1.AttendeePermissions{
+			CanModifyEvent:  inv.Permissions.CanModifyEvent,
+			CanInviteOthers: inv.Permissions.CanInviteOthers,
+			CanSeeGuestList: inv.Permissions.CanSeeGuestList,
+		},
+	}
+	if inv.Guest != nil {
+		settings.GuestEmail = inv.Guest.Email
+	}
+	if inv.GroupID != nil {
+		settings.GroupId = *inv.GroupID
 	}
-	return invitations
+	return settings
+}
+
+func parseGuestToPB(guest *core.Guest) *v1.Guest {
+	if guest == nil {
+		return nil
+	}
+	return &v1.Guest{Email: guest.Email, Name: guest.Name}
 }
 
 func parseEventToPB(event *core.Event) (*v1.Event, error) {
@@ -210,6 +1072,15 @@ func parseEventToPB(event *core.Event) (*v1.Event, error) {
 		CreatedAt:     event.CreatedAt.Format(time.RFC3339),
 		CreatedBy:     event.CreatedBy,
 		LastUpdatedAt: event.GetUpdatedAt(),
+		Resources:     event.ResourceIDs,
+		SkipHolidays:  event.SkipHolidays,
+		MaxAttendees:  event.MaxAttendees,
+	}
+	if event.ParentEventID != nil {
+		e.ParentEventId = *event.ParentEventID
+	}
+	if event.HolidayCalendarID != nil {
+		e.HolidayCalendarId = *event.HolidayCalendarID
 	}
 
 	schedules := make([]*v1.Schedule, len(event.Schedules))
@@ -220,21 +1091,39 @@ func parseEventToPB(event *core.Event) (*v1.Event, error) {
 		}
 
 		s := &v1.Schedule{
-			Id:            sch.ID,
-			StartTime:     st.Format(time.RFC3339),
-			EndTime:       sch.EndTimeFrom(st).Format(time.RFC3339),
-			IsFullDay:     sch.IsFullDay,
-			RecurringType: mapRecurringTypeToPB(sch.RecurringType),
+			Id:                  sch.ID,
+			StartTime:           st.Format(time.RFC3339),
+			EndTime:             sch.EndTimeFrom(st).Format(time.RFC3339),
+			IsFullDay:           sch.IsFullDay,
+			RecurringType:       mapRecurringTypeToPB(sch.RecurringType),
+			RecurrenceRule:      sch.RecurrenceRule.String(),
+			Count:               sch.Count,
+			BufferBeforeMinutes: parseBufferToPB(sch.BufferBeforeInMinutes),
+			BufferAfterMinutes:  parseBufferToPB(sch.BufferAfterInMinutes),
+		}
+		if until := sch.UntilTime(); until != nil {
+			s.Until = until.In(st.Location()).Format(time.RFC3339)
 		}
 		schedules[index] = s
 	}
 	e.Schedule = schedules
 
-	attendees := make([]int32, len(event.Invitations))
-	for index, inv := range event.Invitations {
-		attendees[index] = inv.UserID
+	attendees := make([]int32, 0, len(event.Invitations))
+	var guests []*v1.Guest
+	for _, inv := range event.Invitations {
+		e.AttendeeSettings = append(e.AttendeeSettings, parseAttendeeSettingsToPB(&inv))
+		if inv.Guest != nil {
+			guests = append(guests, parseGuestToPB(inv.Guest))
+			continue
+		}
+		attendees = append(attendees, inv.UserID)
 	}
 	e.Attendees = attendees
+	e.Guests = guests
+
+	for _, g := range event.Groups {
+		e.Groups = append(e.Groups, &v1.EventGroup{GroupId: g.GroupID, Sync: g.Sync})
+	}
 
 	return e, nil
 }
@@ -245,6 +1134,12 @@ func mapRecurringType(rt v1.RecurringType) core.RecurringType {
 		return core.RecurringType_Daily
 	case v1.RecurringType_EVERY_WEEK:
 		return core.RecurringType_Every_Week
+	case v1.RecurringType_MONTHLY:
+		return core.RecurringType_Monthly
+	case v1.RecurringType_YEARLY:
+		return core.RecurringType_Yearly
+	case v1.RecurringType_CUSTOM:
+		return core.RecurringType_Custom
 	default:
 		return core.RecurringType_None
 	}
@@ -256,7 +1151,160 @@ func mapRecurringTypeToPB(rt core.RecurringType) v1.RecurringType {
 		return v1.RecurringType_DAILY
 	case core.RecurringType_Every_Week:
 		return v1.RecurringType_EVERY_WEEK
+	case core.RecurringType_Monthly:
+		return v1.RecurringType_MONTHLY
+	case core.RecurringType_Yearly:
+		return v1.RecurringType_YEARLY
+	case core.RecurringType_Custom:
+		return v1.RecurringType_CUSTOM
 	default:
 		return v1.RecurringType_NONE
 	}
 }
+
+func mapHolidayCalendarFormat(format v1.HolidayCalendarFormat) core.HolidayCalendarFormat {
+	switch format {
+	case v1.HolidayCalendarFormat_JSON:
+		return core.HolidayCalendarFormat_JSON
+	default:
+		return core.HolidayCalendarFormat_ICS
+	}
+}
+
+func parseRespondToInvitationRequest(req *v1.RespondToInvitationRequest) (*core.RespondToInvitationRequest, error) {
+	proposal, err := parseTimeProposal(req.GetProposal())
+	if err != nil {
+		return nil, err
+	}
+
+	var originalStart time.Time
+	if req.GetOriginalStartTime() != "" {
+		originalStart, err = time.Parse(time.RFC3339, req.GetOriginalStartTime())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &core.RespondToInvitationRequest{
+		Token:         req.GetToken(),
+		Response:      mapInvitationStatus(req.GetResponse()),
+		Comment:       req.GetComment(),
+		Proposal:      proposal,
+		ScheduleID:    req.GetScheduleId(),
+		OriginalStart: originalStart,
+	}, nil
+}
+
+func parseTimeProposal(p *v1.TimeProposal) (*core.TimeProposal, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	start, err := time.Parse(time.RFC3339, p.GetStartTime())
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse(time.RFC3339, p.GetEndTime())
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.TimeProposal{
+		ScheduleID: p.GetScheduleId(),
+		Start:      start,
+		End:        end,
+	}, nil
+}
+
+func parseOccurrenceToPB(o *core.Occurrence) *v1.Occurrence {
+	return &v1.Occurrence{
+		ScheduleId:        o.ScheduleID,
+		StartTime:         o.Start.Format(time.RFC3339),
+		EndTime:           o.End.Format(time.RFC3339),
+		IsFullDay:         o.IsFullDay,
+		OriginalStartTime: o.OriginalStart.Format(time.RFC3339),
+		Title:             o.Title,
+		Description:       o.Description,
+		IsModified:        o.IsModified,
+		Attendees:         parseAttendeesToPB(o.Attendees),
+	}
+}
+
+func parseAttendeesToPB(attendees []core.Attendee) []*v1.Attendee {
+	res := make([]*v1.Attendee, len(attendees))
+	for i, a := range attendees {
+		res[i] = &v1.Attendee{
+			UserId: a.UserID,
+			Status: mapInvitationStatusToPB(a.Status),
+			Guest:  parseGuestToPB(a.Guest),
+		}
+	}
+	return res
+}
+
+func mapInvitationStatus(status v1.InvitationStatus) core.InvitationStatus {
+	switch status {
+	case v1.InvitationStatus_CONFIRMED:
+		return core.InvitationStatus_Confirmed
+	case v1.InvitationStatus_DECLINED:
+		return core.InvitationStatus_Declined
+	case v1.InvitationStatus_TENTATIVE:
+		return core.InvitationStatus_Tentative
+	case v1.InvitationStatus_WAITLISTED:
+		return core.InvitationStatus_Waitlisted
+	default:
+		return core.InvitationStatus_Unknown
+	}
+}
+
+func mapInvitationStatusToPB(status core.InvitationStatus) v1.InvitationStatus {
+	switch status {
+	case core.InvitationStatus_Confirmed:
+		return v1.InvitationStatus_CONFIRMED
+	case core.InvitationStatus_Declined:
+		return v1.InvitationStatus_DECLINED
+	case core.InvitationStatus_Tentative:
+		return v1.InvitationStatus_TENTATIVE
+	case core.InvitationStatus_Waitlisted:
+		return v1.InvitationStatus_WAITLISTED
+	default:
+		return v1.InvitationStatus_PENDING
+	}
+}
+
+func mapAttendeeRole(role v1.AttendeeRole) core.AttendeeRole {
+	switch role {
+	case v1.AttendeeRole_OPTIONAL:
+		return core.AttendeeRole_Optional
+	case v1.AttendeeRole_FYI:
+		return core.AttendeeRole_FYI
+	case v1.AttendeeRole_ORGANIZER:
+		return core.AttendeeRole_Organizer
+	default:
+		return core.AttendeeRole_Required
+	}
+}
+
+func mapAttendeeRoleToPB(role core.AttendeeRole) v1.AttendeeRole {
+	switch role {
+	case core.AttendeeRole_Optional:
+		return v1.AttendeeRole_OPTIONAL
+	case core.AttendeeRole_FYI:
+		return v1.AttendeeRole_FYI
+	case core.AttendeeRole_Organizer:
+		return v1.AttendeeRole_ORGANIZER
+	default:
+		return v1.AttendeeRole_REQUIRED
+	}
+}
+
+func mapUpdateScope(scope v1.UpdateScope) core.UpdateScope {
+	switch scope {
+	case v1.UpdateScope_THIS_OCCURRENCE:
+		return core.UpdateScope_ThisOccurrence
+	case v1.UpdateScope_THIS_AND_FOLLOWING:
+		return core.UpdateScope_ThisAndFollowing
+	default:
+		return core.UpdateScope_All
+	}
+}
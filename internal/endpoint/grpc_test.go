@@ -2,15 +2,22 @@ package endpoint_test
 
 import (
 	"context"
+	"fmt"
 
 	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal/auth"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	grpcEndpoint "github.com/dzakaammar/event-scheduling-example/internal/endpoint"
+	"github.com/dzakaammar/event-scheduling-example/internal/notification"
 	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
 	"github.com/dzakaammar/event-scheduling-example/internal/scheduling"
+	"github.com/dzakaammar/event-scheduling-example/internal/user"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 var _ = Describe("Creating an Event", func() {
@@ -21,8 +28,8 @@ var _ = Describe("Creating an Event", func() {
 	)
 	BeforeEach(func() {
 		eventRepo = postgresql.NewEventRepository(db)
-		schedulingSvc = scheduling.NewService(eventRepo)
-		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
 	})
 
 	// AfterAll(func() {})
@@ -38,14 +45,14 @@ var _ = Describe("Creating an Event", func() {
 					Attendees:   []int32{2, 3},
 					Schedule: []*v1.Schedule{
 						{
-							StartTime:     "2022-01-01T00:00:00+07:00",
-							EndTime:       "2022-01-01T01:00:00+07:00",
+							StartTime:     "2033-01-01T00:00:00+07:00",
+							EndTime:       "2033-01-01T01:00:00+07:00",
 							IsFullDay:     false,
 							RecurringType: v1.RecurringType_NONE,
 						},
 						{
-							StartTime:     "2022-01-01T01:00:00+07:00",
-							EndTime:       "2022-01-01T02:00:00+07:00",
+							StartTime:     "2033-01-01T01:00:00+07:00",
+							EndTime:       "2033-01-01T02:00:00+07:00",
 							IsFullDay:     false,
 							RecurringType: v1.RecurringType_NONE,
 						},
@@ -68,9 +75,7 @@ var _ = Describe("Creating an Event", func() {
 				actorID = "1"
 			)
 			BeforeEach(func() {
-				ctx = metadata.NewIncomingContext(context.Background(), metadata.MD{ //nolint:fatcontext
-					"Authorization": []string{actorID},
-				})
+				ctx = auth.NewContext(context.Background(), auth.Principal{Subject: actorID}) //nolint:fatcontext
 			})
 
 			When("the start time format is invalid", func() {
@@ -91,127 +96,1612 @@ var _ = Describe("Creating an Event", func() {
 				})
 			})
 
-			When("the timezone format is invalid", func() {
-				It("returns error", func() {
-					basedReq.Event.Timezone = "invalid"
-					res, err := endpoint.CreateEvent(ctx, basedReq)
-					Expect(err).ShouldNot(BeNil())
-					Expect(res).Should(BeNil())
-				})
+			When("the recurrence rule is invalid", func() {
+				It("returns error", func() {
+					basedReq.Event.Schedule[0].RecurrenceRule = "FREQ=SOMETIMES"
+					res, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(err).ShouldNot(BeNil())
+					Expect(res).Should(BeNil())
+				})
+			})
+
+			When("the schedule has a recurrence rule", func() {
+				It("stores the rule", func() {
+					basedReq.Event.Schedule[0].RecurrenceRule = "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU"
+					res, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(err).Should(BeNil())
+
+					found, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: res.GetId()})
+					Expect(err).Should(BeNil())
+
+					rules := make([]string, 0, len(found.GetEvent().GetSchedule()))
+					for _, sch := range found.GetEvent().GetSchedule() {
+						rules = append(rules, sch.GetRecurrenceRule())
+					}
+					Expect(rules).To(ContainElement("FREQ=WEEKLY;INTERVAL=2;BYDAY=TU"))
+				})
+			})
+
+			When("both until and count are set", func() {
+				It("returns error", func() {
+					basedReq.Event.Schedule[0].RecurrenceRule = "FREQ=WEEKLY"
+					basedReq.Event.Schedule[0].Until = "2033-12-31T00:00:00+07:00"
+					basedReq.Event.Schedule[0].Count = 10
+					res, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(err).ShouldNot(BeNil())
+					Expect(res).Should(BeNil())
+				})
+			})
+
+			When("the schedule has a count", func() {
+				It("stores the end condition", func() {
+					basedReq.Event.Schedule[0].RecurrenceRule = "FREQ=WEEKLY"
+					basedReq.Event.Schedule[0].Count = 10
+					res, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(err).Should(BeNil())
+
+					found, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: res.GetId()})
+					Expect(err).Should(BeNil())
+
+					rules := make([]string, 0, len(found.GetEvent().GetSchedule()))
+					for _, sch := range found.GetEvent().GetSchedule() {
+						if sch.GetCount() == 10 {
+							rules = append(rules, sch.GetRecurrenceRule())
+						}
+					}
+					Expect(rules).To(ContainElement("FREQ=WEEKLY;COUNT=10"))
+				})
+			})
+
+			When("the attendees are already booked", func() {
+				BeforeEach(func() {
+					_, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(err).Should(BeNil())
+				})
+
+				It("returns the conflicting event", func() {
+					res, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(res).Should(BeNil())
+					Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+					details := status.Convert(err).Details()
+					Expect(details).To(HaveLen(1))
+					Expect(details[0].(*errdetails.PreconditionFailure).GetViolations()).To(HaveLen(1))
+				})
+
+				It("creates the event when conflicts are allowed", func() {
+					basedReq.AllowConflicts = true
+					res, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(err).Should(BeNil())
+					Expect(res.GetId()).ShouldNot(BeEmpty())
+				})
+			})
+
+			When("the timezone format is invalid", func() {
+				It("returns error", func() {
+					basedReq.Event.Timezone = "invalid"
+					res, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(err).ShouldNot(BeNil())
+					Expect(res).Should(BeNil())
+				})
+			})
+
+			When("there's no schedule", func() {
+				It("returns error", func() {
+					basedReq.Event.Schedule = nil
+					res, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(err).ShouldNot(BeNil())
+					Expect(res).Should(BeNil())
+				})
+			})
+
+			When("the event data is valid", func() {
+				It("creates the event", func() {
+					res, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(err).Should(BeNil())
+					Expect(res).ShouldNot(BeNil())
+
+					e, err := eventRepo.FindByID(context.Background(), res.GetId())
+					Expect(err).Should(BeNil())
+					Expect(e).ShouldNot(BeNil())
+
+					Expect(e.ID).To(Equal(res.GetId()))
+					Expect(e.Title).To(Equal(basedReq.GetEvent().GetTitle()))
+					Expect(e.Description).To(Equal(basedReq.GetEvent().GetDescription()))
+					Expect(e.Timezone).To(Equal(basedReq.GetEvent().GetTimezone()))
+					Expect(len(e.Schedules)).To(Equal(len(basedReq.GetEvent().GetSchedule())))
+					Expect(len(e.Invitations)).To(Equal(len(basedReq.GetEvent().GetAttendees())))
+					Expect(e.CreatedBy).To(Equal(actorID))
+					Expect(e.CreatedAt).NotTo(BeNil())
+				})
+			})
+
+			When("the event has no attendees", func() {
+				It("creates the event", func() {
+					basedReq.Event.Attendees = nil
+					res, err := endpoint.CreateEvent(ctx, basedReq)
+					Expect(err).Should(BeNil())
+					Expect(res).ShouldNot(BeNil())
+
+					e, err := eventRepo.FindByID(context.Background(), res.GetId())
+					Expect(err).Should(BeNil())
+					Expect(e).ShouldNot(BeNil())
+
+					Expect(e.ID).To(Equal(res.GetId()))
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("Deleting an Event", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+	})
+
+	Context("Run", func() {
+		var event *core.Event
+		BeforeEach(func() {
+			event = core.NewEvent("test_actor")
+			err := eventRepo.Store(context.Background(), event)
+			Expect(err).Should(BeNil())
+		})
+
+		When("user is unauthorized", func() {
+			It("returns an error", func() {
+				empty, err := endpoint.DeleteEventByID(context.Background(), &v1.DeleteEventByIDRequest{
+					Id: event.ID,
+				})
+				Expect(err).ShouldNot(BeNil())
+				Expect(empty).Should(BeNil())
+
+				e, err := eventRepo.FindByID(context.Background(), event.ID)
+				Expect(err).Should(BeNil())
+				Expect(e).ShouldNot(BeNil())
+			})
+		})
+
+		When("user is authorized", func() {
+			var ctx context.Context
+			BeforeEach(func() {
+				ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "test_actor"}) //nolint:fatcontext
+			})
+
+			When("the event is exists", func() {
+				It("deletes the data", func() {
+					empty, err := endpoint.DeleteEventByID(ctx, &v1.DeleteEventByIDRequest{
+						Id: event.ID,
+					})
+					Expect(err).Should(BeNil())
+					Expect(empty).ShouldNot(BeNil())
+
+					_, err = eventRepo.FindByID(context.Background(), event.ID)
+					Expect(err).ShouldNot(BeNil())
+				})
+			})
+
+			When("the event is not exists", func() {
+				It("returns an error", func() {
+					empty, err := endpoint.DeleteEventByID(ctx, &v1.DeleteEventByIDRequest{
+						Id: "invalid id",
+					})
+					Expect(err).ShouldNot(BeNil())
+					Expect(empty).Should(BeNil())
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("Listing occurrences of an Event", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+		ctx           context.Context
+		eventID       string
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		res, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "weekly sync",
+				Description: "weekly sync",
+				Timezone:    "Asia/Jakarta",
+				Schedule: []*v1.Schedule{
+					{
+						StartTime:      "2033-01-04T09:00:00+07:00",
+						EndTime:        "2033-01-04T10:00:00+07:00",
+						RecurrenceRule: "FREQ=WEEKLY;BYDAY=TU",
+					},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+		eventID = res.GetId()
+	})
+
+	When("the time window is invalid", func() {
+		It("returns an error", func() {
+			res, err := endpoint.ListOccurrences(ctx, &v1.ListOccurrencesRequest{
+				Id:   eventID,
+				From: "2033-02-01T00:00:00+07:00",
+				To:   "2033-01-01T00:00:00+07:00",
+			})
+			Expect(err).ShouldNot(BeNil())
+			Expect(res).Should(BeNil())
+		})
+	})
+
+	When("the time window is valid", func() {
+		It("expands the schedules", func() {
+			res, err := endpoint.ListOccurrences(ctx, &v1.ListOccurrencesRequest{
+				Id:   eventID,
+				From: "2033-01-01T00:00:00+07:00",
+				To:   "2033-02-01T00:00:00+07:00",
+			})
+			Expect(err).Should(BeNil())
+			Expect(res.GetTruncated()).To(BeFalse())
+			Expect(res.GetOccurrences()).To(HaveLen(4))
+			Expect(res.GetOccurrences()[0].GetStartTime()).To(Equal("2033-01-04T09:00:00+07:00"))
+			Expect(res.GetOccurrences()[3].GetStartTime()).To(Equal("2033-01-25T09:00:00+07:00"))
+		})
+	})
+})
+
+var _ = Describe("Modifying an occurrence of an Event", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+		ctx           context.Context
+		eventID       string
+		scheduleID    string
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		res, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "weekly sync",
+				Description: "weekly sync",
+				Timezone:    "Asia/Jakarta",
+				Schedule: []*v1.Schedule{
+					{
+						StartTime:      "2033-01-04T09:00:00+07:00",
+						EndTime:        "2033-01-04T10:00:00+07:00",
+						RecurrenceRule: "FREQ=WEEKLY;BYDAY=TU",
+					},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+		eventID = res.GetId()
+
+		list, err := endpoint.ListOccurrences(ctx, &v1.ListOccurrencesRequest{
+			Id:   eventID,
+			From: "2033-01-01T00:00:00+07:00",
+			To:   "2033-01-05T00:00:00+07:00",
+		})
+		Expect(err).Should(BeNil())
+		scheduleID = list.GetOccurrences()[0].GetScheduleId()
+	})
+
+	When("the occurrence doesn't exist", func() {
+		It("returns an error", func() {
+			res, err := endpoint.CancelOccurrence(ctx, &v1.CancelOccurrenceRequest{
+				Id:                eventID,
+				ScheduleId:        scheduleID,
+				OriginalStartTime: "2033-01-05T09:00:00+07:00",
+			})
+			Expect(err).ShouldNot(BeNil())
+			Expect(res).Should(BeNil())
+		})
+	})
+
+	When("the occurrence is cancelled", func() {
+		It("is skipped from the expansion", func() {
+			_, err := endpoint.CancelOccurrence(ctx, &v1.CancelOccurrenceRequest{
+				Id:                eventID,
+				ScheduleId:        scheduleID,
+				OriginalStartTime: "2033-01-11T09:00:00+07:00",
+			})
+			Expect(err).Should(BeNil())
+
+			res, err := endpoint.ListOccurrences(ctx, &v1.ListOccurrencesRequest{
+				Id:   eventID,
+				From: "2033-01-01T00:00:00+07:00",
+				To:   "2033-02-01T00:00:00+07:00",
+			})
+			Expect(err).Should(BeNil())
+			Expect(res.GetOccurrences()).To(HaveLen(3))
+			Expect(res.GetOccurrences()[1].GetStartTime()).To(Equal("2033-01-18T09:00:00+07:00"))
+		})
+	})
+
+	When("the event is updated afterwards", func() {
+		BeforeEach(func() {
+			_, err := endpoint.CancelOccurrence(ctx, &v1.CancelOccurrenceRequest{
+				Id:                eventID,
+				ScheduleId:        scheduleID,
+				OriginalStartTime: "2033-01-11T09:00:00+07:00",
+			})
+			Expect(err).Should(BeNil())
+		})
+
+		updateTitle := func(id string) {
+			_, err := endpoint.UpdateEvent(ctx, &v1.UpdateEventRequest{
+				Id: eventID,
+				Event: &v1.Event{
+					Title:       "renamed sync",
+					Description: "weekly sync",
+					Timezone:    "Asia/Jakarta",
+					Schedule: []*v1.Schedule{
+						{
+							Id:             id,
+							StartTime:      "2033-01-04T09:00:00+07:00",
+							EndTime:        "2033-01-04T10:00:00+07:00",
+							RecurrenceRule: "FREQ=WEEKLY;BYDAY=TU",
+						},
+					},
+				},
+			})
+			Expect(err).Should(BeNil())
+
+			res, err := endpoint.ListOccurrences(ctx, &v1.ListOccurrencesRequest{
+				Id:   eventID,
+				From: "2033-01-01T00:00:00+07:00",
+				To:   "2033-02-01T00:00:00+07:00",
+			})
+			Expect(err).Should(BeNil())
+			Expect(res.GetOccurrences()).To(HaveLen(3))
+			Expect(res.GetOccurrences()[0].GetScheduleId()).To(Equal(scheduleID))
+			Expect(res.GetOccurrences()[0].GetTitle()).To(Equal("renamed sync"))
+		}
+
+		It("keeps the cancellation of the schedule given by its ID", func() {
+			updateTitle(scheduleID)
+		})
+
+		It("keeps the cancellation of the unchanged schedule", func() {
+			updateTitle("")
+		})
+	})
+
+	When("the occurrence is moved", func() {
+		It("returns the modified occurrence", func() {
+			_, err := endpoint.UpdateOccurrence(ctx, &v1.UpdateOccurrenceRequest{
+				Id:                eventID,
+				ScheduleId:        scheduleID,
+				OriginalStartTime: "2033-01-11T09:00:00+07:00",
+				StartTime:         "2033-01-12T13:00:00+07:00",
+				EndTime:           "2033-01-12T14:30:00+07:00",
+				Title:             "moved sync",
+			})
+			Expect(err).Should(BeNil())
+
+			res, err := endpoint.ListOccurrences(ctx, &v1.ListOccurrencesRequest{
+				Id:   eventID,
+				From: "2033-01-01T00:00:00+07:00",
+				To:   "2033-02-01T00:00:00+07:00",
+			})
+			Expect(err).Should(BeNil())
+			Expect(res.GetOccurrences()).To(HaveLen(4))
+
+			moved := res.GetOccurrences()[1]
+			Expect(moved.GetStartTime()).To(Equal("2033-01-12T13:00:00+07:00"))
+			Expect(moved.GetEndTime()).To(Equal("2033-01-12T14:30:00+07:00"))
+			Expect(moved.GetOriginalStartTime()).To(Equal("2033-01-11T09:00:00+07:00"))
+			Expect(moved.GetTitle()).To(Equal("moved sync"))
+			Expect(moved.GetIsModified()).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("Updating this and following occurrences of an Event", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+		ctx           context.Context
+		eventID       string
+		scheduleID    string
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		res, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "weekly sync",
+				Description: "weekly sync",
+				Timezone:    "Asia/Jakarta",
+				Attendees:   []int32{2},
+				Schedule: []*v1.Schedule{
+					{
+						StartTime:      "2033-01-04T09:00:00+07:00",
+						EndTime:        "2033-01-04T10:00:00+07:00",
+						RecurrenceRule: "FREQ=WEEKLY;BYDAY=TU",
+					},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+		eventID = res.GetId()
+
+		found, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: eventID})
+		Expect(err).Should(BeNil())
+		scheduleID = found.GetEvent().GetSchedule()[0].GetId()
+	})
+
+	It("splits the series into two events", func() {
+		res, err := endpoint.UpdateEvent(ctx, &v1.UpdateEventRequest{
+			Id: eventID,
+			Event: &v1.Event{
+				Title:       "weekly sync",
+				Description: "moved to the afternoon",
+				Timezone:    "Asia/Jakarta",
+				Schedule: []*v1.Schedule{
+					{
+						StartTime:      "2033-01-18T14:00:00+07:00",
+						EndTime:        "2033-01-18T15:00:00+07:00",
+						RecurrenceRule: "FREQ=WEEKLY;BYDAY=TU",
+					},
+				},
+			},
+			Scope:               v1.UpdateScope_THIS_AND_FOLLOWING,
+			ScheduleId:          scheduleID,
+			OccurrenceStartTime: "2033-01-18T09:00:00+07:00",
+		})
+		Expect(err).Should(BeNil())
+		Expect(res.GetId()).ShouldNot(Equal(eventID))
+
+		original, err := endpoint.ListOccurrences(ctx, &v1.ListOccurrencesRequest{
+			Id:   eventID,
+			From: "2033-01-01T00:00:00+07:00",
+			To:   "2033-02-01T00:00:00+07:00",
+		})
+		Expect(err).Should(BeNil())
+		Expect(original.GetOccurrences()).To(HaveLen(2))
+
+		following, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: res.GetId()})
+		Expect(err).Should(BeNil())
+		Expect(following.GetEvent().GetParentEventId()).To(Equal(eventID))
+		Expect(following.GetEvent().GetAttendees()).To(Equal([]int32{2}))
+		Expect(following.GetEvent().GetSchedule()[0].GetStartTime()).To(Equal("2033-01-18T14:00:00+07:00"))
+	})
+})
+
+var _ = Describe("Querying free/busy time", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+		ctx           context.Context
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		_, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "daily standup",
+				Description: "daily standup",
+				Timezone:    "Asia/Jakarta",
+				Schedule: []*v1.Schedule{
+					{
+						StartTime:      "2033-01-04T09:00:00+07:00",
+						EndTime:        "2033-01-04T10:00:00+07:00",
+						RecurrenceRule: "FREQ=DAILY",
+					},
+				},
+				Attendees: []int32{2},
+			},
+		})
+		Expect(err).Should(BeNil())
+
+		_, err = endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "planning",
+				Description: "planning",
+				Timezone:    "Asia/Jakarta",
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2033-01-05T09:30:00+07:00",
+						EndTime:   "2033-01-05T11:00:00+07:00",
+					},
+				},
+				Attendees: []int32{2, 3},
+			},
+			AllowConflicts: true,
+		})
+		Expect(err).Should(BeNil())
+	})
+
+	When("the time window is invalid", func() {
+		It("returns an error", func() {
+			res, err := endpoint.QueryFreeBusy(ctx, &v1.QueryFreeBusyRequest{
+				UserIds: []int32{2},
+				From:    "2033-01-06T00:00:00+07:00",
+				To:      "2033-01-04T00:00:00+07:00",
+			})
+			Expect(err).ShouldNot(BeNil())
+			Expect(res).Should(BeNil())
+		})
+	})
+
+	When("the time window is valid", func() {
+		It("returns the merged busy intervals of every user", func() {
+			res, err := endpoint.QueryFreeBusy(ctx, &v1.QueryFreeBusyRequest{
+				UserIds:  []int32{2, 3, 4},
+				From:     "2033-01-04T00:00:00+07:00",
+				To:       "2033-01-06T00:00:00+07:00",
+				Timezone: "Asia/Jakarta",
+			})
+			Expect(err).Should(BeNil())
+			Expect(res.GetUsers()).To(HaveLen(3))
+
+			Expect(res.GetUsers()[0].GetUserId()).To(Equal(int32(2)))
+			Expect(res.GetUsers()[0].GetBusy()).To(HaveLen(2))
+			Expect(res.GetUsers()[0].GetBusy()[1].GetStartTime()).To(Equal("2033-01-05T09:00:00+07:00"))
+			Expect(res.GetUsers()[0].GetBusy()[1].GetEndTime()).To(Equal("2033-01-05T11:00:00+07:00"))
+
+			Expect(res.GetUsers()[1].GetBusy()).To(HaveLen(1))
+			Expect(res.GetUsers()[1].GetBusy()[0].GetStartTime()).To(Equal("2033-01-05T09:30:00+07:00"))
+
+			Expect(res.GetUsers()[2].GetBusy()).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Suggesting meeting times", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+		ctx           context.Context
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		_, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "daily standup",
+				Description: "daily standup",
+				Timezone:    "Asia/Jakarta",
+				Schedule: []*v1.Schedule{
+					{
+						StartTime:      "2033-01-04T09:00:00+07:00",
+						EndTime:        "2033-01-04T10:00:00+07:00",
+						RecurrenceRule: "FREQ=DAILY",
+					},
+				},
+				Attendees: []int32{2},
+			},
+		})
+		Expect(err).Should(BeNil())
+	})
+
+	When("the working hours are invalid", func() {
+		It("returns an error", func() {
+			res, err := endpoint.SuggestMeetingTimes(ctx, &v1.SuggestMeetingTimesRequest{
+				RequiredAttendees: []int32{2},
+				DurationMinutes:   60,
+				From:              "2033-01-04T00:00:00+07:00",
+				To:                "2033-01-05T00:00:00+07:00",
+				WorkingHours:      &v1.WorkingHours{Start: "17:00", End: "09:00"},
+			})
+			Expect(err).ShouldNot(BeNil())
+			Expect(res).Should(BeNil())
+		})
+	})
+
+	When("the request is valid", func() {
+		It("suggests the free slots within the working hours first", func() {
+			res, err := endpoint.SuggestMeetingTimes(ctx, &v1.SuggestMeetingTimesRequest{
+				RequiredAttendees: []int32{2},
+				DurationMinutes:   60,
+				From:              "2033-01-04T00:00:00+07:00",
+				To:                "2033-01-05T00:00:00+07:00",
+				Timezone:          "Asia/Jakarta",
+				WorkingHours:      &v1.WorkingHours{Days: []int32{2}, Start: "09:00", End: "11:00"},
+			})
+			Expect(err).Should(BeNil())
+			Expect(res.GetSuggestions()).To(HaveLen(3))
+			Expect(res.GetSuggestions()[0].GetStartTime()).To(Equal("2033-01-04T10:00:00+07:00"))
+			Expect(res.GetSuggestions()[0].GetConflictingRequiredAttendees()).To(BeEmpty())
+			Expect(res.GetSuggestions()[2].GetConflictingRequiredAttendees()).To(Equal([]int32{2}))
+		})
+	})
+})
+
+var _ = Describe("Working hours of a user", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+		ctx           context.Context
+		basedReq      *v1.CreateEventRequest
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "2"}) //nolint:fatcontext
+
+		_, err := endpoint.UpdateAvailability(ctx, &v1.UpdateAvailabilityRequest{
+			Availability: &v1.Availability{
+				UserId:   2,
+				Timezone: "Asia/Jakarta",
+				WorkingHours: []*v1.WorkingHours{
+					{Days: []int32{1, 2, 3, 4, 5}, Start: "09:00", End: "17:00"},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+
+		basedReq = &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "late call",
+				Description: "late call",
+				Timezone:    "Asia/Jakarta",
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2033-01-04T18:00:00+07:00",
+						EndTime:   "2033-01-04T19:00:00+07:00",
+					},
+				},
+				Attendees: []int32{2},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		_, err := endpoint.UpdateAvailability(ctx, &v1.UpdateAvailabilityRequest{
+			Availability: &v1.Availability{UserId: 2, Timezone: "UTC"},
+		})
+		Expect(err).Should(BeNil())
+	})
+
+	It("returns the working hours", func() {
+		res, err := endpoint.GetAvailability(ctx, &v1.GetAvailabilityRequest{UserId: 2})
+		Expect(err).Should(BeNil())
+		Expect(res.GetAvailability().GetTimezone()).To(Equal("Asia/Jakarta"))
+		Expect(res.GetAvailability().GetWorkingHours()).To(HaveLen(1))
+		Expect(res.GetAvailability().GetWorkingHours()[0].GetDays()).To(Equal([]int32{1, 2, 3, 4, 5}))
+		Expect(res.GetAvailability().GetWorkingHours()[0].GetStart()).To(Equal("09:00"))
+	})
+
+	When("the user is unknown", func() {
+		It("returns an error", func() {
+			res, err := endpoint.GetAvailability(ctx, &v1.GetAvailabilityRequest{UserId: 404})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+			Expect(res).Should(BeNil())
+		})
+	})
+
+	When("the event is outside of the working hours", func() {
+		It("warns about it", func() {
+			res, err := endpoint.CreateEvent(ctx, basedReq)
+			Expect(err).Should(BeNil())
+			Expect(res.GetWarnings()).To(HaveLen(1))
+			Expect(res.GetWarnings()[0].GetUserId()).To(Equal(int32(2)))
+			Expect(res.GetWarnings()[0].GetStartTime()).To(Equal("2033-01-04T18:00:00+07:00"))
+		})
+
+		It("returns an error when the working hours are respected", func() {
+			basedReq.RespectWorkingHours = true
+			res, err := endpoint.CreateEvent(ctx, basedReq)
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			Expect(res).Should(BeNil())
+		})
+
+		It("is busy when the working hours are respected", func() {
+			res, err := endpoint.QueryFreeBusy(ctx, &v1.QueryFreeBusyRequest{
+				UserIds:             []int32{2},
+				From:                "2033-01-04T09:00:00+07:00",
+				To:                  "2033-01-04T21:00:00+07:00",
+				Timezone:            "Asia/Jakarta",
+				RespectWorkingHours: true,
+			})
+			Expect(err).Should(BeNil())
+			Expect(res.GetUsers()[0].GetBusy()).To(HaveLen(1))
+			Expect(res.GetUsers()[0].GetBusy()[0].GetStartTime()).To(Equal("2033-01-04T17:00:00+07:00"))
+		})
+	})
+})
+
+var _ = Describe("Reserving a resource", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+		ctx           context.Context
+		resourceID    string
+		basedReq      *v1.CreateEventRequest
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		res, err := endpoint.CreateResource(ctx, &v1.CreateResourceRequest{
+			Resource: &v1.Resource{
+				Name:     "Room 1",
+				Capacity: 8,
+				Location: "3rd floor",
+				Timezone: "Asia/Jakarta",
+			},
+		})
+		Expect(err).Should(BeNil())
+		resourceID = res.GetId()
+
+		basedReq = &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "planning",
+				Description: "planning",
+				Timezone:    "Asia/Jakarta",
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2033-01-04T09:00:00+07:00",
+						EndTime:   "2033-01-04T10:00:00+07:00",
+					},
+				},
+				Resources: []string{resourceID},
+			},
+		}
+	})
+
+	It("returns the resource", func() {
+		res, err := endpoint.GetResource(ctx, &v1.GetResourceRequest{Id: resourceID})
+		Expect(err).Should(BeNil())
+		Expect(res.GetResource().GetName()).To(Equal("Room 1"))
+		Expect(res.GetResource().GetCapacity()).To(Equal(int32(8)))
+
+		list, err := endpoint.ListResources(ctx, &emptypb.Empty{})
+		Expect(err).Should(BeNil())
+		Expect(list.GetResources()).To(ContainElement(HaveField("Id", resourceID)))
+	})
+
+	It("reserves the resource for the event", func() {
+		res, err := endpoint.CreateEvent(ctx, basedReq)
+		Expect(err).Should(BeNil())
+
+		event, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: res.GetId()})
+		Expect(err).Should(BeNil())
+		Expect(event.GetEvent().GetResources()).To(Equal([]string{resourceID}))
+	})
+
+	When("the resource is already reserved", func() {
+		It("returns an error, even when conflicts are allowed", func() {
+			_, err := endpoint.CreateEvent(ctx, basedReq)
+			Expect(err).Should(BeNil())
+
+			basedReq.AllowConflicts = true
+			basedReq.Event.Schedule[0].StartTime = "2033-01-04T09:30:00+07:00"
+			basedReq.Event.Schedule[0].EndTime = "2033-01-04T10:30:00+07:00"
+			res, err := endpoint.CreateEvent(ctx, basedReq)
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			Expect(res).Should(BeNil())
+		})
+
+		It("is free again once the occurrence is cancelled", func() {
+			created, err := endpoint.CreateEvent(ctx, basedReq)
+			Expect(err).Should(BeNil())
+			event, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: created.GetId()})
+			Expect(err).Should(BeNil())
+
+			_, err = endpoint.CancelOccurrence(ctx, &v1.CancelOccurrenceRequest{
+				Id:                created.GetId(),
+				ScheduleId:        event.GetEvent().GetSchedule()[0].GetId(),
+				OriginalStartTime: "2033-01-04T09:00:00+07:00",
+			})
+			Expect(err).Should(BeNil())
+
+			_, err = endpoint.CreateEvent(ctx, basedReq)
+			Expect(err).Should(BeNil())
+		})
+	})
+
+	When("the event recurs past the reservation window", func() {
+		It("returns an error", func() {
+			basedReq.Event.Schedule[0].RecurrenceRule = "FREQ=WEEKLY;BYDAY=TU"
+			res, err := endpoint.CreateEvent(ctx, basedReq)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(res).Should(BeNil())
+
+			basedReq.Event.Schedule[0].RecurrenceRule = "FREQ=WEEKLY;BYDAY=TU;COUNT=10"
+			_, err = endpoint.CreateEvent(ctx, basedReq)
+			Expect(err).Should(BeNil())
+		})
+	})
+
+	When("the resource is unknown", func() {
+		It("returns an error", func() {
+			basedReq.Event.Resources = []string{"unknown"}
+			res, err := endpoint.CreateEvent(ctx, basedReq)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(res).Should(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Buffers around events", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+		ctx           context.Context
+		visitOn       func(day string) *v1.CreateEventRequest
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "4"}) //nolint:fatcontext
+
+		visitOn = func(day string) *v1.CreateEventRequest {
+			travel := int32(30)
+			return &v1.CreateEventRequest{
+				Event: &v1.Event{
+					Title:       "on-site visit",
+					Description: "on-site visit",
+					Timezone:    "Asia/Jakarta",
+					Schedule: []*v1.Schedule{
+						{
+							StartTime:          day + "T09:00:00+07:00",
+							EndTime:            day + "T10:00:00+07:00",
+							BufferAfterMinutes: &travel,
+						},
+					},
+					Attendees: []int32{4},
+				},
+			}
+		}
+	})
+
+	AfterEach(func() {
+		_, err := endpoint.UpdateAvailability(ctx, &v1.UpdateAvailabilityRequest{
+			Availability: &v1.Availability{UserId: 4, Timezone: "UTC"},
+		})
+		Expect(err).Should(BeNil())
+	})
+
+	It("reports the buffers as busy time", func() {
+		_, err := endpoint.CreateEvent(ctx, visitOn("2034-03-07"))
+		Expect(err).Should(BeNil())
+
+		res, err := endpoint.QueryFreeBusy(ctx, &v1.QueryFreeBusyRequest{
+			UserIds:  []int32{4},
+			From:     "2034-03-07T08:00:00+07:00",
+			To:       "2034-03-07T12:00:00+07:00",
+			Timezone: "Asia/Jakarta",
+		})
+		Expect(err).Should(BeNil())
+		Expect(res.GetUsers()[0].GetBusy()).To(HaveLen(1))
+		Expect(res.GetUsers()[0].GetBusy()[0].GetStartTime()).To(Equal("2034-03-07T09:00:00+07:00"))
+		Expect(res.GetUsers()[0].GetBusy()[0].GetEndTime()).To(Equal("2034-03-07T10:30:00+07:00"))
+	})
+
+	It("keeps the buffers out of the event", func() {
+		created, err := endpoint.CreateEvent(ctx, visitOn("2034-03-14"))
+		Expect(err).Should(BeNil())
+
+		res, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: created.GetId()})
+		Expect(err).Should(BeNil())
+		Expect(res.GetEvent().GetSchedule()[0].GetEndTime()).To(Equal("2034-03-14T10:00:00+07:00"))
+		Expect(res.GetEvent().GetSchedule()[0].GetBufferAfterMinutes()).To(Equal(int32(30)))
+		Expect(res.GetEvent().GetSchedule()[0].BufferBeforeMinutes).To(BeNil())
+	})
+
+	When("an event starts during the buffer of another one", func() {
+		It("returns an error", func() {
+			_, err := endpoint.CreateEvent(ctx, visitOn("2034-03-21"))
+			Expect(err).Should(BeNil())
+
+			req := visitOn("2034-03-21")
+			req.Event.Schedule[0].StartTime = "2034-03-21T10:15:00+07:00"
+			req.Event.Schedule[0].EndTime = "2034-03-21T11:00:00+07:00"
+			req.Event.Schedule[0].BufferAfterMinutes = nil
+			res, err := endpoint.CreateEvent(ctx, req)
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			Expect(res).Should(BeNil())
+		})
+	})
+
+	When("the attendee has default buffers", func() {
+		It("applies them to the events without buffers", func() {
+			_, err := endpoint.UpdateAvailability(ctx, &v1.UpdateAvailabilityRequest{
+				Availability: &v1.Availability{UserId: 4, Timezone: "UTC", DefaultBufferBeforeMinutes: 15},
+			})
+			Expect(err).Should(BeNil())
+
+			availability, err := endpoint.GetAvailability(ctx, &v1.GetAvailabilityRequest{UserId: 4})
+			Expect(err).Should(BeNil())
+			Expect(availability.GetAvailability().GetDefaultBufferBeforeMinutes()).To(Equal(int32(15)))
+
+			_, err = endpoint.CreateEvent(ctx, visitOn("2034-03-28"))
+			Expect(err).Should(BeNil())
+
+			res, err := endpoint.QueryFreeBusy(ctx, &v1.QueryFreeBusyRequest{
+				UserIds:  []int32{4},
+				From:     "2034-03-28T08:00:00+07:00",
+				To:       "2034-03-28T12:00:00+07:00",
+				Timezone: "Asia/Jakarta",
+			})
+			Expect(err).Should(BeNil())
+			Expect(res.GetUsers()[0].GetBusy()[0].GetStartTime()).To(Equal("2034-03-28T08:45:00+07:00"))
+			Expect(res.GetUsers()[0].GetBusy()[0].GetEndTime()).To(Equal("2034-03-28T10:30:00+07:00"))
+		})
+	})
+})
+
+var _ = Describe("Skipping holidays", func() {
+	var (
+		endpoint   *grpcEndpoint.GRPCEndpoint
+		ctx        context.Context
+		calendarID string
+	)
+	BeforeEach(func() {
+		schedulingSvc := scheduling.NewService(postgresql.NewEventRepository(db), postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		res, err := endpoint.CreateHolidayCalendar(ctx, &v1.CreateHolidayCalendarRequest{
+			Name:    "Indonesia",
+			Format:  v1.HolidayCalendarFormat_JSON,
+			Content: `[{"date": "2052-02-13", "name": "Holiday"}, {"date": "2052-02-27", "name": "Holiday"}]`,
+		})
+		Expect(err).Should(BeNil())
+		calendarID = res.GetId()
+	})
+
+	It("stores the holidays of the calendar", func() {
+		res, err := endpoint.GetHolidayCalendar(ctx, &v1.GetHolidayCalendarRequest{Id: calendarID})
+		Expect(err).Should(BeNil())
+		Expect(res.GetHolidayCalendar().GetName()).To(Equal("Indonesia"))
+		Expect(res.GetHolidayCalendar().GetHolidays()).To(HaveLen(2))
+		Expect(res.GetHolidayCalendar().GetHolidays()[0].GetDate()).To(Equal("2052-02-13"))
+	})
+
+	It("skips the occurrences falling on a holiday", func() {
+		created, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:             "weekly sync",
+				Description:       "weekly sync",
+				Timezone:          "Asia/Jakarta",
+				HolidayCalendarId: calendarID,
+				SkipHolidays:      true,
+				Schedule: []*v1.Schedule{
+					{
+						StartTime:      "2052-02-06T09:00:00+07:00",
+						EndTime:        "2052-02-06T10:00:00+07:00",
+						RecurrenceRule: "FREQ=WEEKLY;BYDAY=TU",
+					},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+
+		res, err := endpoint.ListOccurrences(ctx, &v1.ListOccurrencesRequest{
+			Id:   created.GetId(),
+			From: "2052-02-01T00:00:00+07:00",
+			To:   "2052-03-01T00:00:00+07:00",
+		})
+		Expect(err).Should(BeNil())
+		Expect(res.GetOccurrences()).To(HaveLen(2))
+		Expect(res.GetOccurrences()[0].GetStartTime()).To(Equal("2052-02-06T09:00:00+07:00"))
+		Expect(res.GetOccurrences()[1].GetStartTime()).To(Equal("2052-02-20T09:00:00+07:00"))
+	})
+
+	When("the holiday calendar is unknown", func() {
+		It("returns an error", func() {
+			res, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+				Event: &v1.Event{
+					Title:             "weekly sync",
+					Description:       "weekly sync",
+					Timezone:          "Asia/Jakarta",
+					HolidayCalendarId: "unknown",
+					SkipHolidays:      true,
+					Schedule: []*v1.Schedule{
+						{
+							StartTime: "2052-02-06T09:00:00+07:00",
+							EndTime:   "2052-02-06T10:00:00+07:00",
+						},
+					},
+				},
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(res).Should(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Responding to an invitation", func() {
+	var (
+		eventRepo *postgresql.EventRepository
+		endpoint  *grpcEndpoint.GRPCEndpoint
+		ctx       context.Context
+		eventID   string
+		token     string
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc := scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		created, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "planning",
+				Description: "planning",
+				Timezone:    "Asia/Jakarta",
+				Attendees:   []int32{5},
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2099-05-06T09:00:00+07:00",
+						EndTime:   "2099-05-06T10:00:00+07:00",
+					},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+		eventID = created.GetId()
+
+		res, err := endpoint.RegenerateInvitationToken(ctx, &v1.RegenerateInvitationTokenRequest{
+			Id:     eventID,
+			UserId: 5,
+		})
+		Expect(err).Should(BeNil())
+		Expect(res.GetExpiresAt()).To(Equal("2099-05-06T10:00:00+07:00"))
+		token = res.GetToken()
+	})
+
+	It("stores the response without authentication", func() {
+		res, err := endpoint.RespondToInvitation(context.Background(), &v1.RespondToInvitationRequest{
+			Token:    token,
+			Response: v1.InvitationStatus_DECLINED,
+			Comment:  "I'm on leave",
+		})
+		Expect(err).Should(BeNil())
+		Expect(res.GetEventId()).To(Equal(eventID))
+		Expect(res.GetStatus()).To(Equal(v1.InvitationStatus_DECLINED))
+
+		e, err := eventRepo.FindByID(context.Background(), eventID)
+		Expect(err).Should(BeNil())
+		Expect(e.Invitations[0].Status).To(Equal(core.InvitationStatus_Declined))
+		Expect(e.Invitations[0].Comment).To(Equal("I'm on leave"))
+		Expect(e.Invitations[0].UpdatedAt).ShouldNot(BeNil())
+	})
+
+	It("only stores the hash of the token", func() {
+		e, err := eventRepo.FindByID(context.Background(), eventID)
+		Expect(err).Should(BeNil())
+		Expect(e.Invitations[0].TokenHash).To(Equal(core.HashInvitationToken(token)))
+	})
+
+	When("the token is forged", func() {
+		It("returns an error", func() {
+			res, err := endpoint.RespondToInvitation(context.Background(), &v1.RespondToInvitationRequest{
+				Token:    "test.aW52aXRhdGlvbg.c2lnbmF0dXJl",
+				Response: v1.InvitationStatus_CONFIRMED,
+			})
+			Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			Expect(res).Should(BeNil())
+		})
+	})
+
+	When("the token was regenerated", func() {
+		It("returns an error", func() {
+			_, err := endpoint.RegenerateInvitationToken(ctx, &v1.RegenerateInvitationTokenRequest{
+				Id:     eventID,
+				UserId: 5,
+			})
+			Expect(err).Should(BeNil())
+
+			res, err := endpoint.RespondToInvitation(context.Background(), &v1.RespondToInvitationRequest{
+				Token:    token,
+				Response: v1.InvitationStatus_CONFIRMED,
+			})
+			Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			Expect(res).Should(BeNil())
+		})
+	})
+
+	When("the response is missing", func() {
+		It("returns an error", func() {
+			res, err := endpoint.RespondToInvitation(context.Background(), &v1.RespondToInvitationRequest{
+				Token: token,
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(res).Should(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Proposing a new time", func() {
+	var (
+		eventRepo  *postgresql.EventRepository
+		endpoint   *grpcEndpoint.GRPCEndpoint
+		ctx        context.Context
+		eventID    string
+		scheduleID string
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc := scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		created, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "review",
+				Description: "review",
+				Timezone:    "Asia/Jakarta",
+				Attendees:   []int32{6, 7},
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2099-06-03T09:00:00+07:00",
+						EndTime:   "2099-06-03T10:00:00+07:00",
+					},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+		eventID = created.GetId()
+
+		e, err := eventRepo.FindByID(context.Background(), eventID)
+		Expect(err).Should(BeNil())
+		scheduleID = e.Schedules[0].ID
+
+		for _, userID := range []int32{6, 7} {
+			res, err := endpoint.RegenerateInvitationToken(ctx, &v1.RegenerateInvitationTokenRequest{
+				Id:     eventID,
+				UserId: userID,
 			})
+			Expect(err).Should(BeNil())
 
-			When("there's no schedule", func() {
-				It("returns error", func() {
-					basedReq.Event.Schedule = nil
-					res, err := endpoint.CreateEvent(ctx, basedReq)
-					Expect(err).ShouldNot(BeNil())
-					Expect(res).Should(BeNil())
-				})
+			response := v1.InvitationStatus_CONFIRMED
+			var proposal *v1.TimeProposal
+			if userID == 6 {
+				response = v1.InvitationStatus_TENTATIVE
+				proposal = &v1.TimeProposal{
+					ScheduleId: scheduleID,
+					StartTime:  "2099-06-04T13:00:00+07:00",
+					EndTime:    "2099-06-04T14:30:00+07:00",
+				}
+			}
+			_, err = endpoint.RespondToInvitation(context.Background(), &v1.RespondToInvitationRequest{
+				Token:    res.GetToken(),
+				Response: response,
+				Proposal: proposal,
 			})
+			Expect(err).Should(BeNil())
+		}
+	})
 
-			When("the event data is valid", func() {
-				It("creates the event", func() {
-					res, err := endpoint.CreateEvent(ctx, basedReq)
-					Expect(err).Should(BeNil())
-					Expect(res).ShouldNot(BeNil())
+	It("lists the proposals to the organizer", func() {
+		res, err := endpoint.ListProposals(ctx, &v1.ListProposalsRequest{Id: eventID})
+		Expect(err).Should(BeNil())
+		Expect(res.GetProposals()).To(HaveLen(1))
+		Expect(res.GetProposals()[0].GetUserId()).To(Equal(int32(6)))
+		Expect(res.GetProposals()[0].GetStatus()).To(Equal(v1.InvitationStatus_TENTATIVE))
+		Expect(res.GetProposals()[0].GetProposal().GetStartTime()).To(Equal("2099-06-04T06:00:00Z"))
+	})
 
-					e, err := eventRepo.FindByID(context.Background(), res.GetId())
-					Expect(err).Should(BeNil())
-					Expect(e).ShouldNot(BeNil())
+	It("moves the schedule and resets the other responses once accepted", func() {
+		proposals, err := endpoint.ListProposals(ctx, &v1.ListProposalsRequest{Id: eventID})
+		Expect(err).Should(BeNil())
 
-					Expect(e.ID).To(Equal(res.GetId()))
-					Expect(e.Title).To(Equal(basedReq.GetEvent().GetTitle()))
-					Expect(e.Description).To(Equal(basedReq.GetEvent().GetDescription()))
-					Expect(e.Timezone).To(Equal(basedReq.GetEvent().GetTimezone()))
-					Expect(len(e.Schedules)).To(Equal(len(basedReq.GetEvent().GetSchedule())))
-					Expect(len(e.Invitations)).To(Equal(len(basedReq.GetEvent().GetAttendees())))
-					Expect(e.CreatedBy).To(Equal(actorID))
-					Expect(e.CreatedAt).NotTo(BeNil())
-				})
+		_, err = endpoint.AcceptProposal(ctx, &v1.AcceptProposalRequest{
+			Id:           eventID,
+			InvitationId: proposals.GetProposals()[0].GetInvitationId(),
+		})
+		Expect(err).Should(BeNil())
+
+		res, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: eventID})
+		Expect(err).Should(BeNil())
+		Expect(res.GetEvent().GetSchedule()[0].GetStartTime()).To(Equal("2099-06-04T13:00:00+07:00"))
+		Expect(res.GetEvent().GetSchedule()[0].GetEndTime()).To(Equal("2099-06-04T14:30:00+07:00"))
+
+		e, err := eventRepo.FindByID(context.Background(), eventID)
+		Expect(err).Should(BeNil())
+		for _, inv := range e.Invitations {
+			switch inv.UserID {
+			case 6:
+				Expect(inv.Status).To(Equal(core.InvitationStatus_Confirmed))
+				Expect(inv.Proposal).Should(BeNil())
+			case 7:
+				Expect(inv.Status).To(Equal(core.InvitationStatus_Unknown))
+			}
+		}
+	})
+
+	When("the actor isn't the organizer", func() {
+		It("returns an error", func() {
+			proposals, err := endpoint.ListProposals(ctx, &v1.ListProposalsRequest{Id: eventID})
+			Expect(err).Should(BeNil())
+
+			res, err := endpoint.AcceptProposal(auth.NewContext(context.Background(), auth.Principal{Subject: "7"}), &v1.AcceptProposalRequest{
+				Id:           eventID,
+				InvitationId: proposals.GetProposals()[0].GetInvitationId(),
 			})
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			Expect(res).Should(BeNil())
+		})
+	})
+})
 
-			When("the event has no attendees", func() {
-				It("creates the event", func() {
-					basedReq.Event.Attendees = nil
-					res, err := endpoint.CreateEvent(ctx, basedReq)
-					Expect(err).Should(BeNil())
-					Expect(res).ShouldNot(BeNil())
+var _ = Describe("Responding to a single occurrence", func() {
+	var (
+		endpoint   *grpcEndpoint.GRPCEndpoint
+		ctx        context.Context
+		eventID    string
+		scheduleID string
+	)
+	BeforeEach(func() {
+		eventRepo := postgresql.NewEventRepository(db)
+		schedulingSvc := scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
 
-					e, err := eventRepo.FindByID(context.Background(), res.GetId())
-					Expect(err).Should(BeNil())
-					Expect(e).ShouldNot(BeNil())
+		created, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "weekly sync",
+				Description: "weekly sync",
+				Timezone:    "Asia/Jakarta",
+				Attendees:   []int32{2, 3},
+				Schedule: []*v1.Schedule{
+					{
+						StartTime:      "2099-07-07T09:00:00+07:00",
+						EndTime:        "2099-07-07T10:00:00+07:00",
+						RecurrenceRule: "FREQ=WEEKLY;COUNT=3",
+					},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+		eventID = created.GetId()
 
-					Expect(e.ID).To(Equal(res.GetId()))
-				})
+		e, err := eventRepo.FindByID(context.Background(), eventID)
+		Expect(err).Should(BeNil())
+		scheduleID = e.Schedules[0].ID
+
+		token, err := endpoint.RegenerateInvitationToken(ctx, &v1.RegenerateInvitationTokenRequest{
+			Id:     eventID,
+			UserId: 2,
+		})
+		Expect(err).Should(BeNil())
+
+		_, err = endpoint.RespondToInvitation(context.Background(), &v1.RespondToInvitationRequest{
+			Token:    token.GetToken(),
+			Response: v1.InvitationStatus_CONFIRMED,
+		})
+		Expect(err).Should(BeNil())
+
+		res, err := endpoint.RespondToInvitation(context.Background(), &v1.RespondToInvitationRequest{
+			Token:             token.GetToken(),
+			Response:          v1.InvitationStatus_DECLINED,
+			ScheduleId:        scheduleID,
+			OriginalStartTime: "2099-07-14T09:00:00+07:00",
+		})
+		Expect(err).Should(BeNil())
+		Expect(res.GetStatus()).To(Equal(v1.InvitationStatus_DECLINED))
+	})
+
+	It("lists the response to the occurrence alone", func() {
+		res, err := endpoint.ListOccurrences(ctx, &v1.ListOccurrencesRequest{
+			Id:   eventID,
+			From: "2099-07-01T00:00:00+07:00",
+			To:   "2099-08-01T00:00:00+07:00",
+		})
+		Expect(err).Should(BeNil())
+		Expect(res.GetOccurrences()).To(HaveLen(3))
+
+		statuses := make([]v1.InvitationStatus, len(res.GetOccurrences()))
+		for i, o := range res.GetOccurrences() {
+			for _, a := range o.GetAttendees() {
+				if a.GetUserId() == 2 {
+					statuses[i] = a.GetStatus()
+				}
+			}
+		}
+		Expect(statuses).To(Equal([]v1.InvitationStatus{
+			v1.InvitationStatus_CONFIRMED,
+			v1.InvitationStatus_DECLINED,
+			v1.InvitationStatus_CONFIRMED,
+		}))
+	})
+
+	It("frees the attendee during the declined occurrence only", func() {
+		res, err := endpoint.QueryFreeBusy(ctx, &v1.QueryFreeBusyRequest{
+			UserIds:  []int32{2, 3},
+			From:     "2099-07-01T00:00:00+07:00",
+			To:       "2099-08-01T00:00:00+07:00",
+			Timezone: "Asia/Jakarta",
+		})
+		Expect(err).Should(BeNil())
+		Expect(res.GetUsers()[0].GetBusy()).To(HaveLen(2))
+		Expect(res.GetUsers()[0].GetBusy()[1].GetStartTime()).To(Equal("2099-07-21T09:00:00+07:00"))
+		Expect(res.GetUsers()[1].GetBusy()).To(HaveLen(3))
+	})
+
+	When("the schedule has no occurrence at the original start", func() {
+		It("returns an error", func() {
+			token, err := endpoint.RegenerateInvitationToken(ctx, &v1.RegenerateInvitationTokenRequest{
+				Id:     eventID,
+				UserId: 3,
+			})
+			Expect(err).Should(BeNil())
+
+			res, err := endpoint.RespondToInvitation(context.Background(), &v1.RespondToInvitationRequest{
+				Token:             token.GetToken(),
+				Response:          v1.InvitationStatus_DECLINED,
+				ScheduleId:        scheduleID,
+				OriginalStartTime: "2099-07-15T09:00:00+07:00",
 			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(res).Should(BeNil())
 		})
 	})
 })
 
-var _ = Describe("Deleting an Event", func() {
+var _ = Describe("Inviting a guest", func() {
 	var (
-		eventRepo     *postgresql.EventRepository
-		schedulingSvc *scheduling.Service
-		endpoint      *grpcEndpoint.GRPCEndpoint
+		eventRepo *postgresql.EventRepository
+		endpoint  *grpcEndpoint.GRPCEndpoint
+		ctx       context.Context
+		eventID   string
 	)
 	BeforeEach(func() {
 		eventRepo = postgresql.NewEventRepository(db)
-		schedulingSvc = scheduling.NewService(eventRepo)
-		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc)
+		schedulingSvc := scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		created, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "vendor demo",
+				Description: "vendor demo",
+				Timezone:    "Asia/Jakarta",
+				Attendees:   []int32{2},
+				Guests:      []*v1.Guest{{Email: "Jane@Example.com", Name: "Jane Doe"}},
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2099-08-04T09:00:00+07:00",
+						EndTime:   "2099-08-04T10:00:00+07:00",
+					},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+		eventID = created.GetId()
 	})
 
-	Context("Run", func() {
-		var event *core.Event
-		BeforeEach(func() {
-			event = core.NewEvent("test_actor")
-			err := eventRepo.Store(context.Background(), event)
-			Expect(err).Should(BeNil())
+	It("returns the guest along with the attendees", func() {
+		res, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: eventID})
+		Expect(err).Should(BeNil())
+		Expect(res.GetEvent().GetAttendees()).To(Equal([]int32{2}))
+		Expect(res.GetEvent().GetGuests()).To(HaveLen(1))
+		Expect(res.GetEvent().GetGuests()[0].GetEmail()).To(Equal("jane@example.com"))
+		Expect(res.GetEvent().GetGuests()[0].GetName()).To(Equal("Jane Doe"))
+	})
+
+	It("lets the guest respond with the token of the invitation", func() {
+		token, err := endpoint.RegenerateInvitationToken(ctx, &v1.RegenerateInvitationTokenRequest{
+			Id:         eventID,
+			GuestEmail: "jane@example.com",
 		})
+		Expect(err).Should(BeNil())
 
-		When("user is unauthorized", func() {
-			It("returns an error", func() {
-				empty, err := endpoint.DeleteEventByID(context.Background(), &v1.DeleteEventByIDRequest{
-					Id: event.ID,
-				})
-				Expect(err).ShouldNot(BeNil())
-				Expect(empty).Should(BeNil())
+		res, err := endpoint.RespondToInvitation(context.Background(), &v1.RespondToInvitationRequest{
+			Token:    token.GetToken(),
+			Response: v1.InvitationStatus_CONFIRMED,
+		})
+		Expect(err).Should(BeNil())
+		Expect(res.GetStatus()).To(Equal(v1.InvitationStatus_CONFIRMED))
 
-				e, err := eventRepo.FindByID(context.Background(), event.ID)
-				Expect(err).Should(BeNil())
-				Expect(e).ShouldNot(BeNil())
+		e, err := eventRepo.FindByID(context.Background(), eventID)
+		Expect(err).Should(BeNil())
+		for _, inv := range e.Invitations {
+			if inv.Guest != nil {
+				Expect(inv.Status).To(Equal(core.InvitationStatus_Confirmed))
+			}
+		}
+	})
+
+	When("the guest is invited twice", func() {
+		It("returns an error", func() {
+			res, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+				Event: &v1.Event{
+					Title:       "vendor demo",
+					Description: "vendor demo",
+					Timezone:    "Asia/Jakarta",
+					Guests:      []*v1.Guest{{Email: "jane@example.com"}, {Email: "JANE@example.com"}},
+					Schedule: []*v1.Schedule{
+						{
+							StartTime: "2099-08-05T09:00:00+07:00",
+							EndTime:   "2099-08-05T10:00:00+07:00",
+						},
+					},
+				},
 			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(res).Should(BeNil())
 		})
+	})
+})
 
-		When("user is authorized", func() {
-			var ctx context.Context
-			BeforeEach(func() {
-				ctx = metadata.NewIncomingContext(context.Background(), metadata.MD{ //nolint:fatcontext
-					"Authorization": []string{"test_actor"},
-				})
+var _ = Describe("Listing my invitations", func() {
+	var (
+		endpoint *grpcEndpoint.GRPCEndpoint
+		ctx      context.Context
+	)
+	BeforeEach(func() {
+		schedulingSvc := scheduling.NewService(postgresql.NewEventRepository(db), postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		for _, day := range []string{"2099-09-01", "2099-09-02"} {
+			_, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+				Event: &v1.Event{
+					Title:       "review " + day,
+					Description: "review",
+					Timezone:    "Asia/Jakarta",
+					Attendees:   []int32{3},
+					Schedule: []*v1.Schedule{
+						{
+							StartTime: day + "T09:00:00+07:00",
+							EndTime:   day + "T10:00:00+07:00",
+						},
+					},
+				},
 			})
+			Expect(err).Should(BeNil())
+		}
+	})
 
-			When("the event is exists", func() {
-				It("deletes the data", func() {
-					empty, err := endpoint.DeleteEventByID(ctx, &v1.DeleteEventByIDRequest{
-						Id: event.ID,
-					})
-					Expect(err).Should(BeNil())
-					Expect(empty).ShouldNot(BeNil())
+	It("returns the invitations of the user a page at a time", func() {
+		userCtx := auth.NewContext(context.Background(), auth.Principal{Subject: "3"})
 
-					_, err = eventRepo.FindByID(context.Background(), event.ID)
-					Expect(err).ShouldNot(BeNil())
-				})
-			})
+		first, err := endpoint.ListMyInvitations(userCtx, &v1.ListMyInvitationsRequest{PageSize: 1})
+		Expect(err).Should(BeNil())
+		Expect(first.GetInvitations()).To(HaveLen(1))
+		Expect(first.GetInvitations()[0].GetStatus()).To(Equal(v1.InvitationStatus_PENDING))
+		Expect(first.GetInvitations()[0].GetEvent().GetCreatedBy()).To(Equal("1"))
+		Expect(first.GetInvitations()[0].GetNextOccurrence().GetStartTime()).To(HavePrefix("2099-09-0"))
+		Expect(first.GetNextPageToken()).ShouldNot(BeEmpty())
 
-			When("the event is not exists", func() {
-				It("returns an error", func() {
-					empty, err := endpoint.DeleteEventByID(ctx, &v1.DeleteEventByIDRequest{
-						Id: "invalid id",
-					})
-					Expect(err).ShouldNot(BeNil())
-					Expect(empty).Should(BeNil())
-				})
-			})
+		second, err := endpoint.ListMyInvitations(userCtx, &v1.ListMyInvitationsRequest{PageSize: 1, PageToken: first.GetNextPageToken()})
+		Expect(err).Should(BeNil())
+		Expect(second.GetInvitations()).To(HaveLen(1))
+		Expect(second.GetInvitations()[0].GetInvitationId()).ShouldNot(Equal(first.GetInvitations()[0].GetInvitationId()))
+		Expect(second.GetNextPageToken()).To(BeEmpty())
+	})
+
+	It("filters the invitations by status and time range", func() {
+		userCtx := auth.NewContext(context.Background(), auth.Principal{Subject: "3"})
+
+		res, err := endpoint.ListMyInvitations(userCtx, &v1.ListMyInvitationsRequest{Statuses: []v1.InvitationStatus{v1.InvitationStatus_CONFIRMED}})
+		Expect(err).Should(BeNil())
+		Expect(res.GetInvitations()).To(BeEmpty())
+
+		res, err = endpoint.ListMyInvitations(userCtx, &v1.ListMyInvitationsRequest{
+			From: "2099-09-02T00:00:00+07:00",
+			To:   "2099-09-03T00:00:00+07:00",
+		})
+		Expect(err).Should(BeNil())
+		Expect(res.GetInvitations()).To(HaveLen(1))
+		Expect(res.GetInvitations()[0].GetEvent().GetTitle()).To(Equal("review 2099-09-02"))
+	})
+})
+
+var _ = Describe("Updating an event as an attendee", func() {
+	var (
+		endpoint *grpcEndpoint.GRPCEndpoint
+		eventID  string
+		settings []*v1.AttendeeSettings
+	)
+	updateAs := func(userID string, title string) error {
+		ctx := auth.NewContext(context.Background(), auth.Principal{Subject: userID})
+		_, err := endpoint.UpdateEvent(ctx, &v1.UpdateEventRequest{
+			Id: eventID,
+			Event: &v1.Event{
+				Title:            title,
+				Description:      "design review",
+				Timezone:         "Asia/Jakarta",
+				Attendees:        []int32{2, 3},
+				AttendeeSettings: settings,
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2099-10-01T09:00:00+07:00",
+						EndTime:   "2099-10-01T10:00:00+07:00",
+					},
+				},
+			},
+			AllowConflicts: true,
+		})
+		return err
+	}
+	BeforeEach(func() {
+		schedulingSvc := scheduling.NewService(postgresql.NewEventRepository(db), postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx := auth.NewContext(context.Background(), auth.Principal{Subject: "1"})
+		settings = []*v1.AttendeeSettings{
+			{UserId: 2, Role: v1.AttendeeRole_REQUIRED, Permissions: &v1.AttendeePermissions{CanModifyEvent: true, CanSeeGuestList: true}},
+			{UserId: 3, Role: v1.AttendeeRole_OPTIONAL},
+		}
+
+		res, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:            "design review",
+				Description:      "design review",
+				Timezone:         "Asia/Jakarta",
+				Attendees:        []int32{2, 3},
+				AttendeeSettings: settings,
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2099-10-01T09:00:00+07:00",
+						EndTime:   "2099-10-01T10:00:00+07:00",
+					},
+				},
+			},
+			AllowConflicts: true,
+		})
+		Expect(err).Should(BeNil())
+		eventID = res.GetId()
+	})
+
+	It("returns the roles and the permissions of the attendees", func() {
+		res, err := endpoint.FindEventByID(context.Background(), &v1.FindEventByIDRequest{Id: eventID})
+		Expect(err).Should(BeNil())
+		Expect(res.GetEvent().GetAttendeeSettings()).To(HaveLen(2))
+		for _, s := range res.GetEvent().GetAttendeeSettings() {
+			switch s.GetUserId() {
+			case 2:
+				Expect(s.GetPermissions().GetCanModifyEvent()).To(BeTrue())
+			case 3:
+				Expect(s.GetRole()).To(Equal(v1.AttendeeRole_OPTIONAL))
+				Expect(s.GetPermissions().GetCanSeeGuestList()).To(BeTrue())
+			}
+		}
+	})
+
+	It("lets the attendee allowed to modify the event update it", func() {
+		Expect(updateAs("2", "design review, moved")).Should(BeNil())
+
+		res, err := endpoint.FindEventByID(context.Background(), &v1.FindEventByIDRequest{Id: eventID})
+		Expect(err).Should(BeNil())
+		Expect(res.GetEvent().GetTitle()).To(Equal("design review, moved"))
+		Expect(res.GetEvent().GetAttendees()).To(ConsistOf(int32(2), int32(3)))
+	})
+
+	When("the attendee isn't allowed to modify the event", func() {
+		It("returns an error", func() {
+			err := updateAs("3", "design review, moved")
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
 		})
 	})
 })
@@ -243,9 +1733,7 @@ var _ = Describe("Deleting an Event", func() {
 // 				},
 // 			},
 // 			args: args{
-// 				ctx: metadata.NewIncomingContext(context.Background(), metadata.MD{
-// 					"Authorization": []string{"1"},
-// 				}),
+// 				ctx: auth.NewContext(context.Background(), auth.Principal{Subject: "1"}),
 // 				req: &v1.UpdateEventRequest{
 // 					Id: "test123",
 // 					Event: &v1.Event{
@@ -255,14 +1743,14 @@ var _ = Describe("Deleting an Event", func() {
 // 						Attendees:   []string{"2", "3"},
 // 						Schedule: []*v1.Schedule{
 // 							{
-// 								StartTime:     "2022-01-01T00:00:00+07:00",
-// 								EndTime:       "2022-01-01T01:00:00+07:00",
+// 								StartTime:     "2033-01-01T00:00:00+07:00",
+// 								EndTime:       "2033-01-01T01:00:00+07:00",
 // 								IsFullDay:     false,
 // 								RecurringType: v1.RecurringType_NONE,
 // 							},
 // 							{
-// 								StartTime:     "2022-01-01T01:00:00+07:00",
-// 								EndTime:       "2022-01-01T02:00:00+07:00",
+// 								StartTime:     "2033-01-01T01:00:00+07:00",
+// 								EndTime:       "2033-01-01T02:00:00+07:00",
 // 								IsFullDay:     false,
 // 								RecurringType: v1.RecurringType_NONE,
 // 							},
@@ -284,9 +1772,7 @@ var _ = Describe("Deleting an Event", func() {
 // 				},
 // 			},
 // 			args: args{
-// 				ctx: metadata.NewIncomingContext(context.Background(), metadata.MD{
-// 					"Authorization": []string{"1"},
-// 				}),
+// 				ctx: auth.NewContext(context.Background(), auth.Principal{Subject: "1"}),
 // 				req: &v1.UpdateEventRequest{
 // 					Id: "test123",
 // 					Event: &v1.Event{
@@ -296,14 +1782,14 @@ var _ = Describe("Deleting an Event", func() {
 // 						Attendees:   []string{"2", "3"},
 // 						Schedule: []*v1.Schedule{
 // 							{
-// 								StartTime:     "2022-01-01T00:00:00+07:00",
-// 								EndTime:       "2022-01-01T01:00:00+07:00",
+// 								StartTime:     "2033-01-01T00:00:00+07:00",
+// 								EndTime:       "2033-01-01T01:00:00+07:00",
 // 								IsFullDay:     false,
 // 								RecurringType: v1.RecurringType_NONE,
 // 							},
 // 							{
-// 								StartTime:     "2022-01-01T01:00:00+07:00",
-// 								EndTime:       "2022-01-01T02:00:00+07:00",
+// 								StartTime:     "2033-01-01T01:00:00+07:00",
+// 								EndTime:       "2033-01-01T02:00:00+07:00",
 // 								IsFullDay:     false,
 // 								RecurringType: v1.RecurringType_NONE,
 // 							},
@@ -403,15 +1889,15 @@ var _ = Describe("Deleting an Event", func() {
 // 					Schedule: []*v1.Schedule{
 // 						{
 // 							Id:            "sch1",
-// 							StartTime:     "2022-01-01T07:00:00+07:00",
-// 							EndTime:       "2022-01-01T09:00:00+07:00",
+// 							StartTime:     "2033-01-01T07:00:00+07:00",
+// 							EndTime:       "2033-01-01T09:00:00+07:00",
 // 							IsFullDay:     false,
 // 							RecurringType: v1.RecurringType_NONE,
 // 						},
 // 					},
 // 					CreatedBy:     "1",
-// 					CreatedAt:     "2022-01-01T00:00:00Z",
-// 					LastUpdatedAt: "2022-01-01T00:00:00Z",
+// 					CreatedAt:     "2033-01-01T00:00:00Z",
+// 					LastUpdatedAt: "2033-01-01T00:00:00Z",
 // 				},
 // 			},
 // 		},
@@ -451,3 +1937,221 @@ var _ = Describe("Deleting an Event", func() {
 // 		})
 // 	}
 // }
+
+var _ = Describe("Limiting the attendees of an event", func() {
+	var (
+		eventRepo *postgresql.EventRepository
+		endpoint  *grpcEndpoint.GRPCEndpoint
+		ctx       context.Context
+		eventID   string
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc := scheduling.NewService(eventRepo, postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		created, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:        "office hours",
+				Description:  "office hours",
+				Timezone:     "Asia/Jakarta",
+				Attendees:    []int32{2, 3},
+				MaxAttendees: 1,
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2099-08-11T09:00:00+07:00",
+						EndTime:   "2099-08-11T10:00:00+07:00",
+					},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+		eventID = created.GetId()
+	})
+
+	respond := func(userID int32, response v1.InvitationStatus) *v1.RespondToInvitationResponse {
+		token, err := endpoint.RegenerateInvitationToken(ctx, &v1.RegenerateInvitationTokenRequest{Id: eventID, UserId: userID})
+		Expect(err).Should(BeNil())
+
+		res, err := endpoint.RespondToInvitation(context.Background(), &v1.RespondToInvitationRequest{
+			Token:    token.GetToken(),
+			Response: response,
+		})
+		Expect(err).Should(BeNil())
+		return res
+	}
+
+	It("waitlists the confirmations past the limit", func() {
+		Expect(respond(2, v1.InvitationStatus_CONFIRMED).GetStatus()).To(Equal(v1.InvitationStatus_CONFIRMED))
+		Expect(respond(3, v1.InvitationStatus_CONFIRMED).GetStatus()).To(Equal(v1.InvitationStatus_WAITLISTED))
+
+		res, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: eventID})
+		Expect(err).Should(BeNil())
+		Expect(res.GetEvent().GetMaxAttendees()).To(Equal(int32(1)))
+	})
+
+	When("a confirmed attendee declines", func() {
+		It("promotes the waitlisted attendee", func() {
+			respond(2, v1.InvitationStatus_CONFIRMED)
+			respond(3, v1.InvitationStatus_CONFIRMED)
+			Expect(respond(2, v1.InvitationStatus_DECLINED).GetStatus()).To(Equal(v1.InvitationStatus_DECLINED))
+
+			e, err := eventRepo.FindByID(context.Background(), eventID)
+			Expect(err).Should(BeNil())
+			for _, inv := range e.Invitations {
+				if inv.UserID == 3 {
+					Expect(inv.Status).To(Equal(core.InvitationStatus_Confirmed))
+					Expect(inv.WaitlistedAt).Should(BeNil())
+				}
+			}
+		})
+	})
+})
+
+var _ = Describe("Managing users", func() {
+	var (
+		endpoint *grpcEndpoint.GRPCEndpoint
+		ctx      context.Context
+	)
+	BeforeEach(func() {
+		schedulingSvc := scheduling.NewService(postgresql.NewEventRepository(db), postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+	})
+
+	asUser := func(id int32) context.Context {
+		return auth.NewContext(context.Background(), auth.Principal{Subject: fmt.Sprint(id)})
+	}
+
+	eventWith := func(attendees ...int32) *v1.CreateEventRequest {
+		return &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "onboarding",
+				Description: "onboarding",
+				Timezone:    "Asia/Jakarta",
+				Attendees:   attendees,
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2099-08-12T09:00:00+07:00",
+						EndTime:   "2099-08-12T10:00:00+07:00",
+					},
+				},
+			},
+		}
+	}
+
+	It("creates, lists and deactivates a user", func() {
+		created, err := endpoint.CreateUser(ctx, &v1.CreateUserRequest{User: &v1.User{Name: "Erin", Timezone: "Asia/Jakarta"}})
+		Expect(err).Should(BeNil())
+
+		res, err := endpoint.GetUser(ctx, &v1.GetUserRequest{Id: created.GetId()})
+		Expect(err).Should(BeNil())
+		Expect(res.GetUser().GetName()).To(Equal("Erin"))
+		Expect(res.GetUser().GetDeactivatedAt()).To(BeEmpty())
+
+		_, err = endpoint.CreateEvent(ctx, eventWith(created.GetId()))
+		Expect(err).Should(BeNil())
+
+		_, err = endpoint.DeactivateUser(ctx, &v1.DeactivateUserRequest{Id: created.GetId()})
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+
+		_, err = endpoint.DeactivateUser(asUser(created.GetId()), &v1.DeactivateUserRequest{Id: created.GetId()})
+		Expect(err).Should(BeNil())
+
+		active, err := endpoint.ListUsers(ctx, &v1.ListUsersRequest{PageSize: 200})
+		Expect(err).Should(BeNil())
+		for _, u := range active.GetUsers() {
+			Expect(u.GetId()).NotTo(Equal(created.GetId()))
+		}
+
+		all, err := endpoint.ListUsers(ctx, &v1.ListUsersRequest{IncludeDeactivated: true, PageSize: 200})
+		Expect(err).Should(BeNil())
+		Expect(len(all.GetUsers())).To(Equal(len(active.GetUsers()) + 1))
+	})
+
+	When("an attendee is unknown or deactivated", func() {
+		It("returns an error naming the attendees", func() {
+			created, err := endpoint.CreateUser(ctx, &v1.CreateUserRequest{User: &v1.User{Name: "Frank", Timezone: "UTC"}})
+			Expect(err).Should(BeNil())
+			_, err = endpoint.DeactivateUser(asUser(created.GetId()), &v1.DeactivateUserRequest{Id: created.GetId()})
+			Expect(err).Should(BeNil())
+
+			res, err := endpoint.CreateEvent(ctx, eventWith(2, 404, created.GetId()))
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(status.Convert(err).Message()).To(ContainSubstring("unknown attendees 404"))
+			Expect(status.Convert(err).Message()).To(ContainSubstring(fmt.Sprintf("deactivated attendees %d", created.GetId())))
+			Expect(res).Should(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Inviting groups", func() {
+	var (
+		endpoint *grpcEndpoint.GRPCEndpoint
+		ctx      context.Context
+		groupID  string
+	)
+	BeforeEach(func() {
+		schedulingSvc := scheduling.NewService(postgresql.NewEventRepository(db), postgresql.NewAvailabilityRepository(db), postgresql.NewResourceRepository(db), postgresql.NewHolidayCalendarRepository(db), postgresql.NewInvitationRepository(db), postgresql.NewUserRepository(db), postgresql.NewGroupRepository(db), tokenSigner, notification.Nop{})
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, user.NewService(postgresql.NewUserRepository(db)))
+		ctx = auth.NewContext(context.Background(), auth.Principal{Subject: "1"}) //nolint:fatcontext
+
+		sub, err := endpoint.CreateGroup(ctx, &v1.CreateGroupRequest{Group: &v1.Group{Name: "sre", MemberIds: []int32{3}}})
+		Expect(err).Should(BeNil())
+
+		created, err := endpoint.CreateGroup(ctx, &v1.CreateGroupRequest{Group: &v1.Group{Name: "platform", MemberIds: []int32{2}, SubgroupIds: []string{sub.GetId()}}})
+		Expect(err).Should(BeNil())
+		groupID = created.GetId()
+	})
+
+	inviteGroup := func(sync bool) string {
+		created, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+			Event: &v1.Event{
+				Title:       "all hands",
+				Description: "all hands",
+				Timezone:    "Asia/Jakarta",
+				Groups:      []*v1.EventGroup{{GroupId: groupID, Sync: sync}},
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2099-08-13T09:00:00+07:00",
+						EndTime:   "2099-08-13T10:00:00+07:00",
+					},
+				},
+			},
+		})
+		Expect(err).Should(BeNil())
+		return created.GetId()
+	}
+
+	attendeesOf := func(eventID string) []int32 {
+		res, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{Id: eventID})
+		Expect(err).Should(BeNil())
+		for _, settings := range res.GetEvent().GetAttendeeSettings() {
+			Expect(settings.GetGroupId()).To(Equal(groupID))
+		}
+		return res.GetEvent().GetAttendees()
+	}
+
+	It("invites the members of the group and of its subgroups", func() {
+		eventID := inviteGroup(false)
+		Expect(attendeesOf(eventID)).To(ConsistOf(int32(2), int32(3)))
+
+		res, err := endpoint.GetGroup(ctx, &v1.GetGroupRequest{Id: groupID})
+		Expect(err).Should(BeNil())
+		Expect(res.GetGroup().GetMemberIds()).To(ConsistOf(int32(2)))
+	})
+
+	When("the members of a synced group change", func() {
+		It("updates the invitations of the event", func() {
+			eventID := inviteGroup(true)
+			unsyncedID := inviteGroup(false)
+
+			_, err := endpoint.UpdateGroupMembers(ctx, &v1.UpdateGroupMembersRequest{Id: groupID, MemberIds: []int32{4}})
+			Expect(err).Should(BeNil())
+
+			Expect(attendeesOf(eventID)).To(ConsistOf(int32(4)))
+			Expect(attendeesOf(unsyncedID)).To(ConsistOf(int32(2), int32(3)))
+		})
+	})
+})
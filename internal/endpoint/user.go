@@ -0,0 +1,105 @@
+package endpoint
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (g *GRPCEndpoint) CreateUser(ctx context.Context, req *v1.CreateUserRequest) (*v1.CreateUserResponse, error) {
+	createReq, err := parseCreateUserRequest(req)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = g.userSvc.CreateUser(ctx, createReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.CreateUserResponse{
+		Id: createReq.User.ID,
+	}, nil
+}
+
+func (g *GRPCEndpoint) GetUser(ctx context.Context, req *v1.GetUserRequest) (*v1.GetUserResponse, error) {
+	user, err := g.userSvc.GetUser(ctx, &core.GetUserRequest{
+		ID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.GetUserResponse{
+		User: parseUserToPB(user),
+	}, nil
+}
+
+func (g *GRPCEndpoint) ListUsers(ctx context.Context, req *v1.ListUsersRequest) (*v1.ListUsersResponse, error) {
+	page, err := g.userSvc.ListUsers(ctx, &core.ListUsersRequest{
+		IncludeDeactivated: req.GetIncludeDeactivated(),
+		PageSize:           int(req.GetPageSize()),
+		PageToken:          req.GetPageToken(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	users := make([]*v1.User, len(page.Users))
+	for i := range page.Users {
+		users[i] = parseUserToPB(&page.Users[i])
+	}
+
+	return &v1.ListUsersResponse{
+		Users:         users,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+func (g *GRPCEndpoint) DeactivateUser(ctx context.Context, req *v1.DeactivateUserRequest) (*emptypb.Empty, error) {
+	err := g.userSvc.DeactivateUser(ctx, &core.DeactivateUserRequest{
+		ActorID: extractAuthorization(ctx),
+		ID:      req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func parseCreateUserRequest(req *v1.CreateUserRequest) (*core.CreateUserRequest, error) {
+	if req == nil || req.GetUser() == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	return &core.CreateUserRequest{
+		User: &core.User{
+			Name:     req.GetUser().GetName(),
+			Timezone: req.GetUser().GetTimezone(),
+		},
+	}, nil
+}
+
+func parseUserToPB(user *core.User) *v1.User {
+	u := &v1.User{
+		Id:       user.ID,
+		Name:     user.Name,
+		Timezone: user.Timezone,
+	}
+	if user.DeactivatedAt != nil {
+		u.DeactivatedAt = user.DeactivatedAt.Format(time.RFC3339)
+	}
+	return u
+}
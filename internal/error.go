@@ -6,9 +6,14 @@ import (
 )
 
 var (
-	ErrInvalidRequest   = errors.New("invalid request")
-	ErrValidationFailed = errors.New("validation failed")
-	ErrInvalidTimezone  = errors.New("invalid timezone")
+	ErrInvalidRequest        = errors.New("invalid request")
+	ErrValidationFailed      = errors.New("validation failed")
+	ErrInvalidTimezone       = errors.New("invalid timezone")
+	ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+	ErrNotFound              = errors.New("not found")
+	ErrConflict              = errors.New("conflict")
+	ErrInvalidToken          = errors.New("invalid token")
+	ErrPermissionDenied      = errors.New("permission denied")
 )
 
 type Error struct {
@@ -23,6 +28,10 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.err.Error(), e.msg)
 }
 
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
 func WrapErr(err error, msg string) *Error {
 	return &Error{
 		err: err,
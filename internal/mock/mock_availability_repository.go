@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: AvailabilityRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAvailabilityRepository is a mock of AvailabilityRepository interface.
+type MockAvailabilityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvailabilityRepositoryMockRecorder
+}
+
+// MockAvailabilityRepositoryMockRecorder is the mock recorder for MockAvailabilityRepository.
+type MockAvailabilityRepositoryMockRecorder struct {
+	mock *MockAvailabilityRepository
+}
+
+// NewMockAvailabilityRepository creates a new mock instance.
+func NewMockAvailabilityRepository(ctrl *gomock.Controller) *MockAvailabilityRepository {
+	mock := &MockAvailabilityRepository{ctrl: ctrl}
+	mock.recorder = &MockAvailabilityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvailabilityRepository) EXPECT() *MockAvailabilityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUserIDs mocks base method.
+func (m *MockAvailabilityRepository) FindByUserIDs(arg0 context.Context, arg1 []int32) ([]core.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserIDs", arg0, arg1)
+	ret0, _ := ret[0].([]core.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserIDs indicates an expected call of FindByUserIDs.
+func (mr *MockAvailabilityRepositoryMockRecorder) FindByUserIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDs", reflect.TypeOf((*MockAvailabilityRepository)(nil).FindByUserIDs), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockAvailabilityRepository) Update(arg0 context.Context, arg1 *core.Availability) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAvailabilityRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAvailabilityRepository)(nil).Update), arg0, arg1)
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	core "github.com/dzakaammar/event-scheduling-example/internal/core"
 	gomock "github.com/golang/mock/gomock"
@@ -49,6 +50,21 @@ func (mr *MockEventRepositoryMockRecorder) DeleteByID(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockEventRepository)(nil).DeleteByID), arg0, arg1)
 }
 
+// FindByAttendees mocks base method.
+func (m *MockEventRepository) FindByAttendees(arg0 context.Context, arg1 []int32, arg2, arg3 time.Time) ([]core.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAttendees", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]core.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAttendees indicates an expected call of FindByAttendees.
+func (mr *MockEventRepositoryMockRecorder) FindByAttendees(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAttendees", reflect.TypeOf((*MockEventRepository)(nil).FindByAttendees), arg0, arg1, arg2, arg3)
+}
+
 // FindByID mocks base method.
 func (m *MockEventRepository) FindByID(arg0 context.Context, arg1 string) (*core.Event, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +80,35 @@ func (mr *MockEventRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockEventRepository)(nil).FindByID), arg0, arg1)
 }
 
+// FindByIDs mocks base method.
+func (m *MockEventRepository) FindByIDs(arg0 context.Context, arg1 []string) ([]core.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]core.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockEventRepositoryMockRecorder) FindByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockEventRepository)(nil).FindByIDs), arg0, arg1)
+}
+
+// SplitSeries mocks base method.
+func (m *MockEventRepository) SplitSeries(arg0 context.Context, arg1, arg2 *core.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitSeries", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SplitSeries indicates an expected call of SplitSeries.
+func (mr *MockEventRepositoryMockRecorder) SplitSeries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitSeries", reflect.TypeOf((*MockEventRepository)(nil).SplitSeries), arg0, arg1, arg2)
+}
+
 // Store mocks base method.
 func (m *MockEventRepository) Store(arg0 context.Context, arg1 *core.Event) error {
 	m.ctrl.T.Helper()
@@ -78,6 +123,20 @@ func (mr *MockEventRepositoryMockRecorder) Store(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockEventRepository)(nil).Store), arg0, arg1)
 }
 
+// StoreOccurrenceException mocks base method.
+func (m *MockEventRepository) StoreOccurrenceException(arg0 context.Context, arg1 *core.OccurrenceException) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreOccurrenceException", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreOccurrenceException indicates an expected call of StoreOccurrenceException.
+func (mr *MockEventRepositoryMockRecorder) StoreOccurrenceException(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreOccurrenceException", reflect.TypeOf((*MockEventRepository)(nil).StoreOccurrenceException), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockEventRepository) Update(arg0 context.Context, arg1 *core.Event) error {
 	m.ctrl.T.Helper()
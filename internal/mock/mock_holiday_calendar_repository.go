@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: HolidayCalendarRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHolidayCalendarRepository is a mock of HolidayCalendarRepository interface.
+type MockHolidayCalendarRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHolidayCalendarRepositoryMockRecorder
+}
+
+// MockHolidayCalendarRepositoryMockRecorder is the mock recorder for MockHolidayCalendarRepository.
+type MockHolidayCalendarRepositoryMockRecorder struct {
+	mock *MockHolidayCalendarRepository
+}
+
+// NewMockHolidayCalendarRepository creates a new mock instance.
+func NewMockHolidayCalendarRepository(ctrl *gomock.Controller) *MockHolidayCalendarRepository {
+	mock := &MockHolidayCalendarRepository{ctrl: ctrl}
+	mock.recorder = &MockHolidayCalendarRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHolidayCalendarRepository) EXPECT() *MockHolidayCalendarRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockHolidayCalendarRepository) FindByID(arg0 context.Context, arg1 string) (*core.HolidayCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.HolidayCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockHolidayCalendarRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockHolidayCalendarRepository)(nil).FindByID), arg0, arg1)
+}
+
+// Store mocks base method.
+func (m *MockHolidayCalendarRepository) Store(arg0 context.Context, arg1 *core.HolidayCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockHolidayCalendarRepositoryMockRecorder) Store(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockHolidayCalendarRepository)(nil).Store), arg0, arg1)
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: InvitationRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInvitationRepository is a mock of InvitationRepository interface.
+type MockInvitationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationRepositoryMockRecorder
+}
+
+// MockInvitationRepositoryMockRecorder is the mock recorder for MockInvitationRepository.
+type MockInvitationRepositoryMockRecorder struct {
+	mock *MockInvitationRepository
+}
+
+// NewMockInvitationRepository creates a new mock instance.
+func NewMockInvitationRepository(ctrl *gomock.Controller) *MockInvitationRepository {
+	mock := &MockInvitationRepository{ctrl: ctrl}
+	mock.recorder = &MockInvitationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationRepository) EXPECT() *MockInvitationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByFilter mocks base method.
+func (m *MockInvitationRepository) FindByFilter(arg0 context.Context, arg1 core.InvitationFilter) ([]core.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByFilter", arg0, arg1)
+	ret0, _ := ret[0].([]core.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByFilter indicates an expected call of FindByFilter.
+func (mr *MockInvitationRepositoryMockRecorder) FindByFilter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByFilter", reflect.TypeOf((*MockInvitationRepository)(nil).FindByFilter), arg0, arg1)
+}
+
+// FindByTokenHash mocks base method.
+func (m *MockInvitationRepository) FindByTokenHash(arg0 context.Context, arg1 string) (*core.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTokenHash", arg0, arg1)
+	ret0, _ := ret[0].(*core.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTokenHash indicates an expected call of FindByTokenHash.
+func (mr *MockInvitationRepositoryMockRecorder) FindByTokenHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTokenHash", reflect.TypeOf((*MockInvitationRepository)(nil).FindByTokenHash), arg0, arg1)
+}
+
+// Respond mocks base method.
+func (m *MockInvitationRepository) Respond(arg0 context.Context, arg1 string, arg2 func(*core.Event) ([]*core.Invitation, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Respond", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Respond indicates an expected call of Respond.
+func (mr *MockInvitationRepositoryMockRecorder) Respond(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Respond", reflect.TypeOf((*MockInvitationRepository)(nil).Respond), arg0, arg1, arg2)
+}
+
+// StoreOccurrenceResponse mocks base method.
+func (m *MockInvitationRepository) StoreOccurrenceResponse(arg0 context.Context, arg1 *core.OccurrenceResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreOccurrenceResponse", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreOccurrenceResponse indicates an expected call of StoreOccurrenceResponse.
+func (mr *MockInvitationRepositoryMockRecorder) StoreOccurrenceResponse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreOccurrenceResponse", reflect.TypeOf((*MockInvitationRepository)(nil).StoreOccurrenceResponse), arg0, arg1)
+}
+
+// SyncGroup mocks base method.
+func (m *MockInvitationRepository) SyncGroup(arg0 context.Context, arg1 []core.Invitation, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncGroup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncGroup indicates an expected call of SyncGroup.
+func (mr *MockInvitationRepositoryMockRecorder) SyncGroup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncGroup", reflect.TypeOf((*MockInvitationRepository)(nil).SyncGroup), arg0, arg1, arg2)
+}
+
+// UpdateTokenHash mocks base method.
+func (m *MockInvitationRepository) UpdateTokenHash(arg0 context.Context, arg1 *core.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTokenHash", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTokenHash indicates an expected call of UpdateTokenHash.
+func (mr *MockInvitationRepositoryMockRecorder) UpdateTokenHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTokenHash", reflect.TypeOf((*MockInvitationRepository)(nil).UpdateTokenHash), arg0, arg1)
+}
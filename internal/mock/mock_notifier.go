@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: Notifier)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyInvited mocks base method.
+func (m *MockNotifier) NotifyInvited(arg0 context.Context, arg1 *core.Event, arg2 *core.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyInvited", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyInvited indicates an expected call of NotifyInvited.
+func (mr *MockNotifierMockRecorder) NotifyInvited(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyInvited", reflect.TypeOf((*MockNotifier)(nil).NotifyInvited), arg0, arg1, arg2)
+}
+
+// NotifyPromoted mocks base method.
+func (m *MockNotifier) NotifyPromoted(arg0 context.Context, arg1 *core.Event, arg2 *core.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyPromoted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyPromoted indicates an expected call of NotifyPromoted.
+func (mr *MockNotifierMockRecorder) NotifyPromoted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPromoted", reflect.TypeOf((*MockNotifier)(nil).NotifyPromoted), arg0, arg1, arg2)
+}
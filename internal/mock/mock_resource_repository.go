@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: ResourceRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockResourceRepository is a mock of ResourceRepository interface.
+type MockResourceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceRepositoryMockRecorder
+}
+
+// MockResourceRepositoryMockRecorder is the mock recorder for MockResourceRepository.
+type MockResourceRepositoryMockRecorder struct {
+	mock *MockResourceRepository
+}
+
+// NewMockResourceRepository creates a new mock instance.
+func NewMockResourceRepository(ctrl *gomock.Controller) *MockResourceRepository {
+	mock := &MockResourceRepository{ctrl: ctrl}
+	mock.recorder = &MockResourceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceRepository) EXPECT() *MockResourceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockResourceRepository) FindAll(arg0 context.Context) ([]core.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]core.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockResourceRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockResourceRepository)(nil).FindAll), arg0)
+}
+
+// FindByID mocks base method.
+func (m *MockResourceRepository) FindByID(arg0 context.Context, arg1 string) (*core.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockResourceRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockResourceRepository)(nil).FindByID), arg0, arg1)
+}
+
+// Store mocks base method.
+func (m *MockResourceRepository) Store(arg0 context.Context, arg1 *core.Resource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockResourceRepositoryMockRecorder) Store(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockResourceRepository)(nil).Store), arg0, arg1)
+}
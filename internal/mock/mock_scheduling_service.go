@@ -35,20 +35,91 @@ func (m *MockSchedulingService) EXPECT() *MockSchedulingServiceMockRecorder {
 	return m.recorder
 }
 
-// CreateEvent mocks base method.
-func (m *MockSchedulingService) CreateEvent(arg0 context.Context, arg1 *core.CreateEventRequest) error {
+// AcceptProposal mocks base method.
+func (m *MockSchedulingService) AcceptProposal(arg0 context.Context, arg1 *core.AcceptProposalRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEvent", arg0, arg1)
+	ret := m.ctrl.Call(m, "AcceptProposal", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// AcceptProposal indicates an expected call of AcceptProposal.
+func (mr *MockSchedulingServiceMockRecorder) AcceptProposal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptProposal", reflect.TypeOf((*MockSchedulingService)(nil).AcceptProposal), arg0, arg1)
+}
+
+// CancelOccurrence mocks base method.
+func (m *MockSchedulingService) CancelOccurrence(arg0 context.Context, arg1 *core.CancelOccurrenceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOccurrence", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOccurrence indicates an expected call of CancelOccurrence.
+func (mr *MockSchedulingServiceMockRecorder) CancelOccurrence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOccurrence", reflect.TypeOf((*MockSchedulingService)(nil).CancelOccurrence), arg0, arg1)
+}
+
+// CreateEvent mocks base method.
+func (m *MockSchedulingService) CreateEvent(arg0 context.Context, arg1 *core.CreateEventRequest) ([]core.OutsideWorkingHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", arg0, arg1)
+	ret0, _ := ret[0].([]core.OutsideWorkingHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // CreateEvent indicates an expected call of CreateEvent.
 func (mr *MockSchedulingServiceMockRecorder) CreateEvent(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockSchedulingService)(nil).CreateEvent), arg0, arg1)
 }
 
+// CreateGroup mocks base method.
+func (m *MockSchedulingService) CreateGroup(arg0 context.Context, arg1 *core.CreateGroupRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockSchedulingServiceMockRecorder) CreateGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockSchedulingService)(nil).CreateGroup), arg0, arg1)
+}
+
+// CreateHolidayCalendar mocks base method.
+func (m *MockSchedulingService) CreateHolidayCalendar(arg0 context.Context, arg1 *core.CreateHolidayCalendarRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHolidayCalendar", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateHolidayCalendar indicates an expected call of CreateHolidayCalendar.
+func (mr *MockSchedulingServiceMockRecorder) CreateHolidayCalendar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHolidayCalendar", reflect.TypeOf((*MockSchedulingService)(nil).CreateHolidayCalendar), arg0, arg1)
+}
+
+// CreateResource mocks base method.
+func (m *MockSchedulingService) CreateResource(arg0 context.Context, arg1 *core.CreateResourceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResource", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateResource indicates an expected call of CreateResource.
+func (mr *MockSchedulingServiceMockRecorder) CreateResource(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResource", reflect.TypeOf((*MockSchedulingService)(nil).CreateResource), arg0, arg1)
+}
+
 // DeleteEventByID mocks base method.
 func (m *MockSchedulingService) DeleteEventByID(arg0 context.Context, arg1 *core.DeleteEventByIDRequest) error {
 	m.ctrl.T.Helper()
@@ -78,6 +149,200 @@ func (mr *MockSchedulingServiceMockRecorder) FindEventByID(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventByID", reflect.TypeOf((*MockSchedulingService)(nil).FindEventByID), arg0, arg1)
 }
 
+// FindGroupByID mocks base method.
+func (m *MockSchedulingService) FindGroupByID(arg0 context.Context, arg1 *core.FindGroupByIDRequest) (*core.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindGroupByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindGroupByID indicates an expected call of FindGroupByID.
+func (mr *MockSchedulingServiceMockRecorder) FindGroupByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindGroupByID", reflect.TypeOf((*MockSchedulingService)(nil).FindGroupByID), arg0, arg1)
+}
+
+// FindHolidayCalendarByID mocks base method.
+func (m *MockSchedulingService) FindHolidayCalendarByID(arg0 context.Context, arg1 *core.FindHolidayCalendarByIDRequest) (*core.HolidayCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHolidayCalendarByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.HolidayCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHolidayCalendarByID indicates an expected call of FindHolidayCalendarByID.
+func (mr *MockSchedulingServiceMockRecorder) FindHolidayCalendarByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHolidayCalendarByID", reflect.TypeOf((*MockSchedulingService)(nil).FindHolidayCalendarByID), arg0, arg1)
+}
+
+// FindResourceByID mocks base method.
+func (m *MockSchedulingService) FindResourceByID(arg0 context.Context, arg1 *core.FindResourceByIDRequest) (*core.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindResourceByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindResourceByID indicates an expected call of FindResourceByID.
+func (mr *MockSchedulingServiceMockRecorder) FindResourceByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindResourceByID", reflect.TypeOf((*MockSchedulingService)(nil).FindResourceByID), arg0, arg1)
+}
+
+// GetAvailability mocks base method.
+func (m *MockSchedulingService) GetAvailability(arg0 context.Context, arg1 *core.GetAvailabilityRequest) (*core.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailability", arg0, arg1)
+	ret0, _ := ret[0].(*core.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailability indicates an expected call of GetAvailability.
+func (mr *MockSchedulingServiceMockRecorder) GetAvailability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailability", reflect.TypeOf((*MockSchedulingService)(nil).GetAvailability), arg0, arg1)
+}
+
+// ListMyInvitations mocks base method.
+func (m *MockSchedulingService) ListMyInvitations(arg0 context.Context, arg1 *core.ListMyInvitationsRequest) (*core.InvitationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMyInvitations", arg0, arg1)
+	ret0, _ := ret[0].(*core.InvitationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMyInvitations indicates an expected call of ListMyInvitations.
+func (mr *MockSchedulingServiceMockRecorder) ListMyInvitations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMyInvitations", reflect.TypeOf((*MockSchedulingService)(nil).ListMyInvitations), arg0, arg1)
+}
+
+// ListOccurrences mocks base method.
+func (m *MockSchedulingService) ListOccurrences(arg0 context.Context, arg1 *core.ListOccurrencesRequest) (*core.OccurrenceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOccurrences", arg0, arg1)
+	ret0, _ := ret[0].(*core.OccurrenceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOccurrences indicates an expected call of ListOccurrences.
+func (mr *MockSchedulingServiceMockRecorder) ListOccurrences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOccurrences", reflect.TypeOf((*MockSchedulingService)(nil).ListOccurrences), arg0, arg1)
+}
+
+// ListProposals mocks base method.
+func (m *MockSchedulingService) ListProposals(arg0 context.Context, arg1 *core.ListProposalsRequest) ([]core.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProposals", arg0, arg1)
+	ret0, _ := ret[0].([]core.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProposals indicates an expected call of ListProposals.
+func (mr *MockSchedulingServiceMockRecorder) ListProposals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProposals", reflect.TypeOf((*MockSchedulingService)(nil).ListProposals), arg0, arg1)
+}
+
+// ListResources mocks base method.
+func (m *MockSchedulingService) ListResources(arg0 context.Context) ([]core.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResources", arg0)
+	ret0, _ := ret[0].([]core.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResources indicates an expected call of ListResources.
+func (mr *MockSchedulingServiceMockRecorder) ListResources(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResources", reflect.TypeOf((*MockSchedulingService)(nil).ListResources), arg0)
+}
+
+// QueryFreeBusy mocks base method.
+func (m *MockSchedulingService) QueryFreeBusy(arg0 context.Context, arg1 *core.QueryFreeBusyRequest) ([]core.FreeBusy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFreeBusy", arg0, arg1)
+	ret0, _ := ret[0].([]core.FreeBusy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFreeBusy indicates an expected call of QueryFreeBusy.
+func (mr *MockSchedulingServiceMockRecorder) QueryFreeBusy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFreeBusy", reflect.TypeOf((*MockSchedulingService)(nil).QueryFreeBusy), arg0, arg1)
+}
+
+// RegenerateInvitationToken mocks base method.
+func (m *MockSchedulingService) RegenerateInvitationToken(arg0 context.Context, arg1 *core.RegenerateInvitationTokenRequest) (*core.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateInvitationToken", arg0, arg1)
+	ret0, _ := ret[0].(*core.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateInvitationToken indicates an expected call of RegenerateInvitationToken.
+func (mr *MockSchedulingServiceMockRecorder) RegenerateInvitationToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateInvitationToken", reflect.TypeOf((*MockSchedulingService)(nil).RegenerateInvitationToken), arg0, arg1)
+}
+
+// RespondToInvitation mocks base method.
+func (m *MockSchedulingService) RespondToInvitation(arg0 context.Context, arg1 *core.RespondToInvitationRequest) (*core.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondToInvitation", arg0, arg1)
+	ret0, _ := ret[0].(*core.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RespondToInvitation indicates an expected call of RespondToInvitation.
+func (mr *MockSchedulingServiceMockRecorder) RespondToInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondToInvitation", reflect.TypeOf((*MockSchedulingService)(nil).RespondToInvitation), arg0, arg1)
+}
+
+// SuggestMeetingTimes mocks base method.
+func (m *MockSchedulingService) SuggestMeetingTimes(arg0 context.Context, arg1 *core.SuggestMeetingTimesRequest) ([]core.MeetingSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestMeetingTimes", arg0, arg1)
+	ret0, _ := ret[0].([]core.MeetingSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestMeetingTimes indicates an expected call of SuggestMeetingTimes.
+func (mr *MockSchedulingServiceMockRecorder) SuggestMeetingTimes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestMeetingTimes", reflect.TypeOf((*MockSchedulingService)(nil).SuggestMeetingTimes), arg0, arg1)
+}
+
+// UpdateAvailability mocks base method.
+func (m *MockSchedulingService) UpdateAvailability(arg0 context.Context, arg1 *core.UpdateAvailabilityRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvailability", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAvailability indicates an expected call of UpdateAvailability.
+func (mr *MockSchedulingServiceMockRecorder) UpdateAvailability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvailability", reflect.TypeOf((*MockSchedulingService)(nil).UpdateAvailability), arg0, arg1)
+}
+
 // UpdateEvent mocks base method.
 func (m *MockSchedulingService) UpdateEvent(arg0 context.Context, arg1 *core.UpdateEventRequest) error {
 	m.ctrl.T.Helper()
@@ -91,3 +356,31 @@ func (mr *MockSchedulingServiceMockRecorder) UpdateEvent(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockSchedulingService)(nil).UpdateEvent), arg0, arg1)
 }
+
+// UpdateGroupMembers mocks base method.
+func (m *MockSchedulingService) UpdateGroupMembers(arg0 context.Context, arg1 *core.UpdateGroupMembersRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupMembers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroupMembers indicates an expected call of UpdateGroupMembers.
+func (mr *MockSchedulingServiceMockRecorder) UpdateGroupMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupMembers", reflect.TypeOf((*MockSchedulingService)(nil).UpdateGroupMembers), arg0, arg1)
+}
+
+// UpdateOccurrence mocks base method.
+func (m *MockSchedulingService) UpdateOccurrence(arg0 context.Context, arg1 *core.UpdateOccurrenceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOccurrence", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOccurrence indicates an expected call of UpdateOccurrence.
+func (mr *MockSchedulingServiceMockRecorder) UpdateOccurrence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOccurrence", reflect.TypeOf((*MockSchedulingService)(nil).UpdateOccurrence), arg0, arg1)
+}
@@ -0,0 +1,100 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+const (
+	eventTypePromoted = "waitlist.promoted"
+	eventTypeInvited  = "invitation.issued"
+)
+
+// Webhook notifies the attendees by posting the notifications as JSON to an outside service, which
+// delivers them.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string, client *http.Client) *Webhook {
+	return &Webhook{
+		url:    url,
+		client: client,
+	}
+}
+
+type payload struct {
+	Type         string `json:"type"`
+	EventID      string `json:"event_id"`
+	InvitationID string `json:"invitation_id"`
+	UserID       int32  `json:"user_id,omitempty"`
+	GuestEmail   string `json:"guest_email,omitempty"`
+	// Token is the token of the invitation, only sent to the attendee.
+	Token string `json:"token,omitempty"`
+}
+
+func (w *Webhook) NotifyPromoted(ctx context.Context, event *core.Event, inv *core.Invitation) error {
+	return w.post(ctx, payloadOf(eventTypePromoted, event, inv))
+}
+
+func (w *Webhook) NotifyInvited(ctx context.Context, event *core.Event, inv *core.Invitation) error {
+	p := payloadOf(eventTypeInvited, event, inv)
+	p.Token = inv.Token
+	return w.post(ctx, p)
+}
+
+func payloadOf(eventType string, event *core.Event, inv *core.Invitation) payload {
+	p := payload{
+		Type:         eventType,
+		EventID:      event.ID,
+		InvitationID: inv.ID,
+		UserID:       inv.UserID,
+	}
+	if inv.Guest != nil {
+		p.UserID, p.GuestEmail = 0, inv.Guest.Email
+	}
+	return p
+}
+
+func (w *Webhook) post(ctx context.Context, p payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// Nop drops the notifications, when no webhook is configured.
+type Nop struct{}
+
+func (Nop) NotifyPromoted(context.Context, *core.Event, *core.Invitation) error {
+	return nil
+}
+
+func (Nop) NotifyInvited(context.Context, *core.Event, *core.Invitation) error {
+	return nil
+}
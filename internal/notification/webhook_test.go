@@ -0,0 +1,94 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_NotifyPromoted(t *testing.T) {
+	tests := []struct {
+		name       string
+		inv        *core.Invitation
+		statusCode int
+		wantBody   map[string]any
+		wantErr    bool
+	}{
+		{
+			name:       "OK - user",
+			inv:        &core.Invitation{ID: "inv1", UserID: 2},
+			statusCode: http.StatusNoContent,
+			wantBody: map[string]any{
+				"type":          "waitlist.promoted",
+				"event_id":      "123",
+				"invitation_id": "inv1",
+				"user_id":       float64(2),
+			},
+		},
+		{
+			name:       "OK - guest",
+			inv:        &core.Invitation{ID: "inv2", Guest: &core.Guest{Email: "guest@example.com"}},
+			statusCode: http.StatusOK,
+			wantBody: map[string]any{
+				"type":          "waitlist.promoted",
+				"event_id":      "123",
+				"invitation_id": "inv2",
+				"guest_email":   "guest@example.com",
+			},
+		},
+		{
+			name:       "Not OK - webhook failing",
+			inv:        &core.Invitation{ID: "inv1", UserID: 2},
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]any
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer srv.Close()
+
+			webhook := notification.NewWebhook(srv.URL, srv.Client())
+			err := webhook.NotifyPromoted(context.Background(), &core.Event{ID: "123"}, tt.inv)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestWebhook_NotifyInvited(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	webhook := notification.NewWebhook(srv.URL, srv.Client())
+	err := webhook.NotifyInvited(context.Background(), &core.Event{ID: "123"}, &core.Invitation{ID: "inv2", Guest: &core.Guest{Email: "guest@example.com"}, Token: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"type":          "invitation.issued",
+		"event_id":      "123",
+		"invitation_id": "inv2",
+		"guest_email":   "guest@example.com",
+		"token":         "token",
+	}, body)
+}
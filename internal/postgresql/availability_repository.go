@@ -0,0 +1,159 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type AvailabilityRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewAvailabilityRepository(dbConn *sqlx.DB) *AvailabilityRepository {
+	return &AvailabilityRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+type userAvailability struct {
+	ID                  int32  `db:"id"`
+	Timezone            string `db:"timezone"`
+	DefaultBufferBefore int64  `db:"default_buffer_before"`
+	DefaultBufferAfter  int64  `db:"default_buffer_after"`
+	// HolidayCalendarID is nil when the user has no holiday calendar.
+	HolidayCalendarID *string `db:"holiday_calendar_id"`
+}
+
+type holidayRow struct {
+	CalendarID string    `db:"calendar_id"`
+	Date       time.Time `db:"date"`
+	Name       string    `db:"name"`
+}
+
+type workingHoursRow struct {
+	UserID      int32 `db:"user_id"`
+	Weekday     int16 `db:"weekday"`
+	StartMinute int16 `db:"start_minute"`
+	EndMinute   int16 `db:"end_minute"`
+}
+
+func (a *AvailabilityRepository) FindByUserIDs(ctx context.Context, userIDs []int32) ([]core.Availability, error) {
+	var users []userAvailability
+	err := a.dbConn.SelectContext(ctx, &users, `SELECT id, timezone, default_buffer_before, default_buffer_after, holiday_calendar_id FROM "user" WHERE id = ANY($1) ORDER BY id`, userIDs)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	var workingHours []workingHoursRow
+	err = a.dbConn.SelectContext(ctx, &workingHours, `SELECT * FROM working_hours WHERE user_id = ANY($1) ORDER BY user_id, weekday`, userIDs)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	var calendarIDs []string
+	for _, user := range users {
+		if user.HolidayCalendarID != nil && !slices.Contains(calendarIDs, *user.HolidayCalendarID) {
+			calendarIDs = append(calendarIDs, *user.HolidayCalendarID)
+		}
+	}
+
+	var holidays []holidayRow
+	if len(calendarIDs) > 0 {
+		err = a.dbConn.SelectContext(ctx, &holidays, `SELECT * FROM holiday WHERE calendar_id = ANY($1) ORDER BY calendar_id, date`, calendarIDs)
+		if err != nil {
+			slog.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	availabilities := make([]core.Availability, len(users))
+	for index, user := range users {
+		availability := core.Availability{
+			UserID:   user.ID,
+			Timezone: user.Timezone,
+			DefaultBuffers: core.Buffers{
+				Before: time.Duration(user.DefaultBufferBefore) * time.Minute,
+				After:  time.Duration(user.DefaultBufferAfter) * time.Minute,
+			},
+			HolidayCalendarID: user.HolidayCalendarID,
+		}
+		for _, h := range holidays {
+			if user.HolidayCalendarID != nil && h.CalendarID == *user.HolidayCalendarID {
+				availability.Holidays = append(availability.Holidays, core.Holiday{Date: h.Date.UTC(), Name: h.Name})
+			}
+		}
+		for _, wh := range workingHours {
+			if wh.UserID != user.ID {
+				continue
+			}
+
+			// Weekdays sharing the same hours are grouped together.
+			i := slices.IndexFunc(availability.WorkingHours, func(w core.WorkingHours) bool {
+				return w.StartMinute == int(wh.StartMinute) && w.EndMinute == int(wh.EndMinute)
+			})
+			if i < 0 {
+				availability.WorkingHours = append(availability.WorkingHours, core.WorkingHours{
+					StartMinute: int(wh.StartMinute),
+					EndMinute:   int(wh.EndMinute),
+				})
+				i = len(availability.WorkingHours) - 1
+			}
+			availability.WorkingHours[i].Days = append(availability.WorkingHours[i].Days, time.Weekday(wh.Weekday))
+		}
+		availabilities[index] = availability
+	}
+	return availabilities, nil
+}
+
+func (a *AvailabilityRepository) Update(ctx context.Context, availability *core.Availability) error {
+	return inTx(ctx, a.dbConn, a.queries, func(q *gen.Queries) error {
+		rows, err := q.UpdateUserAvailability(ctx, gen.UpdateUserAvailabilityParams{
+			ID:                  availability.UserID,
+			Timezone:            availability.Timezone,
+			DefaultBufferBefore: int64(availability.DefaultBuffers.Before / time.Minute),
+			DefaultBufferAfter:  int64(availability.DefaultBuffers.After / time.Minute),
+			HolidayCalendarID:   nullString(availability.HolidayCalendarID),
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+		if rows == 0 {
+			return internal.WrapErr(internal.ErrNotFound, fmt.Sprintf("user %d", availability.UserID))
+		}
+
+		err = q.DeleteWorkingHoursByUserID(ctx, availability.UserID)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		for _, wh := range availability.WorkingHours {
+			for _, day := range wh.Days {
+				err = q.CreateWorkingHours(ctx, gen.CreateWorkingHoursParams{
+					UserID:      availability.UserID,
+					Weekday:     int16(day),
+					StartMinute: int16(wh.StartMinute), //nolint:gosec
+					EndMinute:   int16(wh.EndMinute),   //nolint:gosec
+				})
+				if err != nil {
+					slog.Error(err.Error())
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,107 @@
+package postgresql_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailabilityRepository_FindByUserIDs(t *testing.T) {
+	holidayCalendarID := "id-holidays"
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+		mock.ExpectQuery(`SELECT id, timezone, default_buffer_before, default_buffer_after, holiday_calendar_id FROM "user"`).WithArgs([]int32{1, 2}).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "timezone", "default_buffer_before", "default_buffer_after", "holiday_calendar_id"}).
+				AddRow(1, "Asia/Jakarta", 0, 0, "id-holidays").
+				AddRow(2, "UTC", 10, 5, nil),
+		)
+		mock.ExpectQuery(`SELECT \* FROM working_hours`).WithArgs([]int32{1, 2}).WillReturnRows(
+			sqlmock.NewRows([]string{"user_id", "weekday", "start_minute", "end_minute"}).
+				AddRow(1, 1, 540, 1020).
+				AddRow(1, 2, 540, 1020).
+				AddRow(1, 5, 480, 720),
+		)
+		mock.ExpectQuery(`SELECT \* FROM holiday`).WithArgs([]string{"id-holidays"}).WillReturnRows(
+			sqlmock.NewRows([]string{"calendar_id", "date", "name"}).
+				AddRow("id-holidays", time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), "New Year's Day"),
+		)
+
+		a := postgresql.NewAvailabilityRepository(sqlx.NewDb(db, "pgx"))
+		got, err := a.FindByUserIDs(t.Context(), []int32{1, 2})
+		require.NoError(t, err)
+		assert.Equal(t, []core.Availability{
+			{
+				UserID:   1,
+				Timezone: "Asia/Jakarta",
+				WorkingHours: []core.WorkingHours{
+					{Days: []time.Weekday{time.Monday, time.Tuesday}, StartMinute: 540, EndMinute: 1020},
+					{Days: []time.Weekday{time.Friday}, StartMinute: 480, EndMinute: 720},
+				},
+				HolidayCalendarID: &holidayCalendarID,
+				Holidays: []core.Holiday{
+					{Date: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), Name: "New Year's Day"},
+				},
+			},
+			{UserID: 2, Timezone: "UTC", DefaultBuffers: core.Buffers{Before: 10 * time.Minute, After: 5 * time.Minute}},
+		}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+		mock.ExpectQuery(`SELECT id, timezone, default_buffer_before, default_buffer_after, holiday_calendar_id FROM "user"`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+		a := postgresql.NewAvailabilityRepository(sqlx.NewDb(db, "pgx"))
+		_, err := a.FindByUserIDs(t.Context(), []int32{1})
+		assert.Error(t, err)
+	})
+}
+
+func TestAvailabilityRepository_Update(t *testing.T) {
+	availability := &core.Availability{
+		UserID:   1,
+		Timezone: "Asia/Jakarta",
+		WorkingHours: []core.WorkingHours{
+			{Days: []time.Weekday{time.Monday, time.Tuesday}, StartMinute: 540, EndMinute: 1020},
+		},
+		DefaultBuffers: core.Buffers{After: 15 * time.Minute},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "user"`).WithArgs(int32(1), "Asia/Jakarta", int64(0), int64(15), nil).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM working_hours`).WithArgs(int32(1)).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`INSERT INTO working_hours`).WithArgs(int32(1), int16(1), int16(540), int16(1020)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO working_hours`).WithArgs(int32(1), int16(2), int16(540), int16(1020)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		a := postgresql.NewAvailabilityRepository(sqlx.NewDb(db, "pgx"))
+		err := a.Update(t.Context(), availability)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - unknown user", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "user"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		a := postgresql.NewAvailabilityRepository(sqlx.NewDb(db, "pgx"))
+		err := a.Update(t.Context(), availability)
+		assert.ErrorIs(t, err, internal.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -5,13 +5,22 @@ import (
 	"database/sql"
 	"errors"
 	"log/slog"
+	"slices"
 	"time"
 
+	"github.com/dzakaammar/event-scheduling-example/internal"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 )
 
+// PostgreSQL error codes of the constraint violations.
+const (
+	foreignKeyViolation = "23503"
+	exclusionViolation  = "23P01"
+)
+
 type EventRepository struct {
 	dbConn  *sqlx.DB
 	queries *gen.Queries
@@ -24,8 +33,35 @@ func NewEventRepository(dbConn *sqlx.DB) *EventRepository {
 	}
 }
 
-func (e *EventRepository) Store(ctx context.Context, event *core.Event) error { //nolint:funlen,gocognit
-	tx, err := e.dbConn.BeginTx(ctx, &sql.TxOptions{})
+func (e *EventRepository) Store(ctx context.Context, event *core.Event) error {
+	return inTx(ctx, e.dbConn, e.queries, func(q *gen.Queries) error {
+		return storeEvent(ctx, q, event)
+	})
+}
+
+func (e *EventRepository) DeleteByID(ctx context.Context, id string) error {
+	return e.queries.DeleteEvent(ctx, id)
+}
+
+func (e *EventRepository) Update(ctx context.Context, event *core.Event) error {
+	return inTx(ctx, e.dbConn, e.queries, func(q *gen.Queries) error {
+		return updateEvent(ctx, q, event)
+	})
+}
+
+// SplitSeries updates the event and stores the series following it within a single transaction.
+func (e *EventRepository) SplitSeries(ctx context.Context, event *core.Event, following *core.Event) error {
+	return inTx(ctx, e.dbConn, e.queries, func(q *gen.Queries) error {
+		err := updateEvent(ctx, q, event)
+		if err != nil {
+			return err
+		}
+		return storeEvent(ctx, q, following)
+	})
+}
+
+func inTx(ctx context.Context, dbConn *sqlx.DB, queries *gen.Queries, fn func(q *gen.Queries) error) error {
+	tx, err := dbConn.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		slog.Error(err.Error())
 		return err
@@ -37,14 +73,32 @@ func (e *EventRepository) Store(ctx context.Context, event *core.Event) error {
 		}
 	}()
 
-	err = e.queries.WithTx(tx).CreateEvent(ctx, gen.CreateEventParams{
-		ID:          event.ID,
-		Title:       event.Title,
-		Description: event.Description,
-		Timezone:    event.Timezone,
-		CreatedBy:   event.CreatedBy,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   sql.NullTime{Time: time.Now()},
+	err = fn(queries.WithTx(tx))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func storeEvent(ctx context.Context, q *gen.Queries, event *core.Event) error {
+	var parentEventID sql.NullString
+	if event.ParentEventID != nil {
+		parentEventID = sql.NullString{String: *event.ParentEventID, Valid: true}
+	}
+
+	err := q.CreateEvent(ctx, gen.CreateEventParams{
+		ID:                event.ID,
+		Title:             event.Title,
+		Description:       event.Description,
+		Timezone:          event.Timezone,
+		CreatedBy:         event.CreatedBy,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         sql.NullTime{Time: time.Now()},
+		ParentEventID:     parentEventID,
+		HolidayCalendarID: nullString(event.HolidayCalendarID),
+		SkipHolidays:      event.SkipHolidays,
+		MaxAttendees:      event.MaxAttendees,
 	})
 	if err != nil {
 		slog.Error(err.Error())
@@ -52,7 +106,7 @@ func (e *EventRepository) Store(ctx context.Context, event *core.Event) error {
 	}
 
 	for _, schedule := range event.Schedules {
-		err = e.queries.WithTx(tx).CreateSchedule(ctx, gen.CreateScheduleParams{
+		err = q.CreateSchedule(ctx, gen.CreateScheduleParams{
 			ID:                schedule.ID,
 			EventID:           event.ID,
 			StartTime:         schedule.StartTime,
@@ -60,59 +114,71 @@ func (e *EventRepository) Store(ctx context.Context, event *core.Event) error {
 			IsFullDay:         schedule.IsFullDay,
 			RecurringInterval: schedule.RecurringInterval,
 			RecurringType:     string(schedule.RecurringType),
+			RecurrenceRule:    schedule.RecurrenceRule.String(),
+			Until:             schedule.Until,
+			Count:             schedule.Count,
+			BufferBefore:      nullInt64(schedule.BufferBeforeInMinutes),
+			BufferAfter:       nullInt64(schedule.BufferAfterInMinutes),
 		})
 		if err != nil {
 			slog.Error(err.Error())
 			return err
 		}
+
+		err = storeOccurrenceExceptions(ctx, q, &schedule)
+		if err != nil {
+			return err
+		}
 	}
 
 	for _, invitation := range event.Invitations {
-		err = e.queries.WithTx(tx).CreateInvitation(ctx, gen.CreateInvitationParams{
-			ID:      invitation.ID,
-			EventID: invitation.EventID,
-			UserID:  int32(invitation.UserID),
-			Token:   invitation.Token,
-			Status:  int16(invitation.Status), //nolint:gosec
-		})
+		err = createInvitation(ctx, q, &invitation)
 		if err != nil {
-			slog.Error(err.Error())
 			return err
 		}
 	}
 
-	return tx.Commit()
-}
+	err = storeEventGroups(ctx, q, event)
+	if err != nil {
+		return err
+	}
 
-func (e *EventRepository) DeleteByID(ctx context.Context, id string) error {
-	return e.queries.DeleteEvent(ctx, id)
+	err = storeOccurrenceResponses(ctx, q, event)
+	if err != nil {
+		return err
+	}
+
+	return storeReservations(ctx, q, event)
 }
 
-func (e *EventRepository) Update(ctx context.Context, event *core.Event) error { //nolint:gocognit
-	tx, err := e.dbConn.BeginTx(ctx, &sql.TxOptions{})
+func updateEvent(ctx context.Context, q *gen.Queries, event *core.Event) error {
+	var updatedAt sql.NullTime
+	if event.UpdatedAt != nil {
+		updatedAt = sql.NullTime{Time: *event.UpdatedAt, Valid: true}
+	}
+
+	err := q.UpdateEvent(ctx, gen.UpdateEventParams{
+		ID:                event.ID,
+		Title:             event.Title,
+		Description:       event.Description,
+		Timezone:          event.Timezone,
+		UpdatedAt:         updatedAt,
+		HolidayCalendarID: nullString(event.HolidayCalendarID),
+		SkipHolidays:      event.SkipHolidays,
+		MaxAttendees:      event.MaxAttendees,
+	})
 	if err != nil {
 		slog.Error(err.Error())
 		return err
 	}
-	defer func() {
-		rollbackErr := tx.Rollback()
-		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
-			slog.Error(rollbackErr.Error())
-		}
-	}()
 
-	err = e.queries.WithTx(tx).UpdateEvent(ctx, gen.UpdateEventParams{
-		Title:       event.Title,
-		Description: event.Description,
-		Timezone:    event.Timezone,
-	})
+	err = deleteRemoved(ctx, q, event)
 	if err != nil {
-		slog.Error(err.Error())
 		return err
 	}
 
 	for _, schedule := range event.Schedules {
-		err = e.queries.WithTx(tx).UpsertSchedule(ctx, gen.UpsertScheduleParams{
+		err = q.UpsertSchedule(ctx, gen.UpsertScheduleParams{
 			ID:                schedule.ID,
 			EventID:           event.ID,
 			StartTime:         schedule.StartTime,
@@ -120,44 +186,265 @@ func (e *EventRepository) Update(ctx context.Context, event *core.Event) error {
 			IsFullDay:         schedule.IsFullDay,
 			RecurringInterval: schedule.RecurringInterval,
 			RecurringType:     string(schedule.RecurringType),
+			RecurrenceRule:    schedule.RecurrenceRule.String(),
+			Until:             schedule.Until,
+			Count:             schedule.Count,
+			BufferBefore:      nullInt64(schedule.BufferBeforeInMinutes),
+			BufferAfter:       nullInt64(schedule.BufferAfterInMinutes),
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		// The exceptions of the schedule are replaced as a whole, dropping the ones the
+		// schedule doesn't hold anymore.
+		err = q.DeleteOccurrenceExceptionsByScheduleID(ctx, schedule.ID)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		err = storeOccurrenceExceptions(ctx, q, &schedule)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, invitation := range event.Invitations {
+		scheduleID, start, end := proposalOf(invitation.Proposal)
+		guestEmail, guestName := guestOf(invitation.Guest)
+		err = q.UpsertInvitation(ctx, gen.UpsertInvitationParams{
+			ID:                 invitation.ID,
+			EventID:            event.ID,
+			UserID:             userIDOf(&invitation),
+			TokenHash:          invitation.TokenHash,
+			Status:             int16(invitation.Status), //nolint:gosec
+			Comment:            invitation.Comment,
+			UpdatedAt:          nullTime(invitation.UpdatedAt),
+			ProposedScheduleID: scheduleID,
+			ProposedStartTime:  start,
+			ProposedEndTime:    end,
+			GuestEmail:         guestEmail,
+			GuestName:          guestName,
+			Role:               int16(invitation.Role), //nolint:gosec
+			CanModifyEvent:     invitation.Permissions.CanModifyEvent,
+			CanInviteOthers:    invitation.Permissions.CanInviteOthers,
+			CanSeeGuestList:    invitation.Permissions.CanSeeGuestList,
+			WaitlistedAt:       nullTime(invitation.WaitlistedAt),
+			GroupID:            nullString(invitation.GroupID),
 		})
 		if err != nil {
 			slog.Error(err.Error())
 			return err
 		}
+
+		// The responses to the occurrences are replaced as a whole, dropping the ones the
+		// invitation doesn't hold anymore.
+		err = q.DeleteOccurrenceResponsesByInvitationID(ctx, invitation.ID)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+	}
+
+	err = storeOccurrenceResponses(ctx, q, event)
+	if err != nil {
+		return err
+	}
+
+	err = q.DeleteEventGroups(ctx, event.ID)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	err = storeEventGroups(ctx, q, event)
+	if err != nil {
+		return err
+	}
+
+	// The reservations follow the new schedules, dropping them releases the resources first.
+	err = q.DeleteEventResources(ctx, event.ID)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	return storeReservations(ctx, q, event)
+}
+
+// deleteRemoved deletes the schedules and the invitations the event doesn't hold anymore, along
+// with their exceptions, reservations and responses.
+func deleteRemoved(ctx context.Context, q *gen.Queries, event *core.Event) error {
+	scheduleIDs, err := q.FindScheduleIDsByEventID(ctx, event.ID)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	for _, id := range scheduleIDs {
+		if slices.ContainsFunc(event.Schedules, func(s core.Schedule) bool { return s.ID == id }) {
+			continue
+		}
+		err = q.DeleteSchedule(ctx, id)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
 	}
 
+	invitationIDs, err := q.FindInvitationIDsByEventID(ctx, event.ID)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	for _, id := range invitationIDs {
+		if slices.ContainsFunc(event.Invitations, func(i core.Invitation) bool { return i.ID == id }) {
+			continue
+		}
+		err = q.DeleteInvitation(ctx, id)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+func storeOccurrenceExceptions(ctx context.Context, q *gen.Queries, schedule *core.Schedule) error {
+	for _, exc := range schedule.Exceptions {
+		err := q.UpsertOccurrenceException(ctx, gen.UpsertOccurrenceExceptionParams{
+			ScheduleID:    schedule.ID,
+			OriginalStart: exc.OriginalStart,
+			IsCancelled:   exc.IsCancelled,
+			StartTime:     exc.StartTime,
+			Duration:      exc.DurationInMinutes,
+			Title:         exc.Title,
+			Description:   exc.Description,
+			UpdatedAt:     exc.UpdatedAt,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+func storeOccurrenceResponses(ctx context.Context, q *gen.Queries, event *core.Event) error {
 	for _, invitation := range event.Invitations {
-		err = e.queries.WithTx(tx).UpsertInvitation(ctx, gen.UpsertInvitationParams{
-			ID:      invitation.ID,
+		for _, resp := range invitation.OccurrenceResponses {
+			resp.InvitationID = invitation.ID
+			err := storeOccurrenceResponse(ctx, q, &resp)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func storeEventGroups(ctx context.Context, q *gen.Queries, event *core.Event) error {
+	for _, g := range event.Groups {
+		err := q.CreateEventGroup(ctx, gen.CreateEventGroupParams{
 			EventID: event.ID,
-			UserID:  invitation.UserID,
-			Token:   invitation.Token,
-			Status:  int16(invitation.Status), //nolint:gosec
+			GroupID: g.GroupID,
+			Sync:    g.Sync,
 		})
 		if err != nil {
 			slog.Error(err.Error())
 			return err
 		}
 	}
+	return nil
+}
 
-	return tx.Commit()
+// storeReservations reserves the resources of the event for its occurrences within the
+// reservation window. The database rejects a reservation overlapping another one of the same
+// resource, even from a concurrent transaction.
+func storeReservations(ctx context.Context, q *gen.Queries, event *core.Event) error {
+	for _, resourceID := range event.ResourceIDs {
+		err := q.CreateEventResource(ctx, gen.CreateEventResourceParams{
+			EventID:    event.ID,
+			ResourceID: resourceID,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return reservationErr(err, resourceID)
+		}
+	}
+
+	reservations, err := event.Reservations(event.ReservationWindow(time.Now()))
+	if err != nil {
+		return err
+	}
+
+	for _, r := range reservations {
+		err = q.CreateReservation(ctx, gen.CreateReservationParams{
+			ResourceID:    r.ResourceID,
+			EventID:       r.EventID,
+			ScheduleID:    r.ScheduleID,
+			OriginalStart: r.OriginalStart.Unix(),
+			StartTime:     r.Start.Unix(),
+			EndTime:       r.End.Unix(),
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return reservationErr(err, r.ResourceID)
+		}
+	}
+
+	return nil
+}
+
+func nullString(v *string) sql.NullString {
+	if v == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *v, Valid: true}
+}
+
+func nullInt64(v *int64) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *v, Valid: true}
+}
+
+// reservationErr turns the violations of the reservation constraints into domain errors.
+func reservationErr(err error, resourceID string) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case exclusionViolation:
+		return &core.ConflictError{ReservedResources: []string{resourceID}}
+	case foreignKeyViolation:
+		return internal.WrapErr(internal.ErrValidationFailed, "unknown resource "+resourceID)
+	default:
+		return err
+	}
 }
 
 func (e *EventRepository) FindByID(ctx context.Context, id string) (*core.Event, error) {
 	queryEvent, err := e.queries.FindEventByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, internal.WrapErr(internal.ErrNotFound, "event "+id)
+	}
 	if err != nil {
 		slog.Error(err.Error())
 		return nil, err
 	}
-	event := core.Event{
-		ID:          queryEvent.ID,
-		Title:       queryEvent.Title,
-		Description: queryEvent.Description,
-		Timezone:    queryEvent.Timezone,
-		CreatedBy:   queryEvent.CreatedBy,
-		CreatedAt:   queryEvent.CreatedAt,
-		UpdatedAt:   &queryEvent.UpdatedAt.Time,
+	event := eventOf(queryEvent)
+	if queryEvent.HolidayCalendarID.Valid {
+		holidays, err := e.queries.FindHolidaysByCalendarID(ctx, queryEvent.HolidayCalendarID.String)
+		if err != nil {
+			slog.Error(err.Error())
+			return nil, err
+		}
+		event.Holidays = holidaysOf(holidays)
 	}
 
 	var schedules []core.Schedule
@@ -166,15 +453,313 @@ func (e *EventRepository) FindByID(ctx context.Context, id string) (*core.Event,
 		slog.Error(err.Error())
 		return nil, err
 	}
+
+	var exceptions []core.OccurrenceException
+	err = e.dbConn.SelectContext(ctx, &exceptions, `SELECT oe.* FROM occurrence_exception oe JOIN schedule s ON s.id = oe.schedule_id WHERE s.event_id = $1`, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	for _, exc := range exceptions {
+		for i := range schedules {
+			if schedules[i].ID == exc.ScheduleID {
+				schedules[i].Exceptions = append(schedules[i].Exceptions, exc)
+			}
+		}
+	}
 	event.Schedules = schedules
 
-	var invitations []core.Invitation
-	err = e.dbConn.SelectContext(ctx, &invitations, `SELECT * FROM invitation WHERE event_id = $1`, id)
+	invitations, err := e.queries.FindInvitationsByEventID(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	for _, inv := range invitations {
+		event.Invitations = append(event.Invitations, *invitationOf(inv))
+	}
+
+	responses, err := e.queries.FindOccurrenceResponsesByEventID(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	for _, resp := range responses {
+		for i := range event.Invitations {
+			if event.Invitations[i].ID == resp.InvitationID {
+				event.Invitations[i].OccurrenceResponses = append(event.Invitations[i].OccurrenceResponses, occurrenceResponseOf(resp))
+			}
+		}
+	}
+
+	resourceIDs, err := e.queries.FindResourceIDsByEventID(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	event.ResourceIDs = resourceIDs
+
+	groups, err := e.queries.FindEventGroupsByEventID(ctx, id)
 	if err != nil {
 		slog.Error(err.Error())
 		return nil, err
 	}
-	event.Invitations = invitations
+	for _, g := range groups {
+		event.Groups = append(event.Groups, core.EventGroup{GroupID: g.GroupID, Sync: g.Sync})
+	}
 
 	return &event, err
 }
+
+// FindByIDs returns the events of the IDs, in the same order, loading all of them at once. The
+// unknown IDs are skipped.
+func (e *EventRepository) FindByIDs(ctx context.Context, ids []string) ([]core.Event, error) {
+	var eventRows []eventRow
+	err := e.dbConn.SelectContext(ctx, &eventRows, `SELECT * FROM event WHERE id = ANY($1)`, ids)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	var calendarIDs []string
+	for _, row := range eventRows {
+		if row.HolidayCalendarID.Valid && !slices.Contains(calendarIDs, row.HolidayCalendarID.String) {
+			calendarIDs = append(calendarIDs, row.HolidayCalendarID.String)
+		}
+	}
+
+	var holidays []holidayRow
+	if len(calendarIDs) > 0 {
+		err = e.dbConn.SelectContext(ctx, &holidays, `SELECT * FROM holiday WHERE calendar_id = ANY($1) ORDER BY calendar_id, date`, calendarIDs)
+		if err != nil {
+			slog.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	var schedules []core.Schedule
+	err = e.dbConn.SelectContext(ctx, &schedules, `SELECT * FROM schedule WHERE event_id = ANY($1)`, ids)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	var exceptions []core.OccurrenceException
+	err = e.dbConn.SelectContext(ctx, &exceptions, `SELECT oe.* FROM occurrence_exception oe JOIN schedule s ON s.id = oe.schedule_id WHERE s.event_id = ANY($1)`, ids)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	var invitations []invitationRow
+	err = e.dbConn.SelectContext(ctx, &invitations, `SELECT id, event_id, user_id, token_hash, status, updated_at, comment,
+			proposed_schedule_id, proposed_start_time, proposed_end_time, guest_email, guest_name,
+			role, can_modify_event, can_invite_others, can_see_guest_list, waitlisted_at, group_id
+		FROM invitation WHERE event_id = ANY($1)`, ids)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	var responses []occurrenceResponseRow
+	err = e.dbConn.SelectContext(ctx, &responses, `SELECT r.* FROM occurrence_response r JOIN invitation i ON i.id = r.invitation_id WHERE i.event_id = ANY($1)`, ids)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	var resources []eventResourceRow
+	err = e.dbConn.SelectContext(ctx, &resources, `SELECT event_id, resource_id FROM event_resource WHERE event_id = ANY($1) ORDER BY resource_id`, ids)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	var groups []eventGroupRow
+	err = e.dbConn.SelectContext(ctx, &groups, `SELECT event_id, group_id, sync FROM event_group WHERE event_id = ANY($1) ORDER BY group_id`, ids)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	events := make([]core.Event, 0, len(eventRows))
+	for _, id := range ids {
+		i := slices.IndexFunc(eventRows, func(row eventRow) bool { return row.ID == id })
+		if i < 0 {
+			continue
+		}
+
+		event := eventOf(gen.Event(eventRows[i]))
+		for _, h := range holidays {
+			if event.HolidayCalendarID != nil && h.CalendarID == *event.HolidayCalendarID {
+				event.Holidays = append(event.Holidays, core.Holiday{Date: h.Date.UTC(), Name: h.Name})
+			}
+		}
+		for _, sch := range schedules {
+			if sch.EventID != id {
+				continue
+			}
+			for _, exc := range exceptions {
+				if exc.ScheduleID == sch.ID {
+					sch.Exceptions = append(sch.Exceptions, exc)
+				}
+			}
+			event.Schedules = append(event.Schedules, sch)
+		}
+		for _, row := range invitations {
+			if row.EventID != id {
+				continue
+			}
+			inv := invitationOf(gen.Invitation(row))
+			for _, resp := range responses {
+				if resp.InvitationID == inv.ID {
+					inv.OccurrenceResponses = append(inv.OccurrenceResponses, occurrenceResponseOf(gen.OccurrenceResponse(resp)))
+				}
+			}
+			event.Invitations = append(event.Invitations, *inv)
+		}
+		for _, r := range resources {
+			if r.EventID == id {
+				event.ResourceIDs = append(event.ResourceIDs, r.ResourceID)
+			}
+		}
+		for _, g := range groups {
+			if g.EventID == id {
+				event.Groups = append(event.Groups, core.EventGroup{GroupID: g.GroupID, Sync: g.Sync})
+			}
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+type eventRow struct {
+	ID                string         `db:"id"`
+	Title             string         `db:"title"`
+	Description       string         `db:"description"`
+	Timezone          string         `db:"timezone"`
+	CreatedBy         string         `db:"created_by"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         sql.NullTime   `db:"updated_at"`
+	ParentEventID     sql.NullString `db:"parent_event_id"`
+	HolidayCalendarID sql.NullString `db:"holiday_calendar_id"`
+	SkipHolidays      bool           `db:"skip_holidays"`
+	MaxAttendees      int32          `db:"max_attendees"`
+}
+
+type occurrenceResponseRow struct {
+	InvitationID  string    `db:"invitation_id"`
+	ScheduleID    string    `db:"schedule_id"`
+	OriginalStart int64     `db:"original_start"`
+	Status        int16     `db:"status"`
+	Comment       string    `db:"comment"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+type eventResourceRow struct {
+	EventID    string `db:"event_id"`
+	ResourceID string `db:"resource_id"`
+}
+
+type eventGroupRow struct {
+	EventID string `db:"event_id"`
+	GroupID string `db:"group_id"`
+	Sync    bool   `db:"sync"`
+}
+
+// eventOf returns the event of the row, without its schedules, invitations and other details.
+func eventOf(row gen.Event) core.Event {
+	event := core.Event{
+		ID:           row.ID,
+		Title:        row.Title,
+		Description:  row.Description,
+		Timezone:     row.Timezone,
+		CreatedBy:    row.CreatedBy,
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    &row.UpdatedAt.Time,
+		SkipHolidays: row.SkipHolidays,
+		MaxAttendees: row.MaxAttendees,
+	}
+	if row.ParentEventID.Valid {
+		event.ParentEventID = &row.ParentEventID.String
+	}
+	if row.HolidayCalendarID.Valid {
+		event.HolidayCalendarID = &row.HolidayCalendarID.String
+	}
+	return event
+}
+
+func (e *EventRepository) FindByAttendees(ctx context.Context, userIDs []int32, from, to time.Time) ([]core.Event, error) {
+	var ids []string
+	err := e.dbConn.SelectContext(ctx, &ids, `SELECT DISTINCT i.event_id FROM invitation i
+		JOIN schedule s ON s.event_id = i.event_id
+		WHERE i.user_id = ANY($1) AND s.start_time < $3 AND (
+			i.status <> $2 OR EXISTS (SELECT 1 FROM occurrence_response r WHERE r.invitation_id = i.id AND r.status <> $2)
+		) AND (
+			(s.recurrence_rule = '' AND s.start_time + s.duration * 60 > $4) OR
+			(s.recurrence_rule <> '' AND (s.until = 0 OR s.until + s.duration * 60 > $4))
+		)`, userIDs, int16(core.InvitationStatus_Declined), to.Unix(), from.Unix())
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	return e.FindByIDs(ctx, ids)
+}
+
+// StoreOccurrenceException stores the exception and moves the reservations of the occurrence
+// along, or releases them when the occurrence is cancelled.
+func (e *EventRepository) StoreOccurrenceException(ctx context.Context, exc *core.OccurrenceException) error {
+	return inTx(ctx, e.dbConn, e.queries, func(q *gen.Queries) error {
+		err := q.UpsertOccurrenceException(ctx, gen.UpsertOccurrenceExceptionParams{
+			ScheduleID:    exc.ScheduleID,
+			OriginalStart: exc.OriginalStart,
+			IsCancelled:   exc.IsCancelled,
+			StartTime:     exc.StartTime,
+			Duration:      exc.DurationInMinutes,
+			Title:         exc.Title,
+			Description:   exc.Description,
+			UpdatedAt:     exc.UpdatedAt,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		if exc.IsCancelled {
+			err = q.DeleteOccurrenceReservations(ctx, gen.DeleteOccurrenceReservationsParams{
+				ScheduleID:    exc.ScheduleID,
+				OriginalStart: exc.OriginalStart,
+			})
+			if err != nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		}
+
+		resourceIDs, err := q.FindResourceIDsByScheduleID(ctx, exc.ScheduleID)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		start := exc.StartTime
+		if start == 0 {
+			start = exc.OriginalStart
+		}
+		for _, resourceID := range resourceIDs {
+			err = q.UpsertOccurrenceReservation(ctx, gen.UpsertOccurrenceReservationParams{
+				ResourceID:    resourceID,
+				ScheduleID:    exc.ScheduleID,
+				OriginalStart: exc.OriginalStart,
+				StartTime:     start,
+				Duration:      exc.DurationInMinutes,
+			})
+			if err != nil {
+				slog.Error(err.Error())
+				return reservationErr(err, resourceID)
+			}
+		}
+		return nil
+	})
+}
@@ -2,13 +2,17 @@ package postgresql_test
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -201,8 +205,25 @@ func TestEventRepository_Update(t *testing.T) {
 
 					mock.ExpectBegin()
 					mock.ExpectExec(`UPDATE event SET`).WillReturnResult(sqlmock.NewResult(1, 1))
+					// The schedule and the invitation removed from the event are deleted.
+					mock.ExpectQuery(`SELECT id FROM schedule`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("123").AddRow("removed"))
+					mock.ExpectExec(`DELETE FROM schedule`).WithArgs("removed").WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectQuery(`SELECT id FROM invitation`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("removed").AddRow("123"))
+					mock.ExpectExec(`DELETE FROM invitation`).WithArgs("removed").WillReturnResult(sqlmock.NewResult(0, 1))
 					mock.ExpectExec(`INSERT INTO schedule`).WillReturnResult(sqlmock.NewResult(1, 1))
+					// The exceptions of the schedule are replaced by the ones it holds.
+					mock.ExpectExec(`DELETE FROM occurrence_exception`).WithArgs("123").WillReturnResult(sqlmock.NewResult(0, 2))
+					mock.ExpectExec(`INSERT INTO occurrence_exception`).WithArgs("123", int64(1641027600), true, int64(0), int64(0), "", "", sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
 					mock.ExpectExec(`INSERT INTO invitation`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`DELETE FROM occurrence_response`).WithArgs("123").WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(`INSERT INTO occurrence_response`).
+						WithArgs("123", "123", int64(1641027600), int16(core.InvitationStatus_Declined), "", sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`DELETE FROM event_group`).WithArgs("123").WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec(`DELETE FROM event_resource`).WithArgs("123").WillReturnResult(sqlmock.NewResult(0, 0))
 					mock.ExpectCommit()
 					mock.MatchExpectationsInOrder(true)
 
@@ -222,15 +243,21 @@ func TestEventRepository_Update(t *testing.T) {
 							IsFullDay:         false,
 							RecurringType:     core.RecurringType_None,
 							RecurringInterval: 0,
+							Exceptions: []core.OccurrenceException{
+								{ScheduleID: "123", OriginalStart: 1641027600, IsCancelled: true},
+							},
 						},
 					},
 					Invitations: []core.Invitation{
 						{
-							ID:      "123",
-							EventID: "123",
-							UserID:  123,
-							Status:  core.InvitationStatus_Unknown,
-							Token:   "123",
+							ID:        "123",
+							EventID:   "123",
+							UserID:    123,
+							Status:    core.InvitationStatus_Unknown,
+							TokenHash: "123",
+							OccurrenceResponses: []core.OccurrenceResponse{
+								{ScheduleID: "123", OriginalStart: 1641027600, Status: core.InvitationStatus_Declined},
+							},
 						},
 					},
 				},
@@ -245,7 +272,10 @@ func TestEventRepository_Update(t *testing.T) {
 
 					mock.ExpectBegin()
 					mock.ExpectExec(`UPDATE event SET`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectQuery(`SELECT id FROM schedule`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectQuery(`SELECT id FROM invitation`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
 					mock.ExpectExec(`INSERT INTO schedule`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`DELETE FROM occurrence_exception`).WillReturnResult(sqlmock.NewResult(0, 0))
 					mock.ExpectExec(`INSERT INTO invitation`).WillReturnError(errors.New("error")) //nolint:goerr113
 					mock.ExpectRollback()
 					mock.MatchExpectationsInOrder(true)
@@ -270,11 +300,11 @@ func TestEventRepository_Update(t *testing.T) {
 					},
 					Invitations: []core.Invitation{
 						{
-							ID:      "123",
-							EventID: "123",
-							UserID:  123,
-							Status:  core.InvitationStatus_Unknown,
-							Token:   "123",
+							ID:        "123",
+							EventID:   "123",
+							UserID:    123,
+							Status:    core.InvitationStatus_Unknown,
+							TokenHash: "123",
 						},
 					},
 				},
@@ -295,21 +325,56 @@ func TestEventRepository_Update(t *testing.T) {
 	}
 }
 
-func TestEventRepository_FindByID(t *testing.T) {
+func TestEventRepository_SplitSeries(t *testing.T) {
 	type fields struct {
 		dbMock func(t *testing.T) *sqlx.DB
 	}
 	type args struct {
-		ctx context.Context
-		id  string
+		ctx       context.Context
+		event     *core.Event
+		following *core.Event
 	}
 
-	now := time.Now()
+	parentID := "123"
+	bufferAfter := int64(15)
+	newArgs := func() args {
+		return args{
+			ctx: t.Context(),
+			event: &core.Event{
+				ID: "123",
+				Schedules: []core.Schedule{
+					{
+						ID:                "sch1",
+						EventID:           "123",
+						StartTime:         time.Now().Unix(),
+						DurationInMinutes: 60,
+						RecurringType:     core.RecurringType_Custom,
+					},
+				},
+			},
+			following: &core.Event{
+				ID:            "456",
+				ParentEventID: &parentID,
+				Schedules: []core.Schedule{
+					{
+						ID:                   "sch2",
+						EventID:              "456",
+						StartTime:            time.Now().Unix(),
+						DurationInMinutes:    60,
+						RecurringType:        core.RecurringType_Every_Week,
+						BufferAfterInMinutes: &bufferAfter,
+						Exceptions: []core.OccurrenceException{
+							{ScheduleID: "sch2", OriginalStart: 1641027600, Title: "moved"},
+						},
+					},
+				},
+			},
+		}
+	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    *core.Event
 		wantErr bool
 	}{
 		{
@@ -319,12 +384,118 @@ func TestEventRepository_FindByID(t *testing.T) {
 					t.Helper()
 					db, mock, _ := sqlmock.New()
 
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE event SET`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectQuery(`SELECT id FROM schedule`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("sch1"))
+					mock.ExpectQuery(`SELECT id FROM invitation`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectExec(`INSERT INTO schedule`).WithArgs("sch1", "123", sqlmock.AnyArg(), int64(60), false, int64(0), "CUSTOM", "", int64(0), int32(0), nil, nil).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					// The exceptions following the split point leave the original schedule.
+					mock.ExpectExec(`DELETE FROM occurrence_exception`).WithArgs("sch1").WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(`DELETE FROM event_group`).WithArgs("123").WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec(`DELETE FROM event_resource`).WithArgs("123").WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec(`INSERT INTO event`).WithArgs("456", "", "", "", "", sqlmock.AnyArg(), sqlmock.AnyArg(), "123", nil, false, int32(0)).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO schedule`).WithArgs("sch2", "456", sqlmock.AnyArg(), int64(60), false, int64(0), "WEEK", "", int64(0), int32(0), nil, int64(15)).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO occurrence_exception`).WithArgs("sch2", int64(1641027600), false, int64(0), int64(0), "moved", "", sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: newArgs(),
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					t.Helper()
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE event SET`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectQuery(`SELECT id FROM schedule`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectQuery(`SELECT id FROM invitation`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectExec(`INSERT INTO schedule`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`DELETE FROM occurrence_exception`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec(`DELETE FROM event_group`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec(`DELETE FROM event_resource`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec(`INSERT INTO event`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			err := e.SplitSeries(tt.args.ctx, tt.args.event, tt.args.following)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventRepository_FindByID(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+
+	now := time.Now()
+	holidayCalendarID := "id-holidays"
+	groupID := "id-group"
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		want      *core.Event
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					t.Helper()
+					db, mock, _ := sqlmock.New()
+
 					mock.ExpectQuery(`SELECT .+ FROM event`).WithArgs("123").WillReturnRows(
-						sqlmock.NewRows([]string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at"}).
-							AddRow("123", "title", "desc", "Asia/Jakarta", "1", now, now),
+						sqlmock.NewRows([]string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "parent_event_id", "holiday_calendar_id", "skip_holidays", "max_attendees"}).
+							AddRow("123", "title", "desc", "Asia/Jakarta", "1", now, now, nil, "id-holidays", true, 0),
+					)
+					mock.ExpectQuery(`SELECT .+ FROM holiday`).WithArgs("id-holidays").WillReturnRows(
+						sqlmock.NewRows([]string{"calendar_id", "date", "name"}).
+							AddRow("id-holidays", time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), "New Year's Day"),
 					)
 					mock.ExpectQuery(`SELECT .+ FROM schedule`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
-					mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectQuery(`SELECT .+ FROM occurrence_exception`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"schedule_id"}))
+					mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs("123").WillReturnRows(
+						sqlmock.NewRows([]string{"id", "event_id", "user_id", "token_hash", "status", "updated_at", "comment", "proposed_schedule_id", "proposed_start_time", "proposed_end_time", "guest_email", "guest_name", "role", "can_modify_event", "can_invite_others", "can_see_guest_list", "waitlisted_at", "group_id"}).
+							AddRow("id-invitation", "123", 2, "hash", int16(core.InvitationStatus_Confirmed), nil, "", nil, nil, nil, nil, "", int16(core.AttendeeRole_Required), false, false, true, nil, "id-group"),
+					)
+					mock.ExpectQuery(`SELECT .+ FROM occurrence_response`).WithArgs("123").WillReturnRows(
+						sqlmock.NewRows([]string{"invitation_id", "schedule_id", "original_start", "status", "comment", "updated_at"}).
+							AddRow("id-invitation", "sch1", int64(1641027600), int16(core.InvitationStatus_Declined), "I'm on leave", now),
+					)
+					mock.ExpectQuery(`SELECT resource_id FROM event_resource`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"resource_id"}).AddRow("room1"))
+					mock.ExpectQuery(`SELECT .+ FROM event_group`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"group_id", "sync"}).AddRow("id-group", true))
 					mock.MatchExpectationsInOrder(true)
 
 					return sqlx.NewDb(db, "pgx")
@@ -342,8 +513,35 @@ func TestEventRepository_FindByID(t *testing.T) {
 				CreatedBy:   "1",
 				CreatedAt:   now,
 				UpdatedAt:   &now,
-				Invitations: []core.Invitation(nil),
-				Schedules:   []core.Schedule(nil),
+				Invitations: []core.Invitation{
+					{
+						ID:          "id-invitation",
+						EventID:     "123",
+						UserID:      2,
+						Status:      core.InvitationStatus_Confirmed,
+						TokenHash:   "hash",
+						Permissions: core.DefaultAttendeePermissions,
+						GroupID:     &groupID,
+						OccurrenceResponses: []core.OccurrenceResponse{
+							{
+								InvitationID:  "id-invitation",
+								ScheduleID:    "sch1",
+								OriginalStart: 1641027600,
+								Status:        core.InvitationStatus_Declined,
+								Comment:       "I'm on leave",
+								UpdatedAt:     now,
+							},
+						},
+					},
+				},
+				Schedules:         []core.Schedule(nil),
+				ResourceIDs:       []string{"room1"},
+				HolidayCalendarID: &holidayCalendarID,
+				SkipHolidays:      true,
+				Holidays: []core.Holiday{
+					{Date: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), Name: "New Year's Day"},
+				},
+				Groups: []core.EventGroup{{GroupID: "id-group", Sync: true}},
 			},
 		},
 		{
@@ -366,6 +564,27 @@ func TestEventRepository_FindByID(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					t.Helper()
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectQuery(`SELECT .+ FROM event`).WithArgs("123").WillReturnError(sql.ErrNoRows)
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				id:  "123",
+			},
+			want:      nil,
+			wantErr:   true,
+			wantErrIs: internal.ErrNotFound,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -373,6 +592,9 @@ func TestEventRepository_FindByID(t *testing.T) {
 			got, err := e.FindByID(tt.args.ctx, tt.args.id)
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
 				return
 			}
 			require.NoError(t, err)
@@ -380,3 +602,311 @@ func TestEventRepository_FindByID(t *testing.T) {
 		})
 	}
 }
+
+type arrayConverter struct{}
+
+func (arrayConverter) ConvertValue(v any) (driver.Value, error) {
+	switch v.(type) {
+	case []int16, []int32, []string:
+		return v, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+func TestEventRepository_FindByIDs(t *testing.T) {
+	createdAt := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	ids := []string{"456", "missing", "123"}
+
+	t.Run("OK", func(t *testing.T) {
+		// The pgx driver binds slices to arrays, unlike the default converter of sqlmock.
+		db, mock, _ := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+		mock.ExpectQuery(`SELECT \* FROM event WHERE id = ANY`).WithArgs(ids).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "parent_event_id", "holiday_calendar_id", "skip_holidays", "max_attendees"}).
+				AddRow("123", "standup", "daily", "Asia/Jakarta", "1", createdAt, createdAt, nil, "cal1", true, 0).
+				AddRow("456", "planning", "weekly", "UTC", "2", createdAt, createdAt, nil, nil, false, 10),
+		)
+		mock.ExpectQuery(`SELECT \* FROM holiday WHERE calendar_id = ANY`).WithArgs([]string{"cal1"}).WillReturnRows(
+			sqlmock.NewRows([]string{"calendar_id", "date", "name"}).AddRow("cal1", createdAt, "new year"),
+		)
+		mock.ExpectQuery(`SELECT .+ FROM schedule`).WithArgs(ids).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "event_id", "start_time", "duration", "is_full_day", "recurring_type", "recurring_interval", "recurrence_rule", "until", "count", "buffer_before", "buffer_after"}).
+				AddRow("sch1", "123", createdAt.Unix(), 15, false, "DAY", 0, "FREQ=DAILY", 0, 0, nil, nil).
+				AddRow("sch2", "456", createdAt.Unix(), 60, false, "NONE", 0, "", 0, 0, nil, nil),
+		)
+		mock.ExpectQuery(`SELECT .+ FROM occurrence_exception`).WithArgs(ids).WillReturnRows(
+			sqlmock.NewRows([]string{"schedule_id", "original_start", "is_cancelled", "start_time", "duration", "title", "description", "updated_at"}).
+				AddRow("sch1", createdAt.AddDate(0, 0, 1).Unix(), true, 0, 0, "", "", createdAt),
+		)
+		mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs(ids).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "event_id", "user_id", "token_hash", "status", "updated_at", "comment",
+				"proposed_schedule_id", "proposed_start_time", "proposed_end_time", "guest_email", "guest_name",
+				"role", "can_modify_event", "can_invite_others", "can_see_guest_list", "waitlisted_at", "group_id"}).
+				AddRow("inv1", "123", 2, "hash1", int16(core.InvitationStatus_Confirmed), nil, "", nil, nil, nil, nil, "", 0, false, false, true, nil, nil).
+				AddRow("inv2", "456", 2, "hash2", int16(core.InvitationStatus_Unknown), nil, "", nil, nil, nil, nil, "", 0, false, false, true, nil, nil),
+		)
+		mock.ExpectQuery(`SELECT .+ FROM occurrence_response`).WithArgs(ids).WillReturnRows(
+			sqlmock.NewRows([]string{"invitation_id", "schedule_id", "original_start", "status", "comment", "updated_at"}).
+				AddRow("inv1", "sch1", createdAt.AddDate(0, 0, 2).Unix(), int16(core.InvitationStatus_Declined), "", createdAt),
+		)
+		mock.ExpectQuery(`SELECT .+ FROM event_resource`).WithArgs(ids).WillReturnRows(
+			sqlmock.NewRows([]string{"event_id", "resource_id"}).AddRow("456", "room1"),
+		)
+		mock.ExpectQuery(`SELECT .+ FROM event_group`).WithArgs(ids).WillReturnRows(
+			sqlmock.NewRows([]string{"event_id", "group_id", "sync"}).AddRow("123", "grp1", true),
+		)
+		mock.MatchExpectationsInOrder(true)
+
+		e := postgresql.NewEventRepository(sqlx.NewDb(db, "pgx"))
+		got, err := e.FindByIDs(t.Context(), ids)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		// The events follow the order of the IDs, each one with its own details.
+		require.Len(t, got, 2)
+		assert.Equal(t, "456", got[0].ID)
+		assert.Equal(t, int32(10), got[0].MaxAttendees)
+		require.Len(t, got[0].Schedules, 1)
+		assert.Equal(t, "sch2", got[0].Schedules[0].ID)
+		assert.Empty(t, got[0].Schedules[0].Exceptions)
+		require.Len(t, got[0].Invitations, 1)
+		assert.Equal(t, "inv2", got[0].Invitations[0].ID)
+		assert.Empty(t, got[0].Invitations[0].OccurrenceResponses)
+		assert.Equal(t, []string{"room1"}, got[0].ResourceIDs)
+		assert.Empty(t, got[0].Groups)
+		assert.Empty(t, got[0].Holidays)
+
+		assert.Equal(t, "123", got[1].ID)
+		assert.Equal(t, "cal1", *got[1].HolidayCalendarID)
+		assert.Equal(t, []core.Holiday{{Date: createdAt, Name: "new year"}}, got[1].Holidays)
+		require.Len(t, got[1].Schedules, 1)
+		assert.Len(t, got[1].Schedules[0].Exceptions, 1)
+		require.Len(t, got[1].Invitations, 1)
+		assert.Len(t, got[1].Invitations[0].OccurrenceResponses, 1)
+		assert.Empty(t, got[1].ResourceIDs)
+		assert.Equal(t, []core.EventGroup{{GroupID: "grp1", Sync: true}}, got[1].Groups)
+	})
+
+	t.Run("Not OK - error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+		mock.ExpectQuery(`SELECT \* FROM event WHERE id = ANY`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+		e := postgresql.NewEventRepository(sqlx.NewDb(db, "pgx"))
+		_, err := e.FindByIDs(t.Context(), ids)
+		assert.Error(t, err)
+	})
+}
+
+func TestEventRepository_FindByAttendees(t *testing.T) {
+	from := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	to := from.Add(core.ConflictHorizon)
+
+	t.Run("OK", func(t *testing.T) {
+		// The pgx driver binds slices to arrays, unlike the default converter of sqlmock.
+		db, mock, _ := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+		mock.ExpectQuery(`SELECT DISTINCT i.event_id FROM invitation`).
+			WithArgs([]int32{2, 3}, int16(core.InvitationStatus_Declined), to.Unix(), from.Unix()).
+			WillReturnRows(sqlmock.NewRows([]string{"event_id"}).AddRow("123"))
+		// The events are loaded at once.
+		mock.ExpectQuery(`SELECT \* FROM event WHERE id = ANY`).WithArgs([]string{"123"}).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "parent_event_id", "holiday_calendar_id", "skip_holidays", "max_attendees"}).
+				AddRow("123", "title", "desc", "Asia/Jakarta", "1", from, from, nil, nil, false, 0),
+		)
+		mock.ExpectQuery(`SELECT .+ FROM schedule`).WithArgs([]string{"123"}).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .+ FROM occurrence_exception`).WithArgs([]string{"123"}).WillReturnRows(sqlmock.NewRows([]string{"schedule_id"}))
+		mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs([]string{"123"}).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .+ FROM occurrence_response`).WithArgs([]string{"123"}).WillReturnRows(sqlmock.NewRows([]string{"invitation_id"}))
+		mock.ExpectQuery(`SELECT .+ FROM event_resource`).WithArgs([]string{"123"}).WillReturnRows(sqlmock.NewRows([]string{"event_id", "resource_id"}))
+		mock.ExpectQuery(`SELECT .+ FROM event_group`).WithArgs([]string{"123"}).WillReturnRows(sqlmock.NewRows([]string{"event_id", "group_id", "sync"}))
+		mock.MatchExpectationsInOrder(true)
+
+		e := postgresql.NewEventRepository(sqlx.NewDb(db, "pgx"))
+		got, err := e.FindByAttendees(t.Context(), []int32{2, 3}, from, to)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "123", got[0].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT DISTINCT i.event_id FROM invitation`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+		e := postgresql.NewEventRepository(sqlx.NewDb(db, "pgx"))
+		_, err := e.FindByAttendees(t.Context(), []int32{2}, from, to)
+		assert.Error(t, err)
+	})
+}
+
+func TestEventRepository_StoreOccurrenceException(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx context.Context
+		exc *core.OccurrenceException
+	}
+
+	now := time.Now()
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					t.Helper()
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectExec(`INSERT INTO occurrence_exception`).
+						WithArgs("sch1", int64(1641261600), true, int64(0), int64(0), "", "", now).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`DELETE FROM reservation`).WithArgs("sch1", int64(1641261600)).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				exc: &core.OccurrenceException{
+					ScheduleID:    "sch1",
+					OriginalStart: 1641261600,
+					IsCancelled:   true,
+					UpdatedAt:     now,
+				},
+			},
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					t.Helper()
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectExec(`INSERT INTO occurrence_exception`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				exc: &core.OccurrenceException{
+					ScheduleID:    "sch1",
+					OriginalStart: 1641261600,
+					IsCancelled:   true,
+					UpdatedAt:     now,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			err := e.StoreOccurrenceException(tt.args.ctx, tt.args.exc)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventRepository_Store_Reservations(t *testing.T) {
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	newEvent := func() *core.Event {
+		return &core.Event{
+			ID:          "123",
+			Timezone:    "UTC",
+			ResourceIDs: []string{"room1"},
+			Schedules: []core.Schedule{
+				{
+					ID:                "sch1",
+					EventID:           "123",
+					StartTime:         start.Unix(),
+					DurationInMinutes: 60,
+				},
+			},
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO schedule`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO event_resource`).WithArgs("123", "room1").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO reservation`).
+			WithArgs("room1", "123", "sch1", start.Unix(), start.Unix(), start.Add(time.Hour).Unix()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.MatchExpectationsInOrder(true)
+
+		e := postgresql.NewEventRepository(sqlx.NewDb(db, "pgx"))
+		require.NoError(t, e.Store(t.Context(), newEvent()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - already reserved", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO schedule`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO event_resource`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO reservation`).WillReturnError(&pgconn.PgError{Code: "23P01"})
+		mock.ExpectRollback()
+		mock.MatchExpectationsInOrder(true)
+
+		e := postgresql.NewEventRepository(sqlx.NewDb(db, "pgx"))
+		err := e.Store(t.Context(), newEvent())
+
+		var conflictErr *core.ConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, []string{"room1"}, conflictErr.ReservedResources)
+	})
+
+	t.Run("Not OK - unknown resource", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO schedule`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO event_resource`).WillReturnError(&pgconn.PgError{Code: "23503"})
+		mock.ExpectRollback()
+		mock.MatchExpectationsInOrder(true)
+
+		e := postgresql.NewEventRepository(sqlx.NewDb(db, "pgx"))
+		err := e.Store(t.Context(), newEvent())
+		assert.ErrorIs(t, err, internal.ErrValidationFailed)
+	})
+}
+
+func TestEventRepository_StoreOccurrenceException_Reservations(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO occurrence_exception`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT .+ FROM event_resource`).WithArgs("sch1").
+		WillReturnRows(sqlmock.NewRows([]string{"resource_id"}).AddRow("room1"))
+	mock.ExpectExec(`INSERT INTO reservation`).WithArgs("room1", int64(1641261600), int64(1641265200), int64(90), "sch1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.MatchExpectationsInOrder(true)
+
+	e := postgresql.NewEventRepository(sqlx.NewDb(db, "pgx"))
+	err := e.StoreOccurrenceException(t.Context(), &core.OccurrenceException{
+		ScheduleID:        "sch1",
+		OriginalStart:     1641261600,
+		StartTime:         1641265200,
+		DurationInMinutes: 90,
+		UpdatedAt:         time.Now(),
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -10,22 +10,96 @@ import (
 )
 
 type Event struct {
-	ID          string
-	Title       string
-	Description string
-	Timezone    string
-	CreatedBy   string
-	CreatedAt   time.Time
-	UpdatedAt   sql.NullTime
+	ID                string
+	Title             string
+	Description       string
+	Timezone          string
+	CreatedBy         string
+	CreatedAt         time.Time
+	UpdatedAt         sql.NullTime
+	ParentEventID     sql.NullString
+	HolidayCalendarID sql.NullString
+	SkipHolidays      bool
+	MaxAttendees      int32
+}
+
+type EventGroup struct {
+	EventID string
+	GroupID string
+	Sync    bool
+}
+
+type EventResource struct {
+	EventID    string
+	ResourceID string
+}
+
+type Holiday struct {
+	CalendarID string
+	Date       time.Time
+	Name       string
+}
+
+type HolidayCalendar struct {
+	ID   string
+	Name string
 }
 
 type Invitation struct {
-	ID        string
-	EventID   string
-	UserID    int32
-	Token     string
-	Status    int16
-	UpdatedAt sql.NullTime
+	ID                 string
+	EventID            string
+	UserID             sql.NullInt32
+	TokenHash          string
+	Status             int16
+	UpdatedAt          sql.NullTime
+	Comment            string
+	ProposedScheduleID sql.NullString
+	ProposedStartTime  sql.NullInt64
+	ProposedEndTime    sql.NullInt64
+	GuestEmail         sql.NullString
+	GuestName          string
+	Role               int16
+	CanModifyEvent     bool
+	CanInviteOthers    bool
+	CanSeeGuestList    bool
+	WaitlistedAt       sql.NullTime
+	GroupID            sql.NullString
+}
+
+type OccurrenceException struct {
+	ScheduleID    string
+	OriginalStart int64
+	IsCancelled   bool
+	StartTime     int64
+	Duration      int64
+	Title         string
+	Description   string
+	UpdatedAt     time.Time
+}
+
+type OccurrenceResponse struct {
+	InvitationID  string
+	ScheduleID    string
+	OriginalStart int64
+	Status        int16
+	Comment       string
+	UpdatedAt     time.Time
+}
+
+type Reservation struct {
+	ResourceID    string
+	EventID       string
+	ScheduleID    string
+	OriginalStart int64
+	Period        interface{}
+}
+
+type Resource struct {
+	ID       string
+	Name     string
+	Capacity int32
+	Location string
+	Timezone string
 }
 
 type Schedule struct {
@@ -36,9 +110,42 @@ type Schedule struct {
 	IsFullDay         bool
 	RecurringInterval int64
 	RecurringType     string
+	RecurrenceRule    string
+	Until             int64
+	Count             int32
+	BufferBefore      sql.NullInt64
+	BufferAfter       sql.NullInt64
 }
 
 type User struct {
-	ID   int32
-	Name string
+	ID                  int32
+	Name                string
+	Timezone            string
+	DefaultBufferBefore int64
+	DefaultBufferAfter  int64
+	HolidayCalendarID   sql.NullString
+	DeactivatedAt       sql.NullTime
+}
+
+type UserGroup struct {
+	ID        string
+	Name      string
+	CreatedBy string
+}
+
+type UserGroupMember struct {
+	GroupID string
+	UserID  int32
+}
+
+type UserGroupSubgroup struct {
+	GroupID    string
+	SubgroupID string
+}
+
+type WorkingHour struct {
+	UserID      int32
+	Weekday     int16
+	StartMinute int16
+	EndMinute   int16
 }
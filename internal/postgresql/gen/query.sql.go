@@ -20,20 +20,28 @@ INSERT INTO
         timezone,
         created_by,
         created_at,
-        updated_at
+        updated_at,
+        parent_event_id,
+        holiday_calendar_id,
+        skip_holidays,
+        max_attendees
     )
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7)
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 `
 
 type CreateEventParams struct {
-	ID          string
-	Title       string
-	Description string
-	Timezone    string
-	CreatedBy   string
-	CreatedAt   time.Time
-	UpdatedAt   sql.NullTime
+	ID                string
+	Title             string
+	Description       string
+	Timezone          string
+	CreatedBy         string
+	CreatedAt         time.Time
+	UpdatedAt         sql.NullTime
+	ParentEventID     sql.NullString
+	HolidayCalendarID sql.NullString
+	SkipHolidays      bool
+	MaxAttendees      int32
 }
 
 func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) error {
@@ -45,23 +53,164 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) error
 		arg.CreatedBy,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.ParentEventID,
+		arg.HolidayCalendarID,
+		arg.SkipHolidays,
+		arg.MaxAttendees,
 	)
 	return err
 }
 
-const createInvitation = `-- name: CreateInvitation :exec
+const createEventGroup = `-- name: CreateEventGroup :exec
 INSERT INTO
-    invitation (id, event_id, user_id, token, status)
+    event_group (event_id, group_id, sync)
 VALUES
-    ($1, $2, $3, $4, $5)
+    ($1, $2, $3)
 `
 
-type CreateInvitationParams struct {
-	ID      string
+type CreateEventGroupParams struct {
 	EventID string
+	GroupID string
+	Sync    bool
+}
+
+func (q *Queries) CreateEventGroup(ctx context.Context, arg CreateEventGroupParams) error {
+	_, err := q.db.ExecContext(ctx, createEventGroup, arg.EventID, arg.GroupID, arg.Sync)
+	return err
+}
+
+const createEventResource = `-- name: CreateEventResource :exec
+INSERT INTO
+    event_resource (event_id, resource_id)
+VALUES
+    ($1, $2)
+`
+
+type CreateEventResourceParams struct {
+	EventID    string
+	ResourceID string
+}
+
+func (q *Queries) CreateEventResource(ctx context.Context, arg CreateEventResourceParams) error {
+	_, err := q.db.ExecContext(ctx, createEventResource, arg.EventID, arg.ResourceID)
+	return err
+}
+
+const createGroup = `-- name: CreateGroup :exec
+INSERT INTO
+    user_group (id, name, created_by)
+VALUES
+    ($1, $2, $3)
+`
+
+type CreateGroupParams struct {
+	ID        string
+	Name      string
+	CreatedBy string
+}
+
+func (q *Queries) CreateGroup(ctx context.Context, arg CreateGroupParams) error {
+	_, err := q.db.ExecContext(ctx, createGroup, arg.ID, arg.Name, arg.CreatedBy)
+	return err
+}
+
+const createGroupMember = `-- name: CreateGroupMember :exec
+INSERT INTO
+    user_group_member (group_id, user_id)
+VALUES
+    ($1, $2)
+`
+
+type CreateGroupMemberParams struct {
+	GroupID string
 	UserID  int32
-	Token   string
-	Status  int16
+}
+
+func (q *Queries) CreateGroupMember(ctx context.Context, arg CreateGroupMemberParams) error {
+	_, err := q.db.ExecContext(ctx, createGroupMember, arg.GroupID, arg.UserID)
+	return err
+}
+
+const createHoliday = `-- name: CreateHoliday :exec
+INSERT INTO
+    holiday (calendar_id, "date", name)
+VALUES
+    ($1, $2, $3)
+`
+
+type CreateHolidayParams struct {
+	CalendarID string
+	Date       time.Time
+	Name       string
+}
+
+func (q *Queries) CreateHoliday(ctx context.Context, arg CreateHolidayParams) error {
+	_, err := q.db.ExecContext(ctx, createHoliday, arg.CalendarID, arg.Date, arg.Name)
+	return err
+}
+
+const createHolidayCalendar = `-- name: CreateHolidayCalendar :exec
+INSERT INTO
+    holiday_calendar (id, name)
+VALUES
+    ($1, $2)
+`
+
+type CreateHolidayCalendarParams struct {
+	ID   string
+	Name string
+}
+
+func (q *Queries) CreateHolidayCalendar(ctx context.Context, arg CreateHolidayCalendarParams) error {
+	_, err := q.db.ExecContext(ctx, createHolidayCalendar, arg.ID, arg.Name)
+	return err
+}
+
+const createInvitation = `-- name: CreateInvitation :exec
+INSERT INTO
+    invitation (
+        id,
+        event_id,
+        user_id,
+        token_hash,
+        status,
+        comment,
+        updated_at,
+        proposed_schedule_id,
+        proposed_start_time,
+        proposed_end_time,
+        guest_email,
+        guest_name,
+        role,
+        can_modify_event,
+        can_invite_others,
+        can_see_guest_list,
+        waitlisted_at,
+        group_id
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+`
+
+type CreateInvitationParams struct {
+	ID                 string
+	EventID            string
+	UserID             sql.NullInt32
+	TokenHash          string
+	Status             int16
+	Comment            string
+	UpdatedAt          sql.NullTime
+	ProposedScheduleID sql.NullString
+	ProposedStartTime  sql.NullInt64
+	ProposedEndTime    sql.NullInt64
+	GuestEmail         sql.NullString
+	GuestName          string
+	Role               int16
+	CanModifyEvent     bool
+	CanInviteOthers    bool
+	CanSeeGuestList    bool
+	WaitlistedAt       sql.NullTime
+	GroupID            sql.NullString
 }
 
 func (q *Queries) CreateInvitation(ctx context.Context, arg CreateInvitationParams) error {
@@ -69,8 +218,81 @@ func (q *Queries) CreateInvitation(ctx context.Context, arg CreateInvitationPara
 		arg.ID,
 		arg.EventID,
 		arg.UserID,
-		arg.Token,
+		arg.TokenHash,
 		arg.Status,
+		arg.Comment,
+		arg.UpdatedAt,
+		arg.ProposedScheduleID,
+		arg.ProposedStartTime,
+		arg.ProposedEndTime,
+		arg.GuestEmail,
+		arg.GuestName,
+		arg.Role,
+		arg.CanModifyEvent,
+		arg.CanInviteOthers,
+		arg.CanSeeGuestList,
+		arg.WaitlistedAt,
+		arg.GroupID,
+	)
+	return err
+}
+
+const createReservation = `-- name: CreateReservation :exec
+INSERT INTO
+    reservation (resource_id, event_id, schedule_id, original_start, period)
+VALUES
+    (
+        $1,
+        $2,
+        $3,
+        $4,
+        int8range($5::BIGINT, $6::BIGINT)
+    )
+`
+
+type CreateReservationParams struct {
+	ResourceID    string
+	EventID       string
+	ScheduleID    string
+	OriginalStart int64
+	StartTime     int64
+	EndTime       int64
+}
+
+func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationParams) error {
+	_, err := q.db.ExecContext(ctx, createReservation,
+		arg.ResourceID,
+		arg.EventID,
+		arg.ScheduleID,
+		arg.OriginalStart,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	return err
+}
+
+const createResource = `-- name: CreateResource :exec
+INSERT INTO
+    resource (id, name, capacity, location, timezone)
+VALUES
+    ($1, $2, $3, $4, $5)
+`
+
+type CreateResourceParams struct {
+	ID       string
+	Name     string
+	Capacity int32
+	Location string
+	Timezone string
+}
+
+func (q *Queries) CreateResource(ctx context.Context, arg CreateResourceParams) error {
+	_, err := q.db.ExecContext(ctx, createResource,
+		arg.ID,
+		arg.Name,
+		arg.Capacity,
+		arg.Location,
+		arg.Timezone,
 	)
 	return err
 }
@@ -84,10 +306,15 @@ INSERT INTO
         "duration",
         is_full_day,
         recurring_interval,
-        recurring_type
+        recurring_type,
+        recurrence_rule,
+        "until",
+        "count",
+        buffer_before,
+        buffer_after
     )
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7)
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 `
 
 type CreateScheduleParams struct {
@@ -98,6 +325,11 @@ type CreateScheduleParams struct {
 	IsFullDay         bool
 	RecurringInterval int64
 	RecurringType     string
+	RecurrenceRule    string
+	Until             int64
+	Count             int32
+	BufferBefore      sql.NullInt64
+	BufferAfter       sql.NullInt64
 }
 
 func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams) error {
@@ -109,10 +341,97 @@ func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams)
 		arg.IsFullDay,
 		arg.RecurringInterval,
 		arg.RecurringType,
+		arg.RecurrenceRule,
+		arg.Until,
+		arg.Count,
+		arg.BufferBefore,
+		arg.BufferAfter,
+	)
+	return err
+}
+
+const createSubgroup = `-- name: CreateSubgroup :exec
+INSERT INTO
+    user_group_subgroup (group_id, subgroup_id)
+VALUES
+    ($1, $2)
+`
+
+type CreateSubgroupParams struct {
+	GroupID    string
+	SubgroupID string
+}
+
+func (q *Queries) CreateSubgroup(ctx context.Context, arg CreateSubgroupParams) error {
+	_, err := q.db.ExecContext(ctx, createSubgroup, arg.GroupID, arg.SubgroupID)
+	return err
+}
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO
+    "user" (name, timezone)
+VALUES
+    ($1, $2) RETURNING id
+`
+
+type CreateUserParams struct {
+	Name     string
+	Timezone string
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, createUser, arg.Name, arg.Timezone)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createWorkingHours = `-- name: CreateWorkingHours :exec
+INSERT INTO
+    working_hours (user_id, weekday, start_minute, end_minute)
+VALUES
+    ($1, $2, $3, $4)
+`
+
+type CreateWorkingHoursParams struct {
+	UserID      int32
+	Weekday     int16
+	StartMinute int16
+	EndMinute   int16
+}
+
+func (q *Queries) CreateWorkingHours(ctx context.Context, arg CreateWorkingHoursParams) error {
+	_, err := q.db.ExecContext(ctx, createWorkingHours,
+		arg.UserID,
+		arg.Weekday,
+		arg.StartMinute,
+		arg.EndMinute,
 	)
 	return err
 }
 
+const deactivateUser = `-- name: DeactivateUser :execrows
+UPDATE
+    "user"
+SET
+    deactivated_at = COALESCE(deactivated_at, $2)
+WHERE
+    id = $1
+`
+
+type DeactivateUserParams struct {
+	ID            int32
+	DeactivatedAt sql.NullTime
+}
+
+func (q *Queries) DeactivateUser(ctx context.Context, arg DeactivateUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deactivateUser, arg.ID, arg.DeactivatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteAllEvents = `-- name: DeleteAllEvents :exec
 DELETE FROM
     event
@@ -135,57 +454,177 @@ func (q *Queries) DeleteEvent(ctx context.Context, id string) error {
 	return err
 }
 
-const findEventByID = `-- name: FindEventByID :one
-SELECT
-    id, title, description, timezone, created_by, created_at, updated_at
-FROM
-    event
+const deleteEventGroups = `-- name: DeleteEventGroups :exec
+DELETE FROM
+    event_group
+WHERE
+    event_id = $1
+`
+
+func (q *Queries) DeleteEventGroups(ctx context.Context, eventID string) error {
+	_, err := q.db.ExecContext(ctx, deleteEventGroups, eventID)
+	return err
+}
+
+const deleteEventResources = `-- name: DeleteEventResources :exec
+DELETE FROM
+    event_resource
+WHERE
+    event_id = $1
+`
+
+func (q *Queries) DeleteEventResources(ctx context.Context, eventID string) error {
+	_, err := q.db.ExecContext(ctx, deleteEventResources, eventID)
+	return err
+}
+
+const deleteGroupMembers = `-- name: DeleteGroupMembers :exec
+DELETE FROM
+    user_group_member
+WHERE
+    group_id = $1
+`
+
+func (q *Queries) DeleteGroupMembers(ctx context.Context, groupID string) error {
+	_, err := q.db.ExecContext(ctx, deleteGroupMembers, groupID)
+	return err
+}
+
+const deleteInvitation = `-- name: DeleteInvitation :exec
+DELETE FROM
+    invitation
 WHERE
     id = $1
-LIMIT
-    1
 `
 
-func (q *Queries) FindEventByID(ctx context.Context, id string) (Event, error) {
-	row := q.db.QueryRowContext(ctx, findEventByID, id)
-	var i Event
-	err := row.Scan(
-		&i.ID,
-		&i.Title,
-		&i.Description,
-		&i.Timezone,
-		&i.CreatedBy,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) DeleteInvitation(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteInvitation, id)
+	return err
 }
 
-const findInvitationsByEventID = `-- name: FindInvitationsByEventID :many
-SELECT
-    id, event_id, user_id, token, status, updated_at
-FROM
+const deleteOccurrenceExceptionsByScheduleID = `-- name: DeleteOccurrenceExceptionsByScheduleID :exec
+DELETE FROM
+    occurrence_exception
+WHERE
+    schedule_id = $1
+`
+
+func (q *Queries) DeleteOccurrenceExceptionsByScheduleID(ctx context.Context, scheduleID string) error {
+	_, err := q.db.ExecContext(ctx, deleteOccurrenceExceptionsByScheduleID, scheduleID)
+	return err
+}
+
+const deleteOccurrenceReservations = `-- name: DeleteOccurrenceReservations :exec
+DELETE FROM
+    reservation
+WHERE
+    schedule_id = $1
+    AND original_start = $2
+`
+
+type DeleteOccurrenceReservationsParams struct {
+	ScheduleID    string
+	OriginalStart int64
+}
+
+func (q *Queries) DeleteOccurrenceReservations(ctx context.Context, arg DeleteOccurrenceReservationsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOccurrenceReservations, arg.ScheduleID, arg.OriginalStart)
+	return err
+}
+
+const deleteOccurrenceResponsesByInvitationID = `-- name: DeleteOccurrenceResponsesByInvitationID :exec
+DELETE FROM
+    occurrence_response
+WHERE
+    invitation_id = $1
+`
+
+func (q *Queries) DeleteOccurrenceResponsesByInvitationID(ctx context.Context, invitationID string) error {
+	_, err := q.db.ExecContext(ctx, deleteOccurrenceResponsesByInvitationID, invitationID)
+	return err
+}
+
+const deletePendingInvitation = `-- name: DeletePendingInvitation :execrows
+DELETE FROM
     invitation
 WHERE
-    event_id = $1
+    id = $1
+    AND status = $2
 `
 
-func (q *Queries) FindInvitationsByEventID(ctx context.Context, eventID string) ([]Invitation, error) {
-	rows, err := q.db.QueryContext(ctx, findInvitationsByEventID, eventID)
+type DeletePendingInvitationParams struct {
+	ID     string
+	Status int16
+}
+
+func (q *Queries) DeletePendingInvitation(ctx context.Context, arg DeletePendingInvitationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deletePendingInvitation, arg.ID, arg.Status)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSchedule = `-- name: DeleteSchedule :exec
+DELETE FROM
+    schedule
+WHERE
+    id = $1
+`
+
+func (q *Queries) DeleteSchedule(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteSchedule, id)
+	return err
+}
+
+const deleteSubgroups = `-- name: DeleteSubgroups :exec
+DELETE FROM
+    user_group_subgroup
+WHERE
+    group_id = $1
+`
+
+func (q *Queries) DeleteSubgroups(ctx context.Context, groupID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSubgroups, groupID)
+	return err
+}
+
+const deleteWorkingHoursByUserID = `-- name: DeleteWorkingHoursByUserID :exec
+DELETE FROM
+    working_hours
+WHERE
+    user_id = $1
+`
+
+func (q *Queries) DeleteWorkingHoursByUserID(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteWorkingHoursByUserID, userID)
+	return err
+}
+
+const findAllResources = `-- name: FindAllResources :many
+SELECT
+    id, name, capacity, location, timezone
+FROM
+    resource
+ORDER BY
+    name
+`
+
+func (q *Queries) FindAllResources(ctx context.Context) ([]Resource, error) {
+	rows, err := q.db.QueryContext(ctx, findAllResources)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Invitation
+	var items []Resource
 	for rows.Next() {
-		var i Invitation
+		var i Resource
 		if err := rows.Scan(
 			&i.ID,
-			&i.EventID,
-			&i.UserID,
-			&i.Token,
-			&i.Status,
-			&i.UpdatedAt,
+			&i.Name,
+			&i.Capacity,
+			&i.Location,
+			&i.Timezone,
 		); err != nil {
 			return nil, err
 		}
@@ -200,17 +639,565 @@ func (q *Queries) FindInvitationsByEventID(ctx context.Context, eventID string)
 	return items, nil
 }
 
-const findSchedulesByEventID = `-- name: FindSchedulesByEventID :many
+const findEventByID = `-- name: FindEventByID :one
 SELECT
-    id, event_id, start_time, duration, is_full_day, recurring_interval, recurring_type
+    id, title, description, timezone, created_by, created_at, updated_at, parent_event_id, holiday_calendar_id, skip_holidays, max_attendees
 FROM
-    schedule
+    event
 WHERE
-    event_id = $1
+    id = $1
+LIMIT
+    1
 `
 
-func (q *Queries) FindSchedulesByEventID(ctx context.Context, eventID string) ([]Schedule, error) {
-	rows, err := q.db.QueryContext(ctx, findSchedulesByEventID, eventID)
+func (q *Queries) FindEventByID(ctx context.Context, id string) (Event, error) {
+	row := q.db.QueryRowContext(ctx, findEventByID, id)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Timezone,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ParentEventID,
+		&i.HolidayCalendarID,
+		&i.SkipHolidays,
+		&i.MaxAttendees,
+	)
+	return i, err
+}
+
+const findEventGroupsByEventID = `-- name: FindEventGroupsByEventID :many
+SELECT
+    group_id,
+    sync
+FROM
+    event_group
+WHERE
+    event_id = $1
+ORDER BY
+    group_id
+`
+
+type FindEventGroupsByEventIDRow struct {
+	GroupID string
+	Sync    bool
+}
+
+func (q *Queries) FindEventGroupsByEventID(ctx context.Context, eventID string) ([]FindEventGroupsByEventIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, findEventGroupsByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindEventGroupsByEventIDRow
+	for rows.Next() {
+		var i FindEventGroupsByEventIDRow
+		if err := rows.Scan(&i.GroupID, &i.Sync); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findGroupByID = `-- name: FindGroupByID :one
+SELECT
+    id, name, created_by
+FROM
+    user_group
+WHERE
+    id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindGroupByID(ctx context.Context, id string) (UserGroup, error) {
+	row := q.db.QueryRowContext(ctx, findGroupByID, id)
+	var i UserGroup
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedBy)
+	return i, err
+}
+
+const findGroupMemberIDs = `-- name: FindGroupMemberIDs :many
+SELECT
+    user_id
+FROM
+    user_group_member
+WHERE
+    group_id = $1
+ORDER BY
+    user_id
+`
+
+func (q *Queries) FindGroupMemberIDs(ctx context.Context, groupID string) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, findGroupMemberIDs, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var user_id int32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findHolidayCalendarByID = `-- name: FindHolidayCalendarByID :one
+SELECT
+    id, name
+FROM
+    holiday_calendar
+WHERE
+    id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindHolidayCalendarByID(ctx context.Context, id string) (HolidayCalendar, error) {
+	row := q.db.QueryRowContext(ctx, findHolidayCalendarByID, id)
+	var i HolidayCalendar
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const findHolidaysByCalendarID = `-- name: FindHolidaysByCalendarID :many
+SELECT
+    calendar_id, date, name
+FROM
+    holiday
+WHERE
+    calendar_id = $1
+ORDER BY
+    "date"
+`
+
+func (q *Queries) FindHolidaysByCalendarID(ctx context.Context, calendarID string) ([]Holiday, error) {
+	rows, err := q.db.QueryContext(ctx, findHolidaysByCalendarID, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Holiday
+	for rows.Next() {
+		var i Holiday
+		if err := rows.Scan(&i.CalendarID, &i.Date, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findInvitationByTokenHash = `-- name: FindInvitationByTokenHash :one
+SELECT
+    id, event_id, user_id, token_hash, status, updated_at, comment, proposed_schedule_id, proposed_start_time, proposed_end_time, guest_email, guest_name, role, can_modify_event, can_invite_others, can_see_guest_list, waitlisted_at, group_id
+FROM
+    invitation
+WHERE
+    token_hash = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindInvitationByTokenHash(ctx context.Context, tokenHash string) (Invitation, error) {
+	row := q.db.QueryRowContext(ctx, findInvitationByTokenHash, tokenHash)
+	var i Invitation
+	err := row.Scan(
+		&i.ID,
+		&i.EventID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.Status,
+		&i.UpdatedAt,
+		&i.Comment,
+		&i.ProposedScheduleID,
+		&i.ProposedStartTime,
+		&i.ProposedEndTime,
+		&i.GuestEmail,
+		&i.GuestName,
+		&i.Role,
+		&i.CanModifyEvent,
+		&i.CanInviteOthers,
+		&i.CanSeeGuestList,
+		&i.WaitlistedAt,
+		&i.GroupID,
+	)
+	return i, err
+}
+
+const findInvitationIDsByEventID = `-- name: FindInvitationIDsByEventID :many
+SELECT
+    id
+FROM
+    invitation
+WHERE
+    event_id = $1
+`
+
+func (q *Queries) FindInvitationIDsByEventID(ctx context.Context, eventID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findInvitationIDsByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findInvitationsByEventID = `-- name: FindInvitationsByEventID :many
+SELECT
+    id, event_id, user_id, token_hash, status, updated_at, comment, proposed_schedule_id, proposed_start_time, proposed_end_time, guest_email, guest_name, role, can_modify_event, can_invite_others, can_see_guest_list, waitlisted_at, group_id
+FROM
+    invitation
+WHERE
+    event_id = $1
+`
+
+func (q *Queries) FindInvitationsByEventID(ctx context.Context, eventID string) ([]Invitation, error) {
+	rows, err := q.db.QueryContext(ctx, findInvitationsByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invitation
+	for rows.Next() {
+		var i Invitation
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.UserID,
+			&i.TokenHash,
+			&i.Status,
+			&i.UpdatedAt,
+			&i.Comment,
+			&i.ProposedScheduleID,
+			&i.ProposedStartTime,
+			&i.ProposedEndTime,
+			&i.GuestEmail,
+			&i.GuestName,
+			&i.Role,
+			&i.CanModifyEvent,
+			&i.CanInviteOthers,
+			&i.CanSeeGuestList,
+			&i.WaitlistedAt,
+			&i.GroupID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findNestedMemberIDs = `-- name: FindNestedMemberIDs :many
+WITH RECURSIVE nested(group_id) AS (
+    SELECT
+        $1::VARCHAR
+    UNION
+    SELECT
+        s.subgroup_id
+    FROM
+        user_group_subgroup s
+        JOIN nested n ON n.group_id = s.group_id
+)
+SELECT DISTINCT
+    m.user_id
+FROM
+    user_group_member m
+    JOIN nested n ON n.group_id = m.group_id
+ORDER BY
+    m.user_id
+`
+
+func (q *Queries) FindNestedMemberIDs(ctx context.Context, groupID string) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, findNestedMemberIDs, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var user_id int32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findNestedSubgroupIDs = `-- name: FindNestedSubgroupIDs :many
+WITH RECURSIVE nested(group_id) AS (
+    SELECT
+        subgroup_id
+    FROM
+        user_group_subgroup
+    WHERE
+        user_group_subgroup.group_id = $1
+    UNION
+    SELECT
+        s.subgroup_id
+    FROM
+        user_group_subgroup s
+        JOIN nested n ON n.group_id = s.group_id
+)
+SELECT
+    group_id::VARCHAR
+FROM
+    nested
+ORDER BY
+    group_id
+`
+
+func (q *Queries) FindNestedSubgroupIDs(ctx context.Context, groupID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findNestedSubgroupIDs, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var group_id string
+		if err := rows.Scan(&group_id); err != nil {
+			return nil, err
+		}
+		items = append(items, group_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findOccurrenceResponsesByEventID = `-- name: FindOccurrenceResponsesByEventID :many
+SELECT
+    r.invitation_id, r.schedule_id, r.original_start, r.status, r.comment, r.updated_at
+FROM
+    occurrence_response r
+    JOIN invitation i ON i.id = r.invitation_id
+WHERE
+    i.event_id = $1
+`
+
+func (q *Queries) FindOccurrenceResponsesByEventID(ctx context.Context, eventID string) ([]OccurrenceResponse, error) {
+	rows, err := q.db.QueryContext(ctx, findOccurrenceResponsesByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OccurrenceResponse
+	for rows.Next() {
+		var i OccurrenceResponse
+		if err := rows.Scan(
+			&i.InvitationID,
+			&i.ScheduleID,
+			&i.OriginalStart,
+			&i.Status,
+			&i.Comment,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findResourceByID = `-- name: FindResourceByID :one
+SELECT
+    id, name, capacity, location, timezone
+FROM
+    resource
+WHERE
+    id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindResourceByID(ctx context.Context, id string) (Resource, error) {
+	row := q.db.QueryRowContext(ctx, findResourceByID, id)
+	var i Resource
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Capacity,
+		&i.Location,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const findResourceIDsByEventID = `-- name: FindResourceIDsByEventID :many
+SELECT
+    resource_id
+FROM
+    event_resource
+WHERE
+    event_id = $1
+ORDER BY
+    resource_id
+`
+
+func (q *Queries) FindResourceIDsByEventID(ctx context.Context, eventID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findResourceIDsByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var resource_id string
+		if err := rows.Scan(&resource_id); err != nil {
+			return nil, err
+		}
+		items = append(items, resource_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findResourceIDsByScheduleID = `-- name: FindResourceIDsByScheduleID :many
+SELECT
+    er.resource_id
+FROM
+    event_resource er
+    JOIN schedule sch ON sch.event_id = er.event_id
+WHERE
+    sch.id = $1
+ORDER BY
+    er.resource_id
+`
+
+func (q *Queries) FindResourceIDsByScheduleID(ctx context.Context, id string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findResourceIDsByScheduleID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var resource_id string
+		if err := rows.Scan(&resource_id); err != nil {
+			return nil, err
+		}
+		items = append(items, resource_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findScheduleIDsByEventID = `-- name: FindScheduleIDsByEventID :many
+SELECT
+    id
+FROM
+    schedule
+WHERE
+    event_id = $1
+`
+
+func (q *Queries) FindScheduleIDsByEventID(ctx context.Context, eventID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findScheduleIDsByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findSchedulesByEventID = `-- name: FindSchedulesByEventID :many
+SELECT
+    id, event_id, start_time, duration, is_full_day, recurring_interval, recurring_type, recurrence_rule, until, count, buffer_before, buffer_after
+FROM
+    schedule
+WHERE
+    event_id = $1
+`
+
+func (q *Queries) FindSchedulesByEventID(ctx context.Context, eventID string) ([]Schedule, error) {
+	rows, err := q.db.QueryContext(ctx, findSchedulesByEventID, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -226,6 +1213,178 @@ func (q *Queries) FindSchedulesByEventID(ctx context.Context, eventID string) ([
 			&i.IsFullDay,
 			&i.RecurringInterval,
 			&i.RecurringType,
+			&i.RecurrenceRule,
+			&i.Until,
+			&i.Count,
+			&i.BufferBefore,
+			&i.BufferAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findSubgroupIDs = `-- name: FindSubgroupIDs :many
+SELECT
+    subgroup_id
+FROM
+    user_group_subgroup
+WHERE
+    group_id = $1
+ORDER BY
+    subgroup_id
+`
+
+func (q *Queries) FindSubgroupIDs(ctx context.Context, groupID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findSubgroupIDs, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var subgroup_id string
+		if err := rows.Scan(&subgroup_id); err != nil {
+			return nil, err
+		}
+		items = append(items, subgroup_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findSyncedEventIDs = `-- name: FindSyncedEventIDs :many
+WITH RECURSIVE parents(group_id) AS (
+    SELECT
+        $1::VARCHAR
+    UNION
+    SELECT
+        s.group_id
+    FROM
+        user_group_subgroup s
+        JOIN parents p ON p.group_id = s.subgroup_id
+)
+SELECT DISTINCT
+    eg.event_id
+FROM
+    event_group eg
+    JOIN parents p ON p.group_id = eg.group_id
+WHERE
+    eg.sync
+ORDER BY
+    eg.event_id
+`
+
+func (q *Queries) FindSyncedEventIDs(ctx context.Context, groupID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findSyncedEventIDs, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var event_id string
+		if err := rows.Scan(&event_id); err != nil {
+			return nil, err
+		}
+		items = append(items, event_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findUserByID = `-- name: FindUserByID :one
+SELECT
+    id,
+    name,
+    timezone,
+    deactivated_at
+FROM
+    "user"
+WHERE
+    id = $1
+`
+
+type FindUserByIDRow struct {
+	ID            int32
+	Name          string
+	Timezone      string
+	DeactivatedAt sql.NullTime
+}
+
+func (q *Queries) FindUserByID(ctx context.Context, id int32) (FindUserByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, findUserByID, id)
+	var i FindUserByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Timezone,
+		&i.DeactivatedAt,
+	)
+	return i, err
+}
+
+const findUsers = `-- name: FindUsers :many
+SELECT
+    id,
+    name,
+    timezone,
+    deactivated_at
+FROM
+    "user"
+WHERE
+    $3::boolean OR deactivated_at IS NULL
+ORDER BY
+    id
+LIMIT
+    $1 OFFSET $2
+`
+
+type FindUsersParams struct {
+	Limit              int32
+	Offset             int32
+	IncludeDeactivated bool
+}
+
+type FindUsersRow struct {
+	ID            int32
+	Name          string
+	Timezone      string
+	DeactivatedAt sql.NullTime
+}
+
+func (q *Queries) FindUsers(ctx context.Context, arg FindUsersParams) ([]FindUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, findUsers, arg.Limit, arg.Offset, arg.IncludeDeactivated)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindUsersRow
+	for rows.Next() {
+		var i FindUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Timezone,
+			&i.DeactivatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -240,6 +1399,22 @@ func (q *Queries) FindSchedulesByEventID(ctx context.Context, eventID string) ([
 	return items, nil
 }
 
+const lockEventCapacity = `-- name: LockEventCapacity :one
+SELECT
+    max_attendees
+FROM
+    event
+WHERE
+    id = $1 FOR UPDATE
+`
+
+func (q *Queries) LockEventCapacity(ctx context.Context, id string) (int32, error) {
+	row := q.db.QueryRowContext(ctx, lockEventCapacity, id)
+	var max_attendees int32
+	err := row.Scan(&max_attendees)
+	return max_attendees, err
+}
+
 const updateEvent = `-- name: UpdateEvent :exec
 UPDATE
     event
@@ -247,14 +1422,23 @@ SET
     title = $1,
     description = $2,
     timezone = $3,
-    updated_at = $4
+    updated_at = $4,
+    holiday_calendar_id = $6,
+    skip_holidays = $7,
+    max_attendees = $8
+WHERE
+    id = $5
 `
 
 type UpdateEventParams struct {
-	Title       string
-	Description string
-	Timezone    string
-	UpdatedAt   sql.NullTime
+	Title             string
+	Description       string
+	Timezone          string
+	UpdatedAt         sql.NullTime
+	ID                string
+	HolidayCalendarID sql.NullString
+	SkipHolidays      bool
+	MaxAttendees      int32
 }
 
 func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) error {
@@ -263,28 +1447,176 @@ func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) error
 		arg.Description,
 		arg.Timezone,
 		arg.UpdatedAt,
+		arg.ID,
+		arg.HolidayCalendarID,
+		arg.SkipHolidays,
+		arg.MaxAttendees,
 	)
 	return err
 }
 
+const updateInvitationResponse = `-- name: UpdateInvitationResponse :execrows
+UPDATE
+    invitation
+SET
+    status = $2,
+    comment = $3,
+    updated_at = $4,
+    proposed_schedule_id = $5,
+    proposed_start_time = $6,
+    proposed_end_time = $7,
+    waitlisted_at = $8
+WHERE
+    id = $1
+`
+
+type UpdateInvitationResponseParams struct {
+	ID                 string
+	Status             int16
+	Comment            string
+	UpdatedAt          sql.NullTime
+	ProposedScheduleID sql.NullString
+	ProposedStartTime  sql.NullInt64
+	ProposedEndTime    sql.NullInt64
+	WaitlistedAt       sql.NullTime
+}
+
+func (q *Queries) UpdateInvitationResponse(ctx context.Context, arg UpdateInvitationResponseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateInvitationResponse,
+		arg.ID,
+		arg.Status,
+		arg.Comment,
+		arg.UpdatedAt,
+		arg.ProposedScheduleID,
+		arg.ProposedStartTime,
+		arg.ProposedEndTime,
+		arg.WaitlistedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateInvitationTokenHash = `-- name: UpdateInvitationTokenHash :execrows
+UPDATE
+    invitation
+SET
+    token_hash = $2
+WHERE
+    id = $1
+`
+
+type UpdateInvitationTokenHashParams struct {
+	ID        string
+	TokenHash string
+}
+
+func (q *Queries) UpdateInvitationTokenHash(ctx context.Context, arg UpdateInvitationTokenHashParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateInvitationTokenHash, arg.ID, arg.TokenHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateUserAvailability = `-- name: UpdateUserAvailability :execrows
+UPDATE
+    "user"
+SET
+    timezone = $2,
+    default_buffer_before = $3,
+    default_buffer_after = $4,
+    holiday_calendar_id = $5
+WHERE
+    id = $1
+`
+
+type UpdateUserAvailabilityParams struct {
+	ID                  int32
+	Timezone            string
+	DefaultBufferBefore int64
+	DefaultBufferAfter  int64
+	HolidayCalendarID   sql.NullString
+}
+
+func (q *Queries) UpdateUserAvailability(ctx context.Context, arg UpdateUserAvailabilityParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserAvailability,
+		arg.ID,
+		arg.Timezone,
+		arg.DefaultBufferBefore,
+		arg.DefaultBufferAfter,
+		arg.HolidayCalendarID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const upsertInvitation = `-- name: UpsertInvitation :exec
 INSERT INTO
-    invitation (id, event_id, user_id, token, status)
+    invitation (
+        id,
+        event_id,
+        user_id,
+        token_hash,
+        status,
+        comment,
+        updated_at,
+        proposed_schedule_id,
+        proposed_start_time,
+        proposed_end_time,
+        guest_email,
+        guest_name,
+        role,
+        can_modify_event,
+        can_invite_others,
+        can_see_guest_list,
+        waitlisted_at,
+        group_id
+    )
 VALUES
-    ($1, $2, $3, $4, $5) ON CONFLICT (id, event_id) DO
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) ON CONFLICT (id) DO
 UPDATE
 SET
     user_id = $3,
-    token = $4,
-    status = $5
+    token_hash = $4,
+    status = $5,
+    comment = $6,
+    updated_at = $7,
+    proposed_schedule_id = $8,
+    proposed_start_time = $9,
+    proposed_end_time = $10,
+    guest_email = $11,
+    guest_name = $12,
+    role = $13,
+    can_modify_event = $14,
+    can_invite_others = $15,
+    can_see_guest_list = $16,
+    waitlisted_at = $17,
+    group_id = $18
 `
 
 type UpsertInvitationParams struct {
-	ID      string
-	EventID string
-	UserID  int32
-	Token   string
-	Status  int16
+	ID                 string
+	EventID            string
+	UserID             sql.NullInt32
+	TokenHash          string
+	Status             int16
+	Comment            string
+	UpdatedAt          sql.NullTime
+	ProposedScheduleID sql.NullString
+	ProposedStartTime  sql.NullInt64
+	ProposedEndTime    sql.NullInt64
+	GuestEmail         sql.NullString
+	GuestName          string
+	Role               int16
+	CanModifyEvent     bool
+	CanInviteOthers    bool
+	CanSeeGuestList    bool
+	WaitlistedAt       sql.NullTime
+	GroupID            sql.NullString
 }
 
 func (q *Queries) UpsertInvitation(ctx context.Context, arg UpsertInvitationParams) error {
@@ -292,8 +1624,150 @@ func (q *Queries) UpsertInvitation(ctx context.Context, arg UpsertInvitationPara
 		arg.ID,
 		arg.EventID,
 		arg.UserID,
-		arg.Token,
+		arg.TokenHash,
+		arg.Status,
+		arg.Comment,
+		arg.UpdatedAt,
+		arg.ProposedScheduleID,
+		arg.ProposedStartTime,
+		arg.ProposedEndTime,
+		arg.GuestEmail,
+		arg.GuestName,
+		arg.Role,
+		arg.CanModifyEvent,
+		arg.CanInviteOthers,
+		arg.CanSeeGuestList,
+		arg.WaitlistedAt,
+		arg.GroupID,
+	)
+	return err
+}
+
+const upsertOccurrenceException = `-- name: UpsertOccurrenceException :exec
+INSERT INTO
+    occurrence_exception (
+        schedule_id,
+        original_start,
+        is_cancelled,
+        start_time,
+        "duration",
+        title,
+        description,
+        updated_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (schedule_id, original_start) DO
+UPDATE
+SET
+    is_cancelled = $3,
+    start_time = $4,
+    "duration" = $5,
+    title = $6,
+    description = $7,
+    updated_at = $8
+`
+
+type UpsertOccurrenceExceptionParams struct {
+	ScheduleID    string
+	OriginalStart int64
+	IsCancelled   bool
+	StartTime     int64
+	Duration      int64
+	Title         string
+	Description   string
+	UpdatedAt     time.Time
+}
+
+func (q *Queries) UpsertOccurrenceException(ctx context.Context, arg UpsertOccurrenceExceptionParams) error {
+	_, err := q.db.ExecContext(ctx, upsertOccurrenceException,
+		arg.ScheduleID,
+		arg.OriginalStart,
+		arg.IsCancelled,
+		arg.StartTime,
+		arg.Duration,
+		arg.Title,
+		arg.Description,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const upsertOccurrenceReservation = `-- name: UpsertOccurrenceReservation :exec
+INSERT INTO
+    reservation (resource_id, event_id, schedule_id, original_start, period)
+SELECT
+    $1::VARCHAR,
+    sch.event_id,
+    sch.id,
+    $2::BIGINT,
+    int8range(
+        $3::BIGINT,
+        $3::BIGINT + COALESCE(NULLIF($4::BIGINT, 0), sch.duration) * 60
+    )
+FROM
+    schedule sch
+WHERE
+    sch.id = $5::VARCHAR ON CONFLICT (resource_id, schedule_id, original_start) DO
+UPDATE
+SET
+    period = EXCLUDED.period
+`
+
+type UpsertOccurrenceReservationParams struct {
+	ResourceID    string
+	OriginalStart int64
+	StartTime     int64
+	Duration      int64
+	ScheduleID    string
+}
+
+func (q *Queries) UpsertOccurrenceReservation(ctx context.Context, arg UpsertOccurrenceReservationParams) error {
+	_, err := q.db.ExecContext(ctx, upsertOccurrenceReservation,
+		arg.ResourceID,
+		arg.OriginalStart,
+		arg.StartTime,
+		arg.Duration,
+		arg.ScheduleID,
+	)
+	return err
+}
+
+const upsertOccurrenceResponse = `-- name: UpsertOccurrenceResponse :exec
+INSERT INTO
+    occurrence_response (
+        invitation_id,
+        schedule_id,
+        original_start,
+        status,
+        comment,
+        updated_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6) ON CONFLICT (invitation_id, schedule_id, original_start) DO
+UPDATE
+SET
+    status = $4,
+    comment = $5,
+    updated_at = $6
+`
+
+type UpsertOccurrenceResponseParams struct {
+	InvitationID  string
+	ScheduleID    string
+	OriginalStart int64
+	Status        int16
+	Comment       string
+	UpdatedAt     time.Time
+}
+
+func (q *Queries) UpsertOccurrenceResponse(ctx context.Context, arg UpsertOccurrenceResponseParams) error {
+	_, err := q.db.ExecContext(ctx, upsertOccurrenceResponse,
+		arg.InvitationID,
+		arg.ScheduleID,
+		arg.OriginalStart,
 		arg.Status,
+		arg.Comment,
+		arg.UpdatedAt,
 	)
 	return err
 }
@@ -307,16 +1781,27 @@ INSERT INTO
         "duration",
         is_full_day,
         recurring_interval,
-        recurring_type
+        recurring_type,
+        recurrence_rule,
+        "until",
+        "count",
+        buffer_before,
+        buffer_after
     )
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (id, event_id) DO
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (id) DO
 UPDATE
 SET
     start_time = $3,
     "duration" = $4,
-    recurring_interval = $5,
-    recurring_type = $6
+    is_full_day = $5,
+    recurring_interval = $6,
+    recurring_type = $7,
+    recurrence_rule = $8,
+    "until" = $9,
+    "count" = $10,
+    buffer_before = $11,
+    buffer_after = $12
 `
 
 type UpsertScheduleParams struct {
@@ -327,6 +1812,11 @@ type UpsertScheduleParams struct {
 	IsFullDay         bool
 	RecurringInterval int64
 	RecurringType     string
+	RecurrenceRule    string
+	Until             int64
+	Count             int32
+	BufferBefore      sql.NullInt64
+	BufferAfter       sql.NullInt64
 }
 
 func (q *Queries) UpsertSchedule(ctx context.Context, arg UpsertScheduleParams) error {
@@ -338,6 +1828,11 @@ func (q *Queries) UpsertSchedule(ctx context.Context, arg UpsertScheduleParams)
 		arg.IsFullDay,
 		arg.RecurringInterval,
 		arg.RecurringType,
+		arg.RecurrenceRule,
+		arg.Until,
+		arg.Count,
+		arg.BufferBefore,
+		arg.BufferAfter,
 	)
 	return err
 }
@@ -0,0 +1,142 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type GroupRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewGroupRepository(dbConn *sqlx.DB) *GroupRepository {
+	return &GroupRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+func (g *GroupRepository) Store(ctx context.Context, group *core.Group) error {
+	return inTx(ctx, g.dbConn, g.queries, func(q *gen.Queries) error {
+		err := q.CreateGroup(ctx, gen.CreateGroupParams{
+			ID:        group.ID,
+			Name:      group.Name,
+			CreatedBy: group.CreatedBy,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		return storeGroupMembers(ctx, q, group)
+	})
+}
+
+func (g *GroupRepository) FindByID(ctx context.Context, id string) (*core.Group, error) {
+	res, err := g.queries.FindGroupByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, internal.WrapErr(internal.ErrNotFound, "group "+id)
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	memberIDs, err := g.queries.FindGroupMemberIDs(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	subgroupIDs, err := g.queries.FindSubgroupIDs(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	return &core.Group{
+		ID:          res.ID,
+		Name:        res.Name,
+		CreatedBy:   res.CreatedBy,
+		MemberIDs:   memberIDs,
+		SubgroupIDs: subgroupIDs,
+	}, nil
+}
+
+func (g *GroupRepository) UpdateMembers(ctx context.Context, group *core.Group) error {
+	return inTx(ctx, g.dbConn, g.queries, func(q *gen.Queries) error {
+		err := q.DeleteGroupMembers(ctx, group.ID)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		err = q.DeleteSubgroups(ctx, group.ID)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		return storeGroupMembers(ctx, q, group)
+	})
+}
+
+func (g *GroupRepository) FindMemberIDs(ctx context.Context, id string) ([]int32, error) {
+	memberIDs, err := g.queries.FindNestedMemberIDs(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	return memberIDs, nil
+}
+
+func (g *GroupRepository) FindSubgroupIDs(ctx context.Context, id string) ([]string, error) {
+	subgroupIDs, err := g.queries.FindNestedSubgroupIDs(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	return subgroupIDs, nil
+}
+
+func (g *GroupRepository) FindSyncedEventIDs(ctx context.Context, id string) ([]string, error) {
+	eventIDs, err := g.queries.FindSyncedEventIDs(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	return eventIDs, nil
+}
+
+func storeGroupMembers(ctx context.Context, q *gen.Queries, group *core.Group) error {
+	for _, userID := range group.MemberIDs {
+		err := q.CreateGroupMember(ctx, gen.CreateGroupMemberParams{
+			GroupID: group.ID,
+			UserID:  userID,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+	}
+
+	for _, subgroupID := range group.SubgroupIDs {
+		err := q.CreateSubgroup(ctx, gen.CreateSubgroupParams{
+			GroupID:    group.ID,
+			SubgroupID: subgroupID,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+	}
+	return nil
+}
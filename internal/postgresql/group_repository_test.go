@@ -0,0 +1,127 @@
+package postgresql_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupRepository_Store(t *testing.T) {
+	group := &core.Group{
+		ID:          "id-group",
+		Name:        "Platform",
+		CreatedBy:   "1",
+		MemberIDs:   []int32{4, 5},
+		SubgroupIDs: []string{"id-sre"},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO user_group`).WithArgs("id-group", "Platform", "1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO user_group_member`).WithArgs("id-group", int32(4)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO user_group_member`).WithArgs("id-group", int32(5)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO user_group_subgroup`).WithArgs("id-group", "id-sre").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		g := postgresql.NewGroupRepository(sqlx.NewDb(db, "pgx"))
+		require.NoError(t, g.Store(t.Context(), group))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO user_group`).WillReturnError(errors.New("error")) //nolint:goerr113
+		mock.ExpectRollback()
+
+		g := postgresql.NewGroupRepository(sqlx.NewDb(db, "pgx"))
+		assert.Error(t, g.Store(t.Context(), group))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGroupRepository_FindByID(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM user_group`).WithArgs("id-group").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "created_by"}).AddRow("id-group", "Platform", "1"),
+		)
+		mock.ExpectQuery(`SELECT user_id FROM user_group_member`).WithArgs("id-group").WillReturnRows(
+			sqlmock.NewRows([]string{"user_id"}).AddRow(int32(4)).AddRow(int32(5)),
+		)
+		mock.ExpectQuery(`SELECT subgroup_id FROM user_group_subgroup`).WithArgs("id-group").WillReturnRows(
+			sqlmock.NewRows([]string{"subgroup_id"}).AddRow("id-sre"),
+		)
+
+		g := postgresql.NewGroupRepository(sqlx.NewDb(db, "pgx"))
+		got, err := g.FindByID(t.Context(), "id-group")
+		require.NoError(t, err)
+		assert.Equal(t, &core.Group{
+			ID:          "id-group",
+			Name:        "Platform",
+			CreatedBy:   "1",
+			MemberIDs:   []int32{4, 5},
+			SubgroupIDs: []string{"id-sre"},
+		}, got)
+	})
+
+	t.Run("Not OK - not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM user_group`).WithArgs("id-group").WillReturnError(sql.ErrNoRows)
+
+		g := postgresql.NewGroupRepository(sqlx.NewDb(db, "pgx"))
+		_, err := g.FindByID(t.Context(), "id-group")
+		assert.ErrorIs(t, err, internal.ErrNotFound)
+	})
+}
+
+func TestGroupRepository_UpdateMembers(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM user_group_member`).WithArgs("id-group").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`DELETE FROM user_group_subgroup`).WithArgs("id-group").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO user_group_member`).WithArgs("id-group", int32(6)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	g := postgresql.NewGroupRepository(sqlx.NewDb(db, "pgx"))
+	require.NoError(t, g.UpdateMembers(t.Context(), &core.Group{ID: "id-group", MemberIDs: []int32{6}}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGroupRepository_FindMemberIDs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`WITH RECURSIVE nested.+ FROM user_group_member`).WithArgs("id-group").WillReturnRows(
+		sqlmock.NewRows([]string{"user_id"}).AddRow(int32(4)).AddRow(int32(6)),
+	)
+
+	g := postgresql.NewGroupRepository(sqlx.NewDb(db, "pgx"))
+	got, err := g.FindMemberIDs(t.Context(), "id-group")
+	require.NoError(t, err)
+	assert.Equal(t, []int32{4, 6}, got)
+}
+
+func TestGroupRepository_FindSyncedEventIDs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`WITH RECURSIVE parents.+ FROM event_group`).WithArgs("id-group").WillReturnRows(
+		sqlmock.NewRows([]string{"event_id"}).AddRow("123"),
+	)
+
+	g := postgresql.NewGroupRepository(sqlx.NewDb(db, "pgx"))
+	got, err := g.FindSyncedEventIDs(t.Context(), "id-group")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"123"}, got)
+}
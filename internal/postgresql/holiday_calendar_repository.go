@@ -0,0 +1,85 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type HolidayCalendarRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewHolidayCalendarRepository(dbConn *sqlx.DB) *HolidayCalendarRepository {
+	return &HolidayCalendarRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+func (h *HolidayCalendarRepository) Store(ctx context.Context, calendar *core.HolidayCalendar) error {
+	return inTx(ctx, h.dbConn, h.queries, func(q *gen.Queries) error {
+		err := q.CreateHolidayCalendar(ctx, gen.CreateHolidayCalendarParams{
+			ID:   calendar.ID,
+			Name: calendar.Name,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		for _, holiday := range calendar.Holidays {
+			err = q.CreateHoliday(ctx, gen.CreateHolidayParams{
+				CalendarID: calendar.ID,
+				Date:       holiday.Date,
+				Name:       holiday.Name,
+			})
+			if err != nil {
+				slog.Error(err.Error())
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (h *HolidayCalendarRepository) FindByID(ctx context.Context, id string) (*core.HolidayCalendar, error) {
+	res, err := h.queries.FindHolidayCalendarByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, internal.WrapErr(internal.ErrNotFound, "holiday calendar "+id)
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	holidays, err := h.queries.FindHolidaysByCalendarID(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	return &core.HolidayCalendar{
+		ID:       res.ID,
+		Name:     res.Name,
+		Holidays: holidaysOf(holidays),
+	}, nil
+}
+
+func holidaysOf(rows []gen.Holiday) []core.Holiday {
+	holidays := make([]core.Holiday, len(rows))
+	for i, row := range rows {
+		holidays[i] = core.Holiday{
+			Date: row.Date.UTC(),
+			Name: row.Name,
+		}
+	}
+	return holidays
+}
@@ -0,0 +1,82 @@
+package postgresql_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolidayCalendarRepository_Store(t *testing.T) {
+	newYear := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	calendar := &core.HolidayCalendar{
+		ID:       "id-holidays",
+		Name:     "Indonesia",
+		Holidays: []core.Holiday{{Date: newYear, Name: "New Year's Day"}},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO holiday_calendar`).WithArgs("id-holidays", "Indonesia").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO holiday`).WithArgs("id-holidays", newYear, "New Year's Day").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		h := postgresql.NewHolidayCalendarRepository(sqlx.NewDb(db, "pgx"))
+		require.NoError(t, h.Store(t.Context(), calendar))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO holiday_calendar`).WillReturnError(errors.New("error")) //nolint:goerr113
+		mock.ExpectRollback()
+
+		h := postgresql.NewHolidayCalendarRepository(sqlx.NewDb(db, "pgx"))
+		assert.Error(t, h.Store(t.Context(), calendar))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestHolidayCalendarRepository_FindByID(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		newYear := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM holiday_calendar`).WithArgs("id-holidays").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow("id-holidays", "Indonesia"),
+		)
+		mock.ExpectQuery(`SELECT .+ FROM holiday`).WithArgs("id-holidays").WillReturnRows(
+			sqlmock.NewRows([]string{"calendar_id", "date", "name"}).AddRow("id-holidays", newYear, "New Year's Day"),
+		)
+
+		h := postgresql.NewHolidayCalendarRepository(sqlx.NewDb(db, "pgx"))
+		got, err := h.FindByID(t.Context(), "id-holidays")
+		require.NoError(t, err)
+		assert.Equal(t, &core.HolidayCalendar{
+			ID:       "id-holidays",
+			Name:     "Indonesia",
+			Holidays: []core.Holiday{{Date: newYear, Name: "New Year's Day"}},
+		}, got)
+	})
+
+	t.Run("Not OK - not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM holiday_calendar`).WithArgs("id-holidays").WillReturnError(sql.ErrNoRows)
+
+		h := postgresql.NewHolidayCalendarRepository(sqlx.NewDb(db, "pgx"))
+		_, err := h.FindByID(t.Context(), "id-holidays")
+		assert.ErrorIs(t, err, internal.ErrNotFound)
+	})
+}
@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"time"
 
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"go.opentelemetry.io/otel"
@@ -56,6 +57,30 @@ func (i *Instrumentation) Update(ctx context.Context, event *core.Event) error {
 	return err
 }
 
+func (i *Instrumentation) FindByAttendees(ctx context.Context, userIDs []int32, from, to time.Time) ([]core.Event, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-attendees")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	events, err := i.next.FindByAttendees(ctx, userIDs, from, to)
+	return events, err
+}
+
+func (i *Instrumentation) SplitSeries(ctx context.Context, event *core.Event, following *core.Event) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "split-series")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.SplitSeries(ctx, event, following)
+	return err
+}
+
 func (i *Instrumentation) FindByID(ctx context.Context, id string) (*core.Event, error) {
 	var err error
 	ctx, span := i.tracer.Start(ctx, "find-by-id")
@@ -67,3 +92,387 @@ func (i *Instrumentation) FindByID(ctx context.Context, id string) (*core.Event,
 	event, err := i.next.FindByID(ctx, id)
 	return event, err
 }
+
+func (i *Instrumentation) FindByIDs(ctx context.Context, ids []string) ([]core.Event, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-ids")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	events, err := i.next.FindByIDs(ctx, ids)
+	return events, err
+}
+
+func (i *Instrumentation) StoreOccurrenceException(ctx context.Context, exc *core.OccurrenceException) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store-occurrence-exception")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.StoreOccurrenceException(ctx, exc)
+	return err
+}
+
+type AvailabilityInstrumentation struct {
+	next   core.AvailabilityRepository
+	tracer trace.Tracer
+}
+
+func NewAvailabilityInstrumentation(next core.AvailabilityRepository) *AvailabilityInstrumentation {
+	return &AvailabilityInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("availability-repository"),
+	}
+}
+
+func (i *AvailabilityInstrumentation) FindByUserIDs(ctx context.Context, userIDs []int32) ([]core.Availability, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-user-ids")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindByUserIDs(ctx, userIDs)
+	return res, err
+}
+
+func (i *AvailabilityInstrumentation) Update(ctx context.Context, availability *core.Availability) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.Update(ctx, availability)
+	return err
+}
+
+type ResourceInstrumentation struct {
+	next   core.ResourceRepository
+	tracer trace.Tracer
+}
+
+func NewResourceInstrumentation(next core.ResourceRepository) *ResourceInstrumentation {
+	return &ResourceInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("resource-repository"),
+	}
+}
+
+func (i *ResourceInstrumentation) Store(ctx context.Context, resource *core.Resource) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.Store(ctx, resource)
+	return err
+}
+
+func (i *ResourceInstrumentation) FindByID(ctx context.Context, id string) (*core.Resource, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindByID(ctx, id)
+	return res, err
+}
+
+func (i *ResourceInstrumentation) FindAll(ctx context.Context) ([]core.Resource, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-all")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindAll(ctx)
+	return res, err
+}
+
+type HolidayCalendarInstrumentation struct {
+	next   core.HolidayCalendarRepository
+	tracer trace.Tracer
+}
+
+func NewHolidayCalendarInstrumentation(next core.HolidayCalendarRepository) *HolidayCalendarInstrumentation {
+	return &HolidayCalendarInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("holiday-calendar-repository"),
+	}
+}
+
+func (i *HolidayCalendarInstrumentation) Store(ctx context.Context, calendar *core.HolidayCalendar) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.Store(ctx, calendar)
+	return err
+}
+
+func (i *HolidayCalendarInstrumentation) FindByID(ctx context.Context, id string) (*core.HolidayCalendar, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindByID(ctx, id)
+	return res, err
+}
+
+type InvitationInstrumentation struct {
+	next   core.InvitationRepository
+	tracer trace.Tracer
+}
+
+func NewInvitationInstrumentation(next core.InvitationRepository) *InvitationInstrumentation {
+	return &InvitationInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("invitation-repository"),
+	}
+}
+
+func (i *InvitationInstrumentation) FindByTokenHash(ctx context.Context, hash string) (*core.Invitation, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-token-hash")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindByTokenHash(ctx, hash)
+	return res, err
+}
+
+func (i *InvitationInstrumentation) Respond(ctx context.Context, eventID string, respond func(event *core.Event) ([]*core.Invitation, error)) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "respond")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.Respond(ctx, eventID, respond)
+	return err
+}
+
+func (i *InvitationInstrumentation) UpdateTokenHash(ctx context.Context, inv *core.Invitation) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-token-hash")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateTokenHash(ctx, inv)
+	return err
+}
+
+func (i *InvitationInstrumentation) StoreOccurrenceResponse(ctx context.Context, resp *core.OccurrenceResponse) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store-occurrence-response")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.StoreOccurrenceResponse(ctx, resp)
+	return err
+}
+
+func (i *InvitationInstrumentation) FindByFilter(ctx context.Context, filter core.InvitationFilter) ([]core.Invitation, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-filter")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindByFilter(ctx, filter)
+	return res, err
+}
+
+func (i *InvitationInstrumentation) SyncGroup(ctx context.Context, invited []core.Invitation, withdrawnIDs []string) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "sync-group")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.SyncGroup(ctx, invited, withdrawnIDs)
+	return err
+}
+
+type UserInstrumentation struct {
+	next   core.UserRepository
+	tracer trace.Tracer
+}
+
+func NewUserInstrumentation(next core.UserRepository) *UserInstrumentation {
+	return &UserInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("user-repository"),
+	}
+}
+
+func (i *UserInstrumentation) Store(ctx context.Context, user *core.User) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.Store(ctx, user)
+	return err
+}
+
+func (i *UserInstrumentation) FindByID(ctx context.Context, id int32) (*core.User, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindByID(ctx, id)
+	return res, err
+}
+
+func (i *UserInstrumentation) FindByIDs(ctx context.Context, ids []int32) ([]core.User, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-ids")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindByIDs(ctx, ids)
+	return res, err
+}
+
+func (i *UserInstrumentation) FindByFilter(ctx context.Context, filter core.UserFilter) ([]core.User, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-filter")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindByFilter(ctx, filter)
+	return res, err
+}
+
+func (i *UserInstrumentation) Deactivate(ctx context.Context, id int32, at time.Time) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "deactivate")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.Deactivate(ctx, id, at)
+	return err
+}
+
+type GroupInstrumentation struct {
+	next   core.GroupRepository
+	tracer trace.Tracer
+}
+
+func NewGroupInstrumentation(next core.GroupRepository) *GroupInstrumentation {
+	return &GroupInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("group-repository"),
+	}
+}
+
+func (i *GroupInstrumentation) Store(ctx context.Context, g *core.Group) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.Store(ctx, g)
+	return err
+}
+
+func (i *GroupInstrumentation) FindByID(ctx context.Context, id string) (*core.Group, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindByID(ctx, id)
+	return res, err
+}
+
+func (i *GroupInstrumentation) UpdateMembers(ctx context.Context, g *core.Group) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-members")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateMembers(ctx, g)
+	return err
+}
+
+func (i *GroupInstrumentation) FindMemberIDs(ctx context.Context, id string) ([]int32, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-member-ids")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindMemberIDs(ctx, id)
+	return res, err
+}
+
+func (i *GroupInstrumentation) FindSubgroupIDs(ctx context.Context, id string) ([]string, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-subgroup-ids")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindSubgroupIDs(ctx, id)
+	return res, err
+}
+
+func (i *GroupInstrumentation) FindSyncedEventIDs(ctx context.Context, id string) ([]string, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-synced-event-ids")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindSyncedEventIDs(ctx, id)
+	return res, err
+}
@@ -0,0 +1,333 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type InvitationRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewInvitationRepository(dbConn *sqlx.DB) *InvitationRepository {
+	return &InvitationRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+func (i *InvitationRepository) FindByTokenHash(ctx context.Context, hash string) (*core.Invitation, error) {
+	res, err := i.queries.FindInvitationByTokenHash(ctx, hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, internal.WrapErr(internal.ErrNotFound, "invitation")
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	return invitationOf(res), nil
+}
+
+func (i *InvitationRepository) Respond(ctx context.Context, eventID string, respond func(event *core.Event) ([]*core.Invitation, error)) error {
+	return inTx(ctx, i.dbConn, i.queries, func(q *gen.Queries) error {
+		maxAttendees, err := q.LockEventCapacity(ctx, eventID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.WrapErr(internal.ErrNotFound, "event")
+		}
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		rows, err := q.FindInvitationsByEventID(ctx, eventID)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		event := &core.Event{ID: eventID, MaxAttendees: maxAttendees}
+		for _, row := range rows {
+			event.Invitations = append(event.Invitations, *invitationOf(row))
+		}
+
+		invitations, err := respond(event)
+		if err != nil {
+			return err
+		}
+
+		for _, inv := range invitations {
+			err = updateResponse(ctx, q, inv)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (i *InvitationRepository) UpdateTokenHash(ctx context.Context, inv *core.Invitation) error {
+	rows, err := i.queries.UpdateInvitationTokenHash(ctx, gen.UpdateInvitationTokenHashParams{
+		ID:        inv.ID,
+		TokenHash: inv.TokenHash,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	if rows == 0 {
+		return internal.WrapErr(internal.ErrNotFound, "invitation")
+	}
+	return nil
+}
+
+func (i *InvitationRepository) StoreOccurrenceResponse(ctx context.Context, resp *core.OccurrenceResponse) error {
+	return storeOccurrenceResponse(ctx, i.queries, resp)
+}
+
+func (i *InvitationRepository) FindByFilter(ctx context.Context, filter core.InvitationFilter) ([]core.Invitation, error) {
+	statuses := make([]int16, len(filter.Statuses))
+	for j, status := range filter.Statuses {
+		statuses[j] = int16(status) //nolint:gosec
+	}
+
+	var to int64
+	if !filter.To.IsZero() {
+		to = filter.To.Unix()
+	}
+	var from int64
+	if !filter.From.IsZero() {
+		from = filter.From.Unix()
+	}
+
+	var rows []invitationRow
+	err := i.dbConn.SelectContext(ctx, &rows, `SELECT i.id, i.event_id, i.user_id, i.token_hash, i.status, i.updated_at, i.comment,
+			i.proposed_schedule_id, i.proposed_start_time, i.proposed_end_time, i.guest_email, i.guest_name,
+			i.role, i.can_modify_event, i.can_invite_others, i.can_see_guest_list, i.waitlisted_at, i.group_id
+		FROM invitation i
+		JOIN event e ON e.id = i.event_id
+		WHERE i.user_id = $1 AND (cardinality($2::smallint[]) = 0 OR i.status = ANY($2)) AND EXISTS (
+			SELECT 1 FROM schedule s WHERE s.event_id = i.event_id AND ($3 = 0 OR s.start_time < $3) AND (
+				(s.recurrence_rule = '' AND s.start_time + s.duration * 60 > $4) OR
+				(s.recurrence_rule <> '' AND (s.until = 0 OR s.until + s.duration * 60 > $4))
+			)
+		)
+		ORDER BY e.created_at DESC, i.id
+		LIMIT $5 OFFSET $6`, filter.UserID, statuses, to, from, filter.Limit, filter.Offset)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	invitations := make([]core.Invitation, 0, len(rows))
+	for _, row := range rows {
+		invitations = append(invitations, *invitationOf(gen.Invitation(row)))
+	}
+	return invitations, nil
+}
+
+// SyncGroup stores the invitations of the users who joined a group and withdraws the ones of the
+// users who left it within a single transaction. An invitation answered in the meantime isn't
+// withdrawn.
+func (i *InvitationRepository) SyncGroup(ctx context.Context, invited []core.Invitation, withdrawnIDs []string) error {
+	return inTx(ctx, i.dbConn, i.queries, func(q *gen.Queries) error {
+		for _, inv := range invited {
+			err := createInvitation(ctx, q, &inv)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, id := range withdrawnIDs {
+			_, err := q.DeletePendingInvitation(ctx, gen.DeletePendingInvitationParams{
+				ID:     id,
+				Status: int16(core.InvitationStatus_Unknown),
+			})
+			if err != nil {
+				slog.Error(err.Error())
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func createInvitation(ctx context.Context, q *gen.Queries, inv *core.Invitation) error {
+	scheduleID, start, end := proposalOf(inv.Proposal)
+	guestEmail, guestName := guestOf(inv.Guest)
+	err := q.CreateInvitation(ctx, gen.CreateInvitationParams{
+		ID:                 inv.ID,
+		EventID:            inv.EventID,
+		UserID:             userIDOf(inv),
+		TokenHash:          inv.TokenHash,
+		Status:             int16(inv.Status), //nolint:gosec
+		Comment:            inv.Comment,
+		UpdatedAt:          nullTime(inv.UpdatedAt),
+		ProposedScheduleID: scheduleID,
+		ProposedStartTime:  start,
+		ProposedEndTime:    end,
+		GuestEmail:         guestEmail,
+		GuestName:          guestName,
+		Role:               int16(inv.Role), //nolint:gosec
+		CanModifyEvent:     inv.Permissions.CanModifyEvent,
+		CanInviteOthers:    inv.Permissions.CanInviteOthers,
+		CanSeeGuestList:    inv.Permissions.CanSeeGuestList,
+		WaitlistedAt:       nullTime(inv.WaitlistedAt),
+		GroupID:            nullString(inv.GroupID),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+func updateResponse(ctx context.Context, q *gen.Queries, inv *core.Invitation) error {
+	scheduleID, start, end := proposalOf(inv.Proposal)
+	rows, err := q.UpdateInvitationResponse(ctx, gen.UpdateInvitationResponseParams{
+		ID:                 inv.ID,
+		Status:             int16(inv.Status), //nolint:gosec
+		Comment:            inv.Comment,
+		UpdatedAt:          nullTime(inv.UpdatedAt),
+		ProposedScheduleID: scheduleID,
+		ProposedStartTime:  start,
+		ProposedEndTime:    end,
+		WaitlistedAt:       nullTime(inv.WaitlistedAt),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	if rows == 0 {
+		return internal.WrapErr(internal.ErrNotFound, "invitation")
+	}
+	return nil
+}
+
+func storeOccurrenceResponse(ctx context.Context, q *gen.Queries, resp *core.OccurrenceResponse) error {
+	err := q.UpsertOccurrenceResponse(ctx, gen.UpsertOccurrenceResponseParams{
+		InvitationID:  resp.InvitationID,
+		ScheduleID:    resp.ScheduleID,
+		OriginalStart: resp.OriginalStart,
+		Status:        int16(resp.Status), //nolint:gosec
+		Comment:       resp.Comment,
+		UpdatedAt:     resp.UpdatedAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// invitationRow is gen.Invitation scanned by sqlx.
+type invitationRow struct {
+	ID                 string         `db:"id"`
+	EventID            string         `db:"event_id"`
+	UserID             sql.NullInt32  `db:"user_id"`
+	TokenHash          string         `db:"token_hash"`
+	Status             int16          `db:"status"`
+	UpdatedAt          sql.NullTime   `db:"updated_at"`
+	Comment            string         `db:"comment"`
+	ProposedScheduleID sql.NullString `db:"proposed_schedule_id"`
+	ProposedStartTime  sql.NullInt64  `db:"proposed_start_time"`
+	ProposedEndTime    sql.NullInt64  `db:"proposed_end_time"`
+	GuestEmail         sql.NullString `db:"guest_email"`
+	GuestName          string         `db:"guest_name"`
+	Role               int16          `db:"role"`
+	CanModifyEvent     bool           `db:"can_modify_event"`
+	CanInviteOthers    bool           `db:"can_invite_others"`
+	CanSeeGuestList    bool           `db:"can_see_guest_list"`
+	WaitlistedAt       sql.NullTime   `db:"waitlisted_at"`
+	GroupID            sql.NullString `db:"group_id"`
+}
+
+func invitationOf(row gen.Invitation) *core.Invitation {
+	inv := &core.Invitation{
+		ID:        row.ID,
+		EventID:   row.EventID,
+		UserID:    row.UserID.Int32,
+		Status:    core.InvitationStatus(row.Status), //nolint:gosec
+		Role:      core.AttendeeRole(row.Role),       //nolint:gosec
+		TokenHash: row.TokenHash,
+		Comment:   row.Comment,
+		Permissions: core.AttendeePermissions{
+			CanModifyEvent:  row.CanModifyEvent,
+			CanInviteOthers: row.CanInviteOthers,
+			CanSeeGuestList: row.CanSeeGuestList,
+		},
+	}
+	if row.UpdatedAt.Valid {
+		inv.UpdatedAt = &row.UpdatedAt.Time
+	}
+	if row.WaitlistedAt.Valid {
+		inv.WaitlistedAt = &row.WaitlistedAt.Time
+	}
+	if row.GroupID.Valid {
+		inv.GroupID = &row.GroupID.String
+	}
+	if row.GuestEmail.Valid {
+		inv.Guest = &core.Guest{Email: row.GuestEmail.String, Name: row.GuestName}
+	}
+	if row.ProposedScheduleID.Valid {
+		inv.Proposal = &core.TimeProposal{
+			ScheduleID: row.ProposedScheduleID.String,
+			Start:      time.Unix(row.ProposedStartTime.Int64, 0).UTC(),
+			End:        time.Unix(row.ProposedEndTime.Int64, 0).UTC(),
+		}
+	}
+	return inv
+}
+
+func occurrenceResponseOf(row gen.OccurrenceResponse) core.OccurrenceResponse {
+	return core.OccurrenceResponse{
+		InvitationID:  row.InvitationID,
+		ScheduleID:    row.ScheduleID,
+		OriginalStart: row.OriginalStart,
+		Status:        core.InvitationStatus(row.Status), //nolint:gosec
+		Comment:       row.Comment,
+		UpdatedAt:     row.UpdatedAt,
+	}
+}
+
+// userIDOf returns the user of the invitation, null for a guest.
+func userIDOf(inv *core.Invitation) sql.NullInt32 {
+	if inv.Guest != nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: inv.UserID, Valid: true}
+}
+
+// guestOf returns the columns of the guest, a null email when there's none.
+func guestOf(g *core.Guest) (sql.NullString, string) {
+	if g == nil {
+		return sql.NullString{}, ""
+	}
+	return sql.NullString{String: g.Email, Valid: true}, g.Name
+}
+
+// proposalOf returns the columns of the proposal, null when there's none.
+func proposalOf(p *core.TimeProposal) (sql.NullString, sql.NullInt64, sql.NullInt64) {
+	if p == nil {
+		return sql.NullString{}, sql.NullInt64{}, sql.NullInt64{}
+	}
+	return sql.NullString{String: p.ScheduleID, Valid: true},
+		sql.NullInt64{Int64: p.Start.Unix(), Valid: true},
+		sql.NullInt64{Int64: p.End.Unix(), Valid: true}
+}
+
+func nullTime(v *time.Time) sql.NullTime {
+	if v == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *v, Valid: true}
+}
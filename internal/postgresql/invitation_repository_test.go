@@ -0,0 +1,272 @@
+package postgresql_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvitationRepository_FindByTokenHash(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs("hash").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "event_id", "user_id", "token_hash", "status", "updated_at", "comment", "proposed_schedule_id", "proposed_start_time", "proposed_end_time", "guest_email", "guest_name", "role", "can_modify_event", "can_invite_others", "can_see_guest_list", "waitlisted_at", "group_id"}).
+				AddRow("id-invitation", "id-event", 2, "hash", int16(core.InvitationStatus_Tentative), nil, "", "sch1", int64(1641027600), int64(1641031200), nil, "", int16(core.AttendeeRole_Optional), false, true, true, nil, nil),
+		)
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		got, err := i.FindByTokenHash(t.Context(), "hash")
+		require.NoError(t, err)
+		assert.Equal(t, &core.Invitation{
+			ID:      "id-invitation",
+			EventID: "id-event",
+			UserID:  2,
+			Status:  core.InvitationStatus_Tentative,
+			Role:    core.AttendeeRole_Optional,
+			Permissions: core.AttendeePermissions{
+				CanInviteOthers: true,
+				CanSeeGuestList: true,
+			},
+			TokenHash: "hash",
+			Proposal: &core.TimeProposal{
+				ScheduleID: "sch1",
+				Start:      time.Date(2022, time.January, 1, 9, 0, 0, 0, time.UTC),
+				End:        time.Date(2022, time.January, 1, 10, 0, 0, 0, time.UTC),
+			},
+		}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("OK - guest", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs("hash").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "event_id", "user_id", "token_hash", "status", "updated_at", "comment", "proposed_schedule_id", "proposed_start_time", "proposed_end_time", "guest_email", "guest_name", "role", "can_modify_event", "can_invite_others", "can_see_guest_list", "waitlisted_at", "group_id"}).
+				AddRow("id-invitation", "id-event", nil, "hash", int16(core.InvitationStatus_Unknown), nil, "", nil, nil, nil, "jane@example.com", "Jane", int16(core.AttendeeRole_Required), false, false, true, nil, nil),
+		)
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		got, err := i.FindByTokenHash(t.Context(), "hash")
+		require.NoError(t, err)
+		assert.Equal(t, &core.Invitation{
+			ID:          "id-invitation",
+			EventID:     "id-event",
+			Guest:       &core.Guest{Email: "jane@example.com", Name: "Jane"},
+			Permissions: core.DefaultAttendeePermissions,
+			TokenHash:   "hash",
+		}, got)
+	})
+
+	t.Run("Not OK - not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs("hash").WillReturnError(sql.ErrNoRows)
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		_, err := i.FindByTokenHash(t.Context(), "hash")
+		assert.ErrorIs(t, err, internal.ErrNotFound)
+	})
+
+	t.Run("Not OK - error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM invitation`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		_, err := i.FindByTokenHash(t.Context(), "hash")
+		assert.Error(t, err)
+	})
+}
+
+func TestInvitationRepository_Respond(t *testing.T) {
+	now := time.Date(2022, time.January, 1, 9, 0, 0, 0, time.UTC)
+	columns := []string{"id", "event_id", "user_id", "token_hash", "status", "updated_at", "comment", "proposed_schedule_id", "proposed_start_time", "proposed_end_time", "guest_email", "guest_name", "role", "can_modify_event", "can_invite_others", "can_see_guest_list", "waitlisted_at", "group_id"}
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT max_attendees FROM event .+ FOR UPDATE`).WithArgs("id-event").
+			WillReturnRows(sqlmock.NewRows([]string{"max_attendees"}).AddRow(int32(1)))
+		mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs("id-event").WillReturnRows(
+			sqlmock.NewRows(columns).
+				AddRow("id-invitation", "id-event", 2, "hash", int16(core.InvitationStatus_Confirmed), nil, "", nil, nil, nil, nil, "", int16(core.AttendeeRole_Required), false, false, true, nil, nil).
+				AddRow("id-waitlisted", "id-event", 3, "hash", int16(core.InvitationStatus_Waitlisted), nil, "", nil, nil, nil, nil, "", int16(core.AttendeeRole_Required), false, false, true, now, nil),
+		)
+		mock.ExpectExec(`UPDATE invitation`).
+			WithArgs("id-invitation", int16(core.InvitationStatus_Declined), "I'm on leave", now, "sch1", now.Add(time.Hour).Unix(), now.Add(2*time.Hour).Unix(), nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE invitation`).
+			WithArgs("id-waitlisted", int16(core.InvitationStatus_Confirmed), "", now, nil, nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		err := i.Respond(t.Context(), "id-event", func(event *core.Event) ([]*core.Invitation, error) {
+			assert.Equal(t, int32(1), event.MaxAttendees)
+			require.Len(t, event.Invitations, 2)
+			inv := &event.Invitations[0]
+			inv.Comment = "I'm on leave"
+			inv.Proposal = &core.TimeProposal{ScheduleID: "sch1", Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)}
+			promoted := event.Respond(inv, core.InvitationStatus_Declined, now)
+			return append([]*core.Invitation{inv}, promoted...), nil
+		})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - event not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT max_attendees FROM event`).WithArgs("id-event").WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		err := i.Respond(t.Context(), "id-event", func(*core.Event) ([]*core.Invitation, error) {
+			t.Fatal("responding to a missing event")
+			return nil, nil
+		})
+		assert.ErrorIs(t, err, internal.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - rejected response", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT max_attendees FROM event`).WithArgs("id-event").
+			WillReturnRows(sqlmock.NewRows([]string{"max_attendees"}).AddRow(int32(0)))
+		mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs("id-event").WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectRollback()
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		err := i.Respond(t.Context(), "id-event", func(*core.Event) ([]*core.Invitation, error) {
+			return nil, internal.ErrNotFound
+		})
+		assert.ErrorIs(t, err, internal.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInvitationRepository_UpdateTokenHash(t *testing.T) {
+	inv := &core.Invitation{ID: "id-invitation", TokenHash: "hash"}
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectExec(`UPDATE invitation`).WithArgs("id-invitation", "hash").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		require.NoError(t, i.UpdateTokenHash(t.Context(), inv))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectExec(`UPDATE invitation`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		assert.ErrorIs(t, i.UpdateTokenHash(t.Context(), inv), internal.ErrNotFound)
+	})
+}
+
+func TestInvitationRepository_StoreOccurrenceResponse(t *testing.T) {
+	now := time.Date(2022, time.January, 1, 9, 0, 0, 0, time.UTC)
+	resp := &core.OccurrenceResponse{
+		InvitationID:  "id-invitation",
+		ScheduleID:    "sch1",
+		OriginalStart: now.Unix(),
+		Status:        core.InvitationStatus_Declined,
+		Comment:       "I'm on leave",
+		UpdatedAt:     now,
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectExec(`INSERT INTO occurrence_response`).
+			WithArgs("id-invitation", "sch1", now.Unix(), int16(core.InvitationStatus_Declined), "I'm on leave", now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		require.NoError(t, i.StoreOccurrenceResponse(t.Context(), resp))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectExec(`INSERT INTO occurrence_response`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		assert.Error(t, i.StoreOccurrenceResponse(t.Context(), resp))
+	})
+}
+
+func TestInvitationRepository_SyncGroup(t *testing.T) {
+	groupID := "id-group"
+	invited := core.NewInvitation("id-event", 6)
+	invited.GroupID = &groupID
+	invited.TokenHash = "hash"
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO invitation`).
+			WithArgs(invited.ID, "id-event", int32(6), "hash", int16(core.InvitationStatus_Unknown), "", nil, nil, nil, nil, nil, "",
+				int16(core.AttendeeRole_Required), false, false, true, nil, "id-group").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`DELETE FROM invitation`).WithArgs("id-left", int16(core.InvitationStatus_Unknown)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		require.NoError(t, i.SyncGroup(t.Context(), []core.Invitation{invited}, []string{"id-left"}))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO invitation`).WillReturnError(errors.New("error")) //nolint:goerr113
+		mock.ExpectRollback()
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		assert.Error(t, i.SyncGroup(t.Context(), []core.Invitation{invited}, []string{"id-left"}))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInvitationRepository_FindByFilter(t *testing.T) {
+	from := time.Date(2022, time.January, 1, 9, 0, 0, 0, time.UTC)
+	filter := core.InvitationFilter{
+		UserID:   2,
+		Statuses: []core.InvitationStatus{core.InvitationStatus_Unknown},
+		From:     from,
+		Limit:    51,
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+		mock.ExpectQuery(`SELECT .+ FROM invitation i`).WithArgs(int64(2), []int16{0}, int64(0), from.Unix(), int64(51), int64(0)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "event_id", "user_id", "token_hash", "status", "updated_at", "comment", "proposed_schedule_id", "proposed_start_time", "proposed_end_time", "guest_email", "guest_name", "role", "can_modify_event", "can_invite_others", "can_see_guest_list", "waitlisted_at", "group_id"}).
+				AddRow("id-invitation", "id-event", 2, "hash", int16(core.InvitationStatus_Unknown), nil, "", nil, nil, nil, nil, "", int16(core.AttendeeRole_Required), false, false, true, nil, nil),
+		)
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		got, err := i.FindByFilter(t.Context(), filter)
+		require.NoError(t, err)
+		assert.Equal(t, []core.Invitation{{ID: "id-invitation", EventID: "id-event", UserID: 2, Permissions: core.DefaultAttendeePermissions, TokenHash: "hash"}}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+		mock.ExpectQuery(`SELECT .+ FROM invitation i`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+		i := postgresql.NewInvitationRepository(sqlx.NewDb(db, "pgx"))
+		_, err := i.FindByFilter(t.Context(), filter)
+		assert.Error(t, err)
+	})
+}
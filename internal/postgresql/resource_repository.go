@@ -0,0 +1,68 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type ResourceRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewResourceRepository(dbConn *sqlx.DB) *ResourceRepository {
+	return &ResourceRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+func (r *ResourceRepository) Store(ctx context.Context, resource *core.Resource) error {
+	err := r.queries.CreateResource(ctx, gen.CreateResourceParams{
+		ID:       resource.ID,
+		Name:     resource.Name,
+		Capacity: resource.Capacity,
+		Location: resource.Location,
+		Timezone: resource.Timezone,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *ResourceRepository) FindByID(ctx context.Context, id string) (*core.Resource, error) {
+	res, err := r.queries.FindResourceByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, internal.WrapErr(internal.ErrNotFound, "resource "+id)
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	resource := core.Resource(res)
+	return &resource, nil
+}
+
+func (r *ResourceRepository) FindAll(ctx context.Context) ([]core.Resource, error) {
+	res, err := r.queries.FindAllResources(ctx)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	resources := make([]core.Resource, len(res))
+	for i, resource := range res {
+		resources[i] = core.Resource(resource)
+	}
+	return resources, nil
+}
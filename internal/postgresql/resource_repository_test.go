@@ -0,0 +1,78 @@
+package postgresql_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceRepository_Store(t *testing.T) {
+	resource := &core.Resource{ID: "room1", Name: "Room 1", Capacity: 8, Location: "3rd floor", Timezone: "Asia/Jakarta"}
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectExec(`INSERT INTO resource`).WithArgs("room1", "Room 1", int32(8), "3rd floor", "Asia/Jakarta").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		r := postgresql.NewResourceRepository(sqlx.NewDb(db, "pgx"))
+		require.NoError(t, r.Store(t.Context(), resource))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectExec(`INSERT INTO resource`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+		r := postgresql.NewResourceRepository(sqlx.NewDb(db, "pgx"))
+		assert.Error(t, r.Store(t.Context(), resource))
+	})
+}
+
+func TestResourceRepository_FindByID(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM resource`).WithArgs("room1").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "capacity", "location", "timezone"}).
+				AddRow("room1", "Room 1", 8, "3rd floor", "Asia/Jakarta"),
+		)
+
+		r := postgresql.NewResourceRepository(sqlx.NewDb(db, "pgx"))
+		got, err := r.FindByID(t.Context(), "room1")
+		require.NoError(t, err)
+		assert.Equal(t, &core.Resource{ID: "room1", Name: "Room 1", Capacity: 8, Location: "3rd floor", Timezone: "Asia/Jakarta"}, got)
+	})
+
+	t.Run("Not OK - not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM resource`).WithArgs("room1").WillReturnError(sql.ErrNoRows)
+
+		r := postgresql.NewResourceRepository(sqlx.NewDb(db, "pgx"))
+		_, err := r.FindByID(t.Context(), "room1")
+		assert.ErrorIs(t, err, internal.ErrNotFound)
+	})
+}
+
+func TestResourceRepository_FindAll(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT .+ FROM resource`).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name", "capacity", "location", "timezone"}).
+			AddRow("room1", "Room 1", 8, "3rd floor", "Asia/Jakarta").
+			AddRow("projector", "Projector", 0, "", "UTC"),
+	)
+
+	r := postgresql.NewResourceRepository(sqlx.NewDb(db, "pgx"))
+	got, err := r.FindAll(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []core.Resource{
+		{ID: "room1", Name: "Room 1", Capacity: 8, Location: "3rd floor", Timezone: "Asia/Jakarta"},
+		{ID: "projector", Name: "Projector", Timezone: "UTC"},
+	}, got)
+}
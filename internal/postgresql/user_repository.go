@@ -0,0 +1,122 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type UserRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewUserRepository(dbConn *sqlx.DB) *UserRepository {
+	return &UserRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+func (u *UserRepository) Store(ctx context.Context, user *core.User) error {
+	id, err := u.queries.CreateUser(ctx, gen.CreateUserParams{
+		Name:     user.Name,
+		Timezone: user.Timezone,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	user.ID = id
+	return nil
+}
+
+func (u *UserRepository) FindByID(ctx context.Context, id int32) (*core.User, error) {
+	res, err := u.queries.FindUserByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, internal.WrapErr(internal.ErrNotFound, fmt.Sprintf("user %d", id))
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	return userOf(gen.FindUsersRow(res)), nil
+}
+
+func (u *UserRepository) FindByIDs(ctx context.Context, ids []int32) ([]core.User, error) {
+	var rows []userRow
+	err := u.dbConn.SelectContext(ctx, &rows, `SELECT id, name, timezone, deactivated_at FROM "user" WHERE id = ANY($1) ORDER BY id`, ids)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	users := make([]core.User, len(rows))
+	for i, row := range rows {
+		users[i] = *userOf(gen.FindUsersRow(row))
+	}
+	return users, nil
+}
+
+func (u *UserRepository) FindByFilter(ctx context.Context, filter core.UserFilter) ([]core.User, error) {
+	res, err := u.queries.FindUsers(ctx, gen.FindUsersParams{
+		Limit:              int32(filter.Limit),  //nolint:gosec
+		Offset:             int32(filter.Offset), //nolint:gosec
+		IncludeDeactivated: filter.IncludeDeactivated,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	users := make([]core.User, len(res))
+	for i, row := range res {
+		users[i] = *userOf(row)
+	}
+	return users, nil
+}
+
+func (u *UserRepository) Deactivate(ctx context.Context, id int32, at time.Time) error {
+	rows, err := u.queries.DeactivateUser(ctx, gen.DeactivateUserParams{
+		ID:            id,
+		DeactivatedAt: nullTime(&at),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	if rows == 0 {
+		return internal.WrapErr(internal.ErrNotFound, fmt.Sprintf("user %d", id))
+	}
+	return nil
+}
+
+// userRow is gen.FindUsersRow scanned by sqlx.
+type userRow struct {
+	ID            int32        `db:"id"`
+	Name          string       `db:"name"`
+	Timezone      string       `db:"timezone"`
+	DeactivatedAt sql.NullTime `db:"deactivated_at"`
+}
+
+func userOf(row gen.FindUsersRow) *core.User {
+	user := &core.User{
+		ID:       row.ID,
+		Name:     row.Name,
+		Timezone: row.Timezone,
+	}
+	if row.DeactivatedAt.Valid {
+		user.DeactivatedAt = &row.DeactivatedAt.Time
+	}
+	return user
+}
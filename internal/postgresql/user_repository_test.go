@@ -0,0 +1,99 @@
+package postgresql_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository_Store(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`INSERT INTO "user"`).WithArgs("Jane", "Asia/Jakarta").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int32(4)))
+
+	u := postgresql.NewUserRepository(sqlx.NewDb(db, "pgx"))
+	user := &core.User{Name: "Jane", Timezone: "Asia/Jakarta"}
+	require.NoError(t, u.Store(t.Context(), user))
+	assert.Equal(t, int32(4), user.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_FindByID(t *testing.T) {
+	deactivatedAt := time.Date(2022, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM "user"`).WithArgs(int32(2)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "timezone", "deactivated_at"}).AddRow(int32(2), "Bar", "UTC", deactivatedAt),
+		)
+
+		u := postgresql.NewUserRepository(sqlx.NewDb(db, "pgx"))
+		got, err := u.FindByID(t.Context(), 2)
+		require.NoError(t, err)
+		assert.Equal(t, &core.User{ID: 2, Name: "Bar", Timezone: "UTC", DeactivatedAt: &deactivatedAt}, got)
+	})
+
+	t.Run("Not OK - not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(`SELECT .+ FROM "user"`).WithArgs(int32(404)).WillReturnError(sql.ErrNoRows)
+
+		u := postgresql.NewUserRepository(sqlx.NewDb(db, "pgx"))
+		_, err := u.FindByID(t.Context(), 404)
+		assert.ErrorIs(t, err, internal.ErrNotFound)
+	})
+}
+
+func TestUserRepository_FindByIDs(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	mock.ExpectQuery(`SELECT .+ FROM "user" WHERE id = ANY`).WithArgs([]int32{2, 404}).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name", "timezone", "deactivated_at"}).AddRow(int32(2), "Bar", "UTC", nil),
+	)
+
+	u := postgresql.NewUserRepository(sqlx.NewDb(db, "pgx"))
+	got, err := u.FindByIDs(t.Context(), []int32{2, 404})
+	require.NoError(t, err)
+	assert.Equal(t, []core.User{{ID: 2, Name: "Bar", Timezone: "UTC"}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_FindByFilter(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT .+ FROM "user"`).WithArgs(int32(3), int32(2), false).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name", "timezone", "deactivated_at"}).AddRow(int32(3), "Jack", "UTC", nil),
+	)
+
+	u := postgresql.NewUserRepository(sqlx.NewDb(db, "pgx"))
+	got, err := u.FindByFilter(t.Context(), core.UserFilter{Limit: 3, Offset: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []core.User{{ID: 3, Name: "Jack", Timezone: "UTC"}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_Deactivate(t *testing.T) {
+	now := time.Date(2022, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("OK", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectExec(`UPDATE "user"`).WithArgs(int32(2), now).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		u := postgresql.NewUserRepository(sqlx.NewDb(db, "pgx"))
+		require.NoError(t, u.Deactivate(t.Context(), 2, now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not OK - not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectExec(`UPDATE "user"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		u := postgresql.NewUserRepository(sqlx.NewDb(db, "pgx"))
+		assert.ErrorIs(t, u.Deactivate(t.Context(), 404, now), internal.ErrNotFound)
+	})
+}
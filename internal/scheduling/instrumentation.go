@@ -20,7 +20,7 @@ func NewInstrumentation(next core.SchedulingService) *Instrumentation {
 	}
 }
 
-func (i *Instrumentation) CreateEvent(ctx context.Context, req *core.CreateEventRequest) error {
+func (i *Instrumentation) CreateEvent(ctx context.Context, req *core.CreateEventRequest) ([]core.OutsideWorkingHours, error) {
 	var err error
 	ctx, span := i.tracer.Start(ctx, "create-event")
 	defer func() {
@@ -28,8 +28,8 @@ func (i *Instrumentation) CreateEvent(ctx context.Context, req *core.CreateEvent
 		span.End()
 	}()
 
-	err = i.next.CreateEvent(ctx, req)
-	return err
+	res, err := i.next.CreateEvent(ctx, req)
+	return res, err
 }
 
 func (i *Instrumentation) DeleteEventByID(ctx context.Context, req *core.DeleteEventByIDRequest) error {
@@ -67,3 +67,243 @@ func (i *Instrumentation) FindEventByID(ctx context.Context, req *core.FindEvent
 	event, err := i.next.FindEventByID(ctx, req)
 	return event, err
 }
+
+func (i *Instrumentation) ListOccurrences(ctx context.Context, req *core.ListOccurrencesRequest) (*core.OccurrenceList, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-occurrences")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	list, err := i.next.ListOccurrences(ctx, req)
+	return list, err
+}
+
+func (i *Instrumentation) CancelOccurrence(ctx context.Context, req *core.CancelOccurrenceRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "cancel-occurrence")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.CancelOccurrence(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) UpdateOccurrence(ctx context.Context, req *core.UpdateOccurrenceRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-occurrence")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateOccurrence(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) QueryFreeBusy(ctx context.Context, req *core.QueryFreeBusyRequest) ([]core.FreeBusy, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "query-free-busy")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.QueryFreeBusy(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) SuggestMeetingTimes(ctx context.Context, req *core.SuggestMeetingTimesRequest) ([]core.MeetingSuggestion, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "suggest-meeting-times")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.SuggestMeetingTimes(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) GetAvailability(ctx context.Context, req *core.GetAvailabilityRequest) (*core.Availability, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "get-availability")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.GetAvailability(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) UpdateAvailability(ctx context.Context, req *core.UpdateAvailabilityRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-availability")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateAvailability(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) CreateResource(ctx context.Context, req *core.CreateResourceRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "create-resource")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.CreateResource(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) FindResourceByID(ctx context.Context, req *core.FindResourceByIDRequest) (*core.Resource, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-resource-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindResourceByID(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) ListResources(ctx context.Context) ([]core.Resource, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-resources")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.ListResources(ctx)
+	return res, err
+}
+
+func (i *Instrumentation) CreateHolidayCalendar(ctx context.Context, req *core.CreateHolidayCalendarRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "create-holiday-calendar")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.CreateHolidayCalendar(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) FindHolidayCalendarByID(ctx context.Context, req *core.FindHolidayCalendarByIDRequest) (*core.HolidayCalendar, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-holiday-calendar-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindHolidayCalendarByID(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) CreateGroup(ctx context.Context, req *core.CreateGroupRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "create-group")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.CreateGroup(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) FindGroupByID(ctx context.Context, req *core.FindGroupByIDRequest) (*core.Group, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-group-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.FindGroupByID(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) UpdateGroupMembers(ctx context.Context, req *core.UpdateGroupMembersRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-group-members")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateGroupMembers(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) RespondToInvitation(ctx context.Context, req *core.RespondToInvitationRequest) (*core.Invitation, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "respond-to-invitation")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.RespondToInvitation(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) RegenerateInvitationToken(ctx context.Context, req *core.RegenerateInvitationTokenRequest) (*core.Invitation, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "regenerate-invitation-token")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.RegenerateInvitationToken(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) ListProposals(ctx context.Context, req *core.ListProposalsRequest) ([]core.Invitation, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-proposals")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.ListProposals(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) AcceptProposal(ctx context.Context, req *core.AcceptProposalRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "accept-proposal")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.AcceptProposal(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) ListMyInvitations(ctx context.Context, req *core.ListMyInvitationsRequest) (*core.InvitationPage, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-my-invitations")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.ListMyInvitations(ctx, req)
+	return res, err
+}
@@ -2,32 +2,98 @@ package scheduling
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
 	"time"
 
+	"github.com/dzakaammar/event-scheduling-example/internal"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 )
 
 type Service struct {
-	eventRepo core.EventRepository
+	eventRepo        core.EventRepository
+	availabilityRepo core.AvailabilityRepository
+	resourceRepo     core.ResourceRepository
+	holidayRepo      core.HolidayCalendarRepository
+	invitationRepo   core.InvitationRepository
+	userRepo         core.UserRepository
+	groupRepo        core.GroupRepository
+	tokenSigner      *core.InvitationTokenSigner
+	notifier         core.Notifier
 }
 
-func NewService(eventRepo core.EventRepository) *Service {
+func NewService(eventRepo core.EventRepository, availabilityRepo core.AvailabilityRepository, resourceRepo core.ResourceRepository,
+	holidayRepo core.HolidayCalendarRepository, invitationRepo core.InvitationRepository, userRepo core.UserRepository,
+	groupRepo core.GroupRepository, tokenSigner *core.InvitationTokenSigner, notifier core.Notifier) *Service {
 	return &Service{
-		eventRepo: eventRepo,
+		eventRepo:        eventRepo,
+		availabilityRepo: availabilityRepo,
+		resourceRepo:     resourceRepo,
+		holidayRepo:      holidayRepo,
+		invitationRepo:   invitationRepo,
+		userRepo:         userRepo,
+		groupRepo:        groupRepo,
+		tokenSigner:      tokenSigner,
+		notifier:         notifier,
 	}
 }
 
-func (e *Service) CreateEvent(ctx context.Context, req *core.CreateEventRequest) error {
+func (e *Service) CreateEvent(ctx context.Context, req *core.CreateEventRequest) ([]core.OutsideWorkingHours, error) {
 	err := req.Validate()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	err = e.checkAttendees(ctx, req.Event.InvitedUserIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.inviteGroups(ctx, req.Event)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Event.HolidayCalendarID != nil {
+		req.Event.Holidays, err = e.holidays(ctx, *req.Event.HolidayCalendarID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	availabilities, err := e.attendeeAvailabilities(ctx, req.Event)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := req.Event.ConflictWindow(time.Now())
+	outside, err := req.Event.OutsideWorkingHours(availabilities, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.AllowConflicts {
+		err = e.checkConflicts(ctx, req.Event, availabilities, req.RespectWorkingHours)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = e.issueInvitationTokens(req.Event)
+	if err != nil {
+		return nil, err
 	}
 
 	err = e.eventRepo.Store(ctx, req.Event)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+
+	e.notifyInvited(ctx, req.Event, req.Event.Invitations)
+	return outside, nil
 }
 
 func (e *Service) DeleteEventByID(ctx context.Context, req *core.DeleteEventByIDRequest) error {
@@ -54,16 +120,485 @@ func (e *Service) UpdateEvent(ctx context.Context, req *core.UpdateEventRequest)
 		return err
 	}
 
+	if req.Event.HolidayCalendarID != nil {
+		req.Event.Holidays, err = e.holidays(ctx, *req.Event.HolidayCalendarID)
+		if err != nil {
+			return err
+		}
+	}
+
+	event, err := e.eventRepo.FindByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	err = e.inviteGroups(ctx, req.Event)
+	if err != nil {
+		return err
+	}
+
+	err = event.AuthorizeUpdate(req.ActorID, req.Event)
+	if err != nil {
+		return err
+	}
+
+	// The attendees already invited stay on the event even once deactivated.
+	invited := event.InvitedUserIDs()
+	err = e.checkAttendees(ctx, slices.DeleteFunc(req.Event.InvitedUserIDs(), func(id int32) bool {
+		return slices.Contains(invited, id)
+	}))
+	if err != nil {
+		return err
+	}
+
+	switch req.Scope {
+	case core.UpdateScope_ThisOccurrence:
+		return e.updateThisOccurrence(ctx, event, req)
+	case core.UpdateScope_ThisAndFollowing:
+		return e.updateThisAndFollowing(ctx, event, req)
+	default:
+		return e.updateAll(ctx, event, req)
+	}
+}
+
+// updateAll replaces the event with the update. The schedules kept by the update keep their
+// exceptions, the attendees kept by it keep their invitations, along with their responses and
+// tokens, and those waitlisted are promoted when the update raises the capacity of the event.
+func (e *Service) updateAll(ctx context.Context, event *core.Event, req *core.UpdateEventRequest) error {
+	req.Event.KeepSchedules(event.Schedules)
+	req.Event.Invitations = keptInvitations(event.Invitations, req.Event.Invitations, req.Event.Schedules)
+
+	if !req.AllowConflicts {
+		availabilities, err := e.attendeeAvailabilities(ctx, req.Event)
+		if err != nil {
+			return err
+		}
+
+		err = e.checkConflicts(ctx, req.Event, availabilities, req.RespectWorkingHours)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := e.issueInvitationTokens(req.Event)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
 	req.Event.UpdatedAt = &now
+	promoted := req.Event.PromoteWaitlisted(now)
 
 	err = e.eventRepo.Update(ctx, req.Event)
 	if err != nil {
 		return err
 	}
+
+	e.notifyInvited(ctx, req.Event, req.Event.Invitations)
+	e.notifyPromoted(ctx, req.Event, promoted)
+	return nil
+}
+
+// updateThisOccurrence turns the update into an exception of the targeted occurrence, holding
+// whatever differs from the series.
+func (e *Service) updateThisOccurrence(ctx context.Context, event *core.Event, req *core.UpdateEventRequest) error {
+	exc, err := findOccurrenceException(event, req.ScheduleID, req.OccurrenceStart)
+	if err != nil {
+		return err
+	}
+
+	sch := req.Event.Schedules[0]
+	start, err := sch.StartTimeIn(event.Timezone)
+	if err != nil {
+		return err
+	}
+	if !start.Equal(req.OccurrenceStart) || sch.DurationInMinutes != scheduleOf(event, req.ScheduleID).DurationInMinutes {
+		exc.StartTime = start.Unix()
+		exc.DurationInMinutes = sch.DurationInMinutes
+	}
+	if req.Event.Title != event.Title {
+		exc.Title = req.Event.Title
+	}
+	if req.Event.Description != event.Description {
+		exc.Description = req.Event.Description
+	}
+	exc.IsCancelled = false
+	exc.UpdatedAt = time.Now()
+
+	return e.storeOccurrenceException(ctx, event, exc, req.AllowConflicts, req.RespectWorkingHours)
+}
+
+// storeOccurrenceException stores the exception of an occurrence of the event, after checking the
+// occurrence moved by it doesn't conflict unless conflicts are allowed.
+func (e *Service) storeOccurrenceException(ctx context.Context, event *core.Event, exc *core.OccurrenceException, allowConflicts, respectWorkingHours bool) error {
+	if !allowConflicts && exc.StartTime != 0 {
+		// Only the moved occurrence can run into a new conflict.
+		original := scheduleOf(event, exc.ScheduleID)
+		moved := core.Event{
+			ID:       event.ID,
+			Timezone: event.Timezone,
+			Schedules: []core.Schedule{
+				{
+					ID:                    exc.ScheduleID,
+					StartTime:             exc.StartTime,
+					DurationInMinutes:     exc.DurationInMinutes,
+					BufferBeforeInMinutes: original.BufferBeforeInMinutes,
+					BufferAfterInMinutes:  original.BufferAfterInMinutes,
+				},
+			},
+			Invitations: event.Invitations,
+		}
+		availabilities, err := e.attendeeAvailabilities(ctx, &moved)
+		if err != nil {
+			return err
+		}
+
+		err = e.checkConflicts(ctx, &moved, availabilities, respectWorkingHours, event.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.eventRepo.StoreOccurrenceException(ctx, exc)
+}
+
+// updateThisAndFollowing ends the targeted schedule right before the occurrence, and moves the
+// occurrence and the ones following it into a new event linked to the original one, along with
+// their exceptions and responses. The ID of the new event is set back into the request.
+func (e *Service) updateThisAndFollowing(ctx context.Context, event *core.Event, req *core.UpdateEventRequest) error {
+	// Validates that the schedule has an occurrence at the split point.
+	_, err := findOccurrenceException(event, req.ScheduleID, req.OccurrenceStart)
+	if err != nil {
+		return err
+	}
+
+	sch := scheduleOf(event, req.ScheduleID)
+	if sch.StartTime == req.OccurrenceStart.Unix() {
+		// Splitting at the first occurrence leaves nothing behind, it's an update of the whole series.
+		return e.updateAll(ctx, event, req)
+	}
+
+	loc, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return internal.WrapErr(internal.ErrInvalidTimezone, event.Timezone)
+	}
+
+	count := sch.RecurrenceRule.Count
+	kept := sch.EndBefore(loc, req.OccurrenceStart)
+
+	now := time.Now()
+	event.UpdatedAt = &now
+
+	following := core.NewEvent(event.CreatedBy)
+	following.Title = req.Event.Title
+	following.Description = req.Event.Description
+	following.Timezone = req.Event.Timezone
+	following.ParentEventID = &event.ID
+	following.ResourceIDs = req.Event.ResourceIDs
+	following.HolidayCalendarID = req.Event.HolidayCalendarID
+	following.SkipHolidays = req.Event.SkipHolidays
+	following.Holidays = req.Event.Holidays
+	following.Groups = req.Event.Groups
+	following.MaxAttendees = req.Event.MaxAttendees
+	for _, s := range req.Event.Schedules {
+		s.EventID = following.ID
+		if count > 0 && s.RecurrenceRule.Count == count {
+			// The series keeps its number of occurrences across both events.
+			rule := s.RecurrenceRule
+			rule.Count = count - kept
+			s.SetRecurrenceRule(rule)
+		}
+		following.Schedules = append(following.Schedules, s)
+	}
+	following.Invitations = carryInvitations(event.Invitations, req.Event.Invitations, following.ID)
+
+	followingLoc, err := time.LoadLocation(following.Timezone)
+	if err != nil {
+		return internal.WrapErr(internal.ErrInvalidTimezone, following.Timezone)
+	}
+	carryOverrides(event, sch, following, followingLoc, req.OccurrenceStart)
+
+	if !req.AllowConflicts {
+		availabilities, err := e.attendeeAvailabilities(ctx, following)
+		if err != nil {
+			return err
+		}
+
+		// The original event is ending at the split point, it doesn't conflict with what follows.
+		err = e.checkConflicts(ctx, following, availabilities, req.RespectWorkingHours, event.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = e.issueInvitationTokens(following)
+	if err != nil {
+		return err
+	}
+
+	promoted := following.PromoteWaitlisted(now)
+
+	err = e.eventRepo.SplitSeries(ctx, event, following)
+	if err != nil {
+		return err
+	}
+
+	e.notifyInvited(ctx, following, following.Invitations)
+	e.notifyPromoted(ctx, following, promoted)
+
+	req.Event = following
+	return nil
+}
+
+// checkConflicts fails with a core.ConflictError when an attendee of the event is already booked
+// by another event, apart from the excluded ones, during one of its occurrences or their buffers,
+// or when some occurrences are outside the working hours of an attendee and they are respected.
+// The availabilities of the attendees give their default buffers and working hours.
+func (e *Service) checkConflicts(ctx context.Context, event *core.Event, availabilities []core.Availability, respectWorkingHours bool, excludedIDs ...string) error {
+	attendees := event.AttendeeIDs()
+	if len(attendees) == 0 {
+		return nil
+	}
+
+	from, to := event.ConflictWindow(time.Now())
+
+	var outside []core.OutsideWorkingHours
+	if respectWorkingHours {
+		var err error
+		outside, err = event.OutsideWorkingHours(availabilities, from, to)
+		if err != nil {
+			return err
+		}
+	}
+
+	others, err := e.eventRepo.FindByAttendees(ctx, attendees, from.Add(-core.MaxBuffer), to.Add(core.MaxBuffer))
+	if err != nil {
+		return err
+	}
+	others = slices.DeleteFunc(others, func(o core.Event) bool {
+		return o.ID == event.ID || slices.Contains(excludedIDs, o.ID)
+	})
+
+	conflicts, err := event.Conflicts(others, core.DefaultBuffers(availabilities), from, to)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 || len(outside) > 0 {
+		return &core.ConflictError{Conflicts: conflicts, OutsideWorkingHours: outside}
+	}
+	return nil
+}
+
+// checkAttendees fails with internal.ErrValidationFailed, naming them, when some of the users are
+// unknown or deactivated.
+func (e *Service) checkAttendees(ctx context.Context, userIDs []int32) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	users, err := e.userRepo.FindByIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	return core.CheckAttendees(userIDs, users)
+}
+
+// attendeeAvailabilities returns the availability of the attendees of the event.
+func (e *Service) attendeeAvailabilities(ctx context.Context, event *core.Event) ([]core.Availability, error) {
+	attendees := event.AttendeeIDs()
+	if len(attendees) == 0 {
+		return nil, nil
+	}
+	return e.availabilityRepo.FindByUserIDs(ctx, attendees)
+}
+
+// inviteGroups invites the active members of the groups of the event who aren't invited yet.
+func (e *Service) inviteGroups(ctx context.Context, event *core.Event) error {
+	for _, g := range event.Groups {
+		_, err := e.group(ctx, g.GroupID)
+		if err != nil {
+			return err
+		}
+
+		memberIDs, err := e.activeMemberIDs(ctx, g.GroupID)
+		if err != nil {
+			return err
+		}
+		event.InviteGroup(g.GroupID, memberIDs)
+	}
+	return nil
+}
+
+// activeMemberIDs returns the members of the group, at any depth, leaving out the deactivated ones.
+func (e *Service) activeMemberIDs(ctx context.Context, groupID string) ([]int32, error) {
+	memberIDs, err := e.groupRepo.FindMemberIDs(ctx, groupID)
+	if err != nil || len(memberIDs) == 0 {
+		return nil, err
+	}
+
+	users, err := e.userRepo.FindByIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var active []int32
+	for _, u := range users {
+		if u.IsActive() {
+			active = append(active, u.ID)
+		}
+	}
+	slices.Sort(active)
+	return active, nil
+}
+
+// group returns the group, an unknown group fails the validation.
+func (e *Service) group(ctx context.Context, groupID string) (*core.Group, error) {
+	group, err := e.groupRepo.FindByID(ctx, groupID)
+	if errors.Is(err, internal.ErrNotFound) {
+		return nil, internal.WrapErr(internal.ErrValidationFailed, "unknown group "+groupID)
+	}
+	return group, err
+}
+
+// holidays returns the holidays of the calendar, an unknown calendar fails the validation.
+func (e *Service) holidays(ctx context.Context, calendarID string) ([]core.Holiday, error) {
+	calendar, err := e.holidayRepo.FindByID(ctx, calendarID)
+	if errors.Is(err, internal.ErrNotFound) {
+		return nil, internal.WrapErr(internal.ErrValidationFailed, "unknown holiday calendar "+calendarID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return calendar.Holidays, nil
+}
+
+// issueInvitationTokens issues a token, expiring at the end of the event, to every invitation
+// without one.
+func (e *Service) issueInvitationTokens(event *core.Event) error {
+	expiresAt, err := event.InvitationTokenExpiry(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range event.Invitations {
+		if event.Invitations[i].TokenHash != "" {
+			continue
+		}
+		err = event.Invitations[i].IssueToken(e.tokenSigner, expiresAt)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// respectedAvailabilities returns the availabilities when their working hours are respected.
+func respectedAvailabilities(availabilities []core.Availability, respect bool) []core.Availability {
+	if !respect {
+		return nil
+	}
+	return availabilities
+}
+
+// carryInvitations invites the attendees of the update to the following series, keeping the
+// responses they gave to the original series, and the waitlisted ones their place on the waitlist.
+// Every original attendee is carried along when the update has no attendees.
+func carryInvitations(original, updated []core.Invitation, eventID string) []core.Invitation {
+	if len(updated) == 0 {
+		updated = original
+	}
+
+	invitations := make([]core.Invitation, len(updated))
+	for index, inv := range updated {
+		i := core.NewInvitation(eventID, inv.UserID)
+		i.Guest = inv.Guest
+		i.Role = inv.Role
+		i.Permissions = inv.Permissions
+		i.GroupID = inv.GroupID
+		for _, o := range original {
+			if o.SameAttendee(&inv) {
+				i.Status = o.Status
+				i.WaitlistedAt = o.WaitlistedAt
+				i.UpdatedAt = o.UpdatedAt
+			}
+		}
+		invitations[index] = i
+	}
+	return invitations
+}
+
+// carryOverrides moves the exceptions of the split schedule and the responses to its occurrences,
+// from the split point on, to the schedule of the following series still having the occurrence.
+// The other ones are dropped, their occurrence being moved by the update.
+func carryOverrides(event *core.Event, sch *core.Schedule, following *core.Event, loc *time.Location, split time.Time) {
+	followingSchedule := func(originalStart int64) *core.Schedule {
+		for i := range following.Schedules {
+			if following.Schedules[i].HasOccurrenceAt(loc, time.Unix(originalStart, 0)) {
+				return &following.Schedules[i]
+			}
+		}
+		return nil
+	}
+
+	var exceptions []core.OccurrenceException
+	for _, exc := range sch.Exceptions {
+		if exc.OriginalStart < split.Unix() {
+			exceptions = append(exceptions, exc)
+			continue
+		}
+		if f := followingSchedule(exc.OriginalStart); f != nil {
+			exc.ScheduleID = f.ID
+			f.Exceptions = append(f.Exceptions, exc)
+		}
+	}
+	sch.Exceptions = exceptions
+
+	for i := range event.Invitations {
+		inv := &event.Invitations[i]
+		var responses []core.OccurrenceResponse
+		for _, resp := range inv.OccurrenceResponses {
+			if resp.ScheduleID != sch.ID || resp.OriginalStart < split.Unix() {
+				responses = append(responses, resp)
+				continue
+			}
+			f := followingSchedule(resp.OriginalStart)
+			if f == nil {
+				continue
+			}
+			for j := range following.Invitations {
+				carried := &following.Invitations[j]
+				if carried.SameAttendee(inv) {
+					resp.InvitationID = carried.ID
+					resp.ScheduleID = f.ID
+					carried.OccurrenceResponses = append(carried.OccurrenceResponses, resp)
+				}
+			}
+		}
+		inv.OccurrenceResponses = responses
+	}
+}
+
+// keptInvitations returns the invitations of the update, the ones of the attendees already invited
+// being replaced by their original invitation holding the role and the permissions of the update.
+// The responses to the occurrences of the schedules the update drops go along with them.
+func keptInvitations(original, updated []core.Invitation, schedules []core.Schedule) []core.Invitation {
+	invitations := make([]core.Invitation, len(updated))
+	for index, inv := range updated {
+		invitations[index] = inv
+		for _, o := range original {
+			if o.SameAttendee(&inv) {
+				o.Role = inv.Role
+				o.Permissions = inv.Permissions
+				o.OccurrenceResponses = slices.DeleteFunc(slices.Clone(o.OccurrenceResponses), func(r core.OccurrenceResponse) bool {
+					return !slices.ContainsFunc(schedules, func(sch core.Schedule) bool { return sch.ID == r.ScheduleID })
+				})
+				invitations[index] = o
+			}
+		}
+	}
+	return invitations
+}
+
 func (e *Service) FindEventByID(ctx context.Context, req *core.FindEventByIDRequest) (*core.Event, error) {
 	err := req.Validate()
 	if err != nil {
@@ -75,5 +610,748 @@ func (e *Service) FindEventByID(ctx context.Context, req *core.FindEventByIDRequ
 		return nil, err
 	}
 
+	event.HideGuestListFrom(req.ActorID)
 	return event, nil
 }
+
+func (e *Service) ListOccurrences(ctx context.Context, req *core.ListOccurrencesRequest) (*core.OccurrenceList, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := e.eventRepo.FindByID(ctx, req.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	event.HideGuestListFrom(req.ActorID)
+	return event.Occurrences(req.From, req.To, core.MaxOccurrences)
+}
+
+func (e *Service) CancelOccurrence(ctx context.Context, req *core.CancelOccurrenceRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	event, err := e.eventRepo.FindByID(ctx, req.EventID)
+	if err != nil {
+		return err
+	}
+
+	err = event.AuthorizeOccurrenceUpdate(req.ActorID)
+	if err != nil {
+		return err
+	}
+
+	exc, err := findOccurrenceException(event, req.ScheduleID, req.OriginalStart)
+	if err != nil {
+		return err
+	}
+
+	exc.IsCancelled = true
+	exc.UpdatedAt = time.Now()
+
+	return e.eventRepo.StoreOccurrenceException(ctx, exc)
+}
+
+func (e *Service) UpdateOccurrence(ctx context.Context, req *core.UpdateOccurrenceRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	event, err := e.eventRepo.FindByID(ctx, req.EventID)
+	if err != nil {
+		return err
+	}
+
+	err = event.AuthorizeOccurrenceUpdate(req.ActorID)
+	if err != nil {
+		return err
+	}
+
+	exc, err := findOccurrenceException(event, req.ScheduleID, req.OriginalStart)
+	if err != nil {
+		return err
+	}
+
+	exc.IsCancelled = false
+	if !req.Start.IsZero() {
+		exc.StartTime = req.Start.Unix()
+		exc.DurationInMinutes = int64(req.End.Sub(req.Start).Minutes())
+	}
+	if req.Title != "" {
+		exc.Title = req.Title
+	}
+	if req.Description != "" {
+		exc.Description = req.Description
+	}
+	exc.UpdatedAt = time.Now()
+
+	return e.storeOccurrenceException(ctx, event, exc, req.AllowConflicts, req.RespectWorkingHours)
+}
+
+func (e *Service) QueryFreeBusy(ctx context.Context, req *core.QueryFreeBusyRequest) ([]core.FreeBusy, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, internal.WrapErr(internal.ErrInvalidTimezone, req.Timezone)
+	}
+
+	events, err := e.eventRepo.FindByAttendees(ctx, req.UserIDs, req.From.Add(-core.MaxBuffer), req.To.Add(core.MaxBuffer))
+	if err != nil {
+		return nil, err
+	}
+
+	availabilities, err := e.availabilityRepo.FindByUserIDs(ctx, req.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.FreeBusyOf(events, respectedAvailabilities(availabilities, req.RespectWorkingHours), core.DefaultBuffers(availabilities),
+		req.UserIDs, req.From, req.To, loc)
+}
+
+func (e *Service) SuggestMeetingTimes(ctx context.Context, req *core.SuggestMeetingTimesRequest) ([]core.MeetingSuggestion, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, internal.WrapErr(internal.ErrInvalidTimezone, req.Timezone)
+	}
+
+	userIDs := append(slices.Clone(req.RequiredUserIDs), req.OptionalUserIDs...)
+	events, err := e.eventRepo.FindByAttendees(ctx, userIDs, req.From.Add(-core.MaxBuffer), req.To.Add(core.MaxBuffer))
+	if err != nil {
+		return nil, err
+	}
+
+	availabilities, err := e.availabilityRepo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	freeBusy, err := core.FreeBusyOf(events, respectedAvailabilities(availabilities, req.RespectWorkingHours), core.DefaultBuffers(availabilities),
+		userIDs, req.From, req.To, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = core.DefaultSuggestionLimit
+	}
+
+	return core.SuggestMeetings(freeBusy, req.RequiredUserIDs, req.OptionalUserIDs, req.Duration, req.From, req.To, loc, req.WorkingHours, limit), nil
+}
+
+func (e *Service) GetAvailability(ctx context.Context, req *core.GetAvailabilityRequest) (*core.Availability, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	availabilities, err := e.availabilityRepo.FindByUserIDs(ctx, []int32{req.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if len(availabilities) == 0 {
+		return nil, internal.WrapErr(internal.ErrNotFound, fmt.Sprintf("user %d", req.UserID))
+	}
+	return &availabilities[0], nil
+}
+
+func (e *Service) UpdateAvailability(ctx context.Context, req *core.UpdateAvailabilityRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	if req.ActorID != strconv.Itoa(int(req.Availability.UserID)) {
+		return internal.WrapErr(internal.ErrPermissionDenied, "the user can only update their own availability")
+	}
+
+	if req.Availability.HolidayCalendarID != nil {
+		req.Availability.Holidays, err = e.holidays(ctx, *req.Availability.HolidayCalendarID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.availabilityRepo.Update(ctx, req.Availability)
+}
+
+func (e *Service) CreateResource(ctx context.Context, req *core.CreateResourceRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	return e.resourceRepo.Store(ctx, req.Resource)
+}
+
+func (e *Service) FindResourceByID(ctx context.Context, req *core.FindResourceByIDRequest) (*core.Resource, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.resourceRepo.FindByID(ctx, req.ID)
+}
+
+func (e *Service) ListResources(ctx context.Context) ([]core.Resource, error) {
+	return e.resourceRepo.FindAll(ctx)
+}
+
+func (e *Service) CreateHolidayCalendar(ctx context.Context, req *core.CreateHolidayCalendarRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	return e.holidayRepo.Store(ctx, req.HolidayCalendar)
+}
+
+func (e *Service) FindHolidayCalendarByID(ctx context.Context, req *core.FindHolidayCalendarByIDRequest) (*core.HolidayCalendar, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.holidayRepo.FindByID(ctx, req.ID)
+}
+
+func (e *Service) CreateGroup(ctx context.Context, req *core.CreateGroupRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = e.checkAttendees(ctx, req.Group.MemberIDs)
+	if err != nil {
+		return err
+	}
+
+	err = e.checkSubgroups(ctx, req.Group)
+	if err != nil {
+		return err
+	}
+
+	return e.groupRepo.Store(ctx, req.Group)
+}
+
+func (e *Service) FindGroupByID(ctx context.Context, req *core.FindGroupByIDRequest) (*core.Group, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.groupRepo.FindByID(ctx, req.ID)
+}
+
+// UpdateGroupMembers replaces the direct members of the group, then brings the events following
+// the group in line with its members.
+func (e *Service) UpdateGroupMembers(ctx context.Context, req *core.UpdateGroupMembersRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	group, err := e.groupRepo.FindByID(ctx, req.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if group.CreatedBy != req.ActorID {
+		return internal.WrapErr(internal.ErrPermissionDenied, "only the creator of the group can change its members")
+	}
+
+	// The members already in the group stay in it even once deactivated.
+	err = e.checkAttendees(ctx, slices.DeleteFunc(slices.Clone(req.MemberIDs), func(id int32) bool {
+		return slices.Contains(group.MemberIDs, id)
+	}))
+	if err != nil {
+		return err
+	}
+
+	group.MemberIDs = req.MemberIDs
+	group.SubgroupIDs = req.SubgroupIDs
+	err = group.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = e.checkSubgroups(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	err = e.groupRepo.UpdateMembers(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	return e.syncGroupEvents(ctx, group.ID)
+}
+
+// checkSubgroups fails with internal.ErrValidationFailed when a subgroup of the group is unknown, or
+// already contains the group at any depth, nesting the group in itself.
+func (e *Service) checkSubgroups(ctx context.Context, group *core.Group) error {
+	for _, id := range group.SubgroupIDs {
+		_, err := e.group(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		nested, err := e.groupRepo.FindSubgroupIDs(ctx, id)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(nested, group.ID) {
+			return internal.WrapErr(internal.ErrValidationFailed, "the group "+id+" already contains the group "+group.ID)
+		}
+	}
+	return nil
+}
+
+// syncGroupEvents brings the events following the group, or a group containing it, in line with
+// the members of their synced groups, as long as they have occurrences to come. The new invitations
+// get a token, handed out to the attendees by regenerating it.
+func (e *Service) syncGroupEvents(ctx context.Context, groupID string) error {
+	eventIDs, err := e.groupRepo.FindSyncedEventIDs(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, id := range eventIDs {
+		event, err := e.eventRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		next, err := event.NextOccurrence(now, time.Time{})
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			continue
+		}
+
+		var invited []core.Invitation
+		var withdrawnIDs []string
+		for _, syncedID := range event.SyncedGroupIDs() {
+			memberIDs, err := e.activeMemberIDs(ctx, syncedID)
+			if err != nil {
+				return err
+			}
+
+			newInvitations, withdrawn := event.SyncGroup(syncedID, memberIDs)
+			invited = append(invited, newInvitations...)
+			withdrawnIDs = append(withdrawnIDs, withdrawn...)
+		}
+		if len(invited) == 0 && len(withdrawnIDs) == 0 {
+			continue
+		}
+
+		expiresAt, err := event.InvitationTokenExpiry(now)
+		if err != nil {
+			return err
+		}
+		for i := range invited {
+			err = invited[i].IssueToken(e.tokenSigner, expiresAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = e.invitationRepo.SyncGroup(ctx, invited, withdrawnIDs)
+		if err != nil {
+			return err
+		}
+
+		e.notifyInvited(ctx, event, invited)
+	}
+	return nil
+}
+
+func (e *Service) RespondToInvitation(ctx context.Context, req *core.RespondToInvitationRequest) (*core.Invitation, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = e.tokenSigner.Verify(req.Token, now)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := e.invitationRepo.FindByTokenHash(ctx, core.HashInvitationToken(req.Token))
+	if errors.Is(err, internal.ErrNotFound) {
+		// A valid token missing from the database was revoked by a newer one.
+		return nil, internal.WrapErr(internal.ErrInvalidToken, "revoked invitation token")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IsForOccurrence() {
+		return e.respondToOccurrence(ctx, inv, req, now)
+	}
+
+	if req.Proposal != nil {
+		event, err := e.eventRepo.FindByID(ctx, inv.EventID)
+		if err != nil {
+			return nil, err
+		}
+		if scheduleOf(event, req.Proposal.ScheduleID) == nil {
+			return nil, internal.WrapErr(internal.ErrValidationFailed, "unknown schedule "+req.Proposal.ScheduleID)
+		}
+	}
+
+	var (
+		event    *core.Event
+		promoted []*core.Invitation
+	)
+	err = e.invitationRepo.Respond(ctx, inv.EventID, func(locked *core.Event) ([]*core.Invitation, error) {
+		idx := slices.IndexFunc(locked.Invitations, func(i core.Invitation) bool {
+			return i.ID == inv.ID
+		})
+		if idx < 0 {
+			return nil, internal.WrapErr(internal.ErrNotFound, "invitation")
+		}
+		responded := &locked.Invitations[idx]
+
+		responded.Comment = req.Comment
+		responded.Proposal = req.Proposal
+		promoted = locked.Respond(responded, req.Response, now)
+		event, inv = locked, responded
+		return append([]*core.Invitation{responded}, promoted...), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	e.notifyPromoted(ctx, event, promoted)
+	return inv, nil
+}
+
+// respondToOccurrence stores the response to a single occurrence, the response to the invitation
+// stays the same for the other occurrences.
+func (e *Service) respondToOccurrence(ctx context.Context, inv *core.Invitation, req *core.RespondToInvitationRequest, now time.Time) (*core.Invitation, error) {
+	event, err := e.eventRepo.FindByID(ctx, inv.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = occurringSchedule(event, req.ScheduleID, req.OriginalStart)
+	if err != nil {
+		return nil, err
+	}
+
+	if inv.Status == core.InvitationStatus_Waitlisted && req.Response == core.InvitationStatus_Confirmed {
+		return nil, internal.WrapErr(internal.ErrValidationFailed, "the attendee is waitlisted for the event")
+	}
+
+	resp := core.OccurrenceResponse{
+		InvitationID:  inv.ID,
+		ScheduleID:    req.ScheduleID,
+		OriginalStart: req.OriginalStart.Unix(),
+		Status:        req.Response,
+		Comment:       req.Comment,
+		UpdatedAt:     now,
+	}
+	err = e.invitationRepo.StoreOccurrenceResponse(ctx, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	inv.RespondToOccurrence(resp)
+	return inv, nil
+}
+
+func (e *Service) RegenerateInvitationToken(ctx context.Context, req *core.RegenerateInvitationTokenRequest) (*core.Invitation, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := e.organizedEvent(ctx, req.EventID, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := slices.IndexFunc(event.Invitations, func(inv core.Invitation) bool {
+		return inv.IsFor(req.UserID, req.GuestEmail)
+	})
+	if idx < 0 && req.GuestEmail != "" {
+		return nil, internal.WrapErr(internal.ErrNotFound, "invitation of guest "+req.GuestEmail)
+	}
+	if idx < 0 {
+		return nil, internal.WrapErr(internal.ErrNotFound, fmt.Sprintf("invitation of user %d", req.UserID))
+	}
+	inv := &event.Invitations[idx]
+
+	expiresAt, err := event.InvitationTokenExpiry(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = inv.IssueToken(e.tokenSigner, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.invitationRepo.UpdateTokenHash(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (e *Service) ListProposals(ctx context.Context, req *core.ListProposalsRequest) ([]core.Invitation, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := e.organizedEvent(ctx, req.EventID, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposals []core.Invitation
+	for _, inv := range event.Invitations {
+		if inv.Proposal != nil {
+			proposals = append(proposals, inv)
+		}
+	}
+	return proposals, nil
+}
+
+// AcceptProposal moves the proposed schedule, dropping the proposals made for the previous time.
+// The attendee proposing it is confirmed, or waitlisted when the event is full. The attendees who
+// answered for the previous time are back to pending, with their responses to occurrences dropped,
+// while the organizers and the waitlisted attendees keep their status.
+func (e *Service) AcceptProposal(ctx context.Context, req *core.AcceptProposalRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	event, err := e.organizedEvent(ctx, req.EventID, req.ActorID)
+	if err != nil {
+		return err
+	}
+
+	idx := slices.IndexFunc(event.Invitations, func(inv core.Invitation) bool {
+		return inv.ID == req.InvitationID && inv.Proposal != nil
+	})
+	if idx < 0 {
+		return internal.WrapErr(internal.ErrNotFound, "proposal of invitation "+req.InvitationID)
+	}
+	proposal := event.Invitations[idx].Proposal
+
+	sch := scheduleOf(event, proposal.ScheduleID)
+	if sch == nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "the proposed schedule doesn't exist anymore")
+	}
+	proposal.Apply(sch)
+
+	now := time.Now()
+	for i := range event.Invitations {
+		inv := &event.Invitations[i]
+		inv.Proposal = nil
+		if i == idx || inv.UserID > 0 && event.IsOrganizedBy(strconv.Itoa(int(inv.UserID))) {
+			continue
+		}
+
+		switch inv.Status {
+		case core.InvitationStatus_Confirmed, core.InvitationStatus_Tentative, core.InvitationStatus_Declined:
+			inv.Status = core.InvitationStatus_Unknown
+			inv.Comment = ""
+			inv.OccurrenceResponses = nil
+			inv.UpdatedAt = &now
+		}
+	}
+
+	proposer := &event.Invitations[idx]
+	proposer.Comment = ""
+	proposer.OccurrenceResponses = nil
+	event.Respond(proposer, core.InvitationStatus_Confirmed, now)
+
+	if !req.AllowConflicts {
+		availabilities, err := e.attendeeAvailabilities(ctx, event)
+		if err != nil {
+			return err
+		}
+
+		err = e.checkConflicts(ctx, event, availabilities, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	event.UpdatedAt = &now
+	return e.eventRepo.Update(ctx, event)
+}
+
+// notifyPromoted tells the attendees promoted from the waitlist of the event. The promotion is
+// already stored, so a failing notification is only logged.
+func (e *Service) notifyPromoted(ctx context.Context, event *core.Event, promoted []*core.Invitation) {
+	for _, inv := range promoted {
+		err := e.notifier.NotifyPromoted(ctx, event, inv)
+		if err != nil {
+			slog.Error(err.Error())
+		}
+	}
+}
+
+// notifyInvited hands the tokens just issued to the invitations to their attendees, the other
+// invitations are left out. The invitations are already stored, so a failing notification is only
+// logged.
+func (e *Service) notifyInvited(ctx context.Context, event *core.Event, invitations []core.Invitation) {
+	for i := range invitations {
+		if invitations[i].Token == "" {
+			continue
+		}
+
+		err := e.notifier.NotifyInvited(ctx, event, &invitations[i])
+		if err != nil {
+			slog.Error(err.Error())
+		}
+	}
+}
+
+// organizedEvent returns the event, as long as the actor is one of its organizers.
+func (e *Service) organizedEvent(ctx context.Context, eventID, actorID string) (*core.Event, error) {
+	event, err := e.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !event.IsOrganizedBy(actorID) {
+		return nil, internal.WrapErr(internal.ErrPermissionDenied, "only the organizer of the event can do it")
+	}
+	return event, nil
+}
+
+func scheduleOf(event *core.Event, scheduleID string) *core.Schedule {
+	for i := range event.Schedules {
+		if event.Schedules[i].ID == scheduleID {
+			return &event.Schedules[i]
+		}
+	}
+	return nil
+}
+
+// findOccurrenceException returns the exception of the occurrence, or a new one when the
+// occurrence has none yet. It fails when the schedule has no occurrence at the original start.
+func findOccurrenceException(event *core.Event, scheduleID string, originalStart time.Time) (*core.OccurrenceException, error) {
+	sch, err := occurringSchedule(event, scheduleID, originalStart)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, exc := range sch.Exceptions {
+		if exc.OriginalStart == originalStart.Unix() {
+			return &exc, nil
+		}
+	}
+
+	return &core.OccurrenceException{
+		ScheduleID:    sch.ID,
+		OriginalStart: originalStart.Unix(),
+	}, nil
+}
+
+// occurringSchedule returns the schedule, as long as it has an occurrence at the original start.
+func occurringSchedule(event *core.Event, scheduleID string, originalStart time.Time) (*core.Schedule, error) {
+	sch := scheduleOf(event, scheduleID)
+	if sch == nil {
+		return nil, internal.WrapErr(internal.ErrNotFound, "schedule "+scheduleID)
+	}
+
+	loc, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return nil, internal.WrapErr(internal.ErrInvalidTimezone, event.Timezone)
+	}
+
+	if !sch.HasOccurrenceAt(loc, originalStart) {
+		return nil, internal.WrapErr(internal.ErrValidationFailed, "the schedule has no occurrence at "+originalStart.Format(time.RFC3339))
+	}
+	return sch, nil
+}
+
+// ListMyInvitations lists the invitations of the actor along with the next occurrence of their
+// events, from now or from the start of the requested time range when it's later.
+func (e *Service) ListMyInvitations(ctx context.Context, req *core.ListMyInvitationsRequest) (*core.InvitationPage, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := req.Filter()
+	invitations, err := e.invitationRepo.FindByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &core.InvitationPage{}
+	if pageSize := filter.Limit - 1; len(invitations) > pageSize {
+		invitations = invitations[:pageSize]
+		page.NextPageToken = core.PageToken(filter.Offset + pageSize)
+	}
+
+	from := time.Now()
+	if req.From.After(from) {
+		from = req.From
+	}
+
+	eventIDs := make([]string, 0, len(invitations))
+	for _, inv := range invitations {
+		if !slices.Contains(eventIDs, inv.EventID) {
+			eventIDs = append(eventIDs, inv.EventID)
+		}
+	}
+	events, err := e.eventRepo.FindByIDs(ctx, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, inv := range invitations {
+		i := slices.IndexFunc(events, func(event core.Event) bool { return event.ID == inv.EventID })
+		if i < 0 {
+			return nil, internal.WrapErr(internal.ErrNotFound, "event "+inv.EventID)
+		}
+		event := &events[i]
+		event.HideGuestListFrom(req.ActorID)
+
+		next, err := event.NextOccurrence(from, req.To)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, eventInv := range event.Invitations {
+			if eventInv.ID == inv.ID {
+				inv = eventInv
+			}
+		}
+		page.Summaries = append(page.Summaries, core.InvitationSummary{
+			Invitation:     inv,
+			Event:          event,
+			NextOccurrence: next,
+		})
+	}
+	return page, nil
+}
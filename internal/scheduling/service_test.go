@@ -9,9 +9,11 @@ import (
 	"github.com/dzakaammar/event-scheduling-example/internal"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/notification"
 	"github.com/dzakaammar/event-scheduling-example/internal/scheduling"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewEventService(t *testing.T) {
@@ -19,7 +21,13 @@ func TestNewEventService(t *testing.T) {
 	defer ctrl.Finish()
 
 	type args struct {
-		eventRepo core.EventRepository
+		eventRepo        core.EventRepository
+		availabilityRepo core.AvailabilityRepository
+		resourceRepo     core.ResourceRepository
+		holidayRepo      core.HolidayCalendarRepository
+		invitationRepo   core.InvitationRepository
+		userRepo         core.UserRepository
+		groupRepo        core.GroupRepository
 	}
 	tests := []struct {
 		name string
@@ -28,18 +36,52 @@ func TestNewEventService(t *testing.T) {
 		{
 			name: "OK",
 			args: args{
-				eventRepo: mock.NewMockEventRepository(ctrl),
+				eventRepo:        mock.NewMockEventRepository(ctrl),
+				availabilityRepo: mock.NewMockAvailabilityRepository(ctrl),
+				resourceRepo:     mock.NewMockResourceRepository(ctrl),
+				holidayRepo:      mock.NewMockHolidayCalendarRepository(ctrl),
+				invitationRepo:   mock.NewMockInvitationRepository(ctrl),
+				userRepo:         mock.NewMockUserRepository(ctrl),
+				groupRepo:        mock.NewMockGroupRepository(ctrl),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := scheduling.NewService(tt.args.eventRepo)
+			got := scheduling.NewService(tt.args.eventRepo, tt.args.availabilityRepo, tt.args.resourceRepo, tt.args.holidayRepo, tt.args.invitationRepo, tt.args.userRepo, tt.args.groupRepo, tokenSigner, notification.Nop{})
 			assert.NotNil(t, got)
 		})
 	}
 }
 
+// tokenSigner signs the invitation tokens issued by the service.
+var tokenSigner, _ = core.NewInvitationTokenSigner(internal.InvitationTokenConfig{
+	KeyID:  "test",
+	Secret: "test-invitation-token-secret-of-32-bytes",
+})
+
+// noAvailabilities returns a repository knowing no availability, leaving the users without
+// working hours nor default buffers.
+func noAvailabilities(ctrl *gomock.Controller) core.AvailabilityRepository {
+	repo := mock.NewMockAvailabilityRepository(ctrl)
+	repo.EXPECT().FindByUserIDs(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+	return repo
+}
+
+// activeUsers returns a repository finding every user asked for, all of them active.
+func activeUsers(ctrl *gomock.Controller) core.UserRepository {
+	repo := mock.NewMockUserRepository(ctrl)
+	repo.EXPECT().FindByIDs(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, ids []int32) ([]core.User, error) {
+			users := make([]core.User, len(ids))
+			for i, id := range ids {
+				users[i] = core.User{ID: id, Name: "user", Timezone: "UTC"}
+			}
+			return users, nil
+		})
+	return repo
+}
+
 func TestEventService_CreateEvent(t *testing.T) {
 	type fields struct {
 		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
@@ -176,14 +218,47 @@ func TestEventService_CreateEvent(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Not OK - until and count",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.CreateEventRequest{
+					ActorID: "123",
+					Event: &core.Event{
+						ID:          "123",
+						Title:       "test",
+						Description: "test123",
+						Timezone:    "Asia/Jakarta",
+						Schedules: []core.Schedule{
+							{
+								ID:                "test123",
+								EventID:           "123",
+								StartTime:         time.Now().Unix(),
+								DurationInMinutes: 120,
+								RecurringType:     core.RecurringType_Daily,
+								RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1},
+								Until:             time.Now().AddDate(0, 1, 0).Unix(),
+								Count:             3,
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl))
-			err := e.CreateEvent(tt.args.ctx, tt.args.req)
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+			_, err := e.CreateEvent(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -291,7 +366,7 @@ func TestEventService_DeleteEventByID(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl))
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
 			err := e.DeleteEventByID(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -321,6 +396,8 @@ func TestEventService_UpdateEvent(t *testing.T) {
 			fields: fields{
 				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
 					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "test123").Times(1).Return(&core.Event{ID: "test123", Timezone: "Asia/Jakarta", CreatedBy: "test123"}, nil)
+					repo.EXPECT().FindByAttendees(gomock.Any(), []int32{2}, gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
 					repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
 						Return(nil)
 					return repo
@@ -356,11 +433,11 @@ func TestEventService_UpdateEvent(t *testing.T) {
 						},
 						Invitations: []core.Invitation{
 							{
-								ID:      "inv1",
-								EventID: "test123",
-								UserID:  2,
-								Status:  core.InvitationStatus_Confirmed,
-								Token:   "123",
+								ID:        "inv1",
+								EventID:   "test123",
+								UserID:    2,
+								Status:    core.InvitationStatus_Confirmed,
+								TokenHash: "123",
 							},
 						},
 					},
@@ -372,6 +449,8 @@ func TestEventService_UpdateEvent(t *testing.T) {
 			fields: fields{
 				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
 					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "test123").Times(1).Return(&core.Event{ID: "test123", Timezone: "Asia/Jakarta", CreatedBy: "test123"}, nil)
+					repo.EXPECT().FindByAttendees(gomock.Any(), []int32{2}, gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
 					repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
 						Return(internal.ErrInvalidRequest)
 					return repo
@@ -407,11 +486,11 @@ func TestEventService_UpdateEvent(t *testing.T) {
 						},
 						Invitations: []core.Invitation{
 							{
-								ID:      "inv1",
-								EventID: "test123",
-								UserID:  2,
-								Status:  core.InvitationStatus_Confirmed,
-								Token:   "123",
+								ID:        "inv1",
+								EventID:   "test123",
+								UserID:    2,
+								Status:    core.InvitationStatus_Confirmed,
+								TokenHash: "123",
 							},
 						},
 					},
@@ -443,7 +522,7 @@ func TestEventService_UpdateEvent(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl))
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
 			err := e.UpdateEvent(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -454,6 +533,177 @@ func TestEventService_UpdateEvent(t *testing.T) {
 	}
 }
 
+func TestEventService_UpdateEvent_Permissions(t *testing.T) {
+	start := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+	stored := func() *core.Event {
+		return &core.Event{
+			ID:          "123",
+			Title:       "planning",
+			Description: "planning",
+			Timezone:    "UTC",
+			CreatedBy:   "1",
+			Schedules:   []core.Schedule{{ID: "sch1", EventID: "123", StartTime: start.Unix(), DurationInMinutes: 60}},
+			Invitations: []core.Invitation{
+				{ID: "inv1", EventID: "123", UserID: 2, Status: core.InvitationStatus_Confirmed, TokenHash: "inv1", Permissions: core.AttendeePermissions{CanModifyEvent: true}},
+				{ID: "inv2", EventID: "123", UserID: 3, Status: core.InvitationStatus_Declined, TokenHash: "inv2"},
+			},
+		}
+	}
+	newReq := func(actorID string) *core.UpdateEventRequest {
+		event := stored()
+		event.Title = "retro"
+		event.Invitations = []core.Invitation{core.NewInvitation("123", 2), core.NewInvitation("123", 3)}
+		event.Invitations[0].Permissions = core.AttendeePermissions{CanModifyEvent: true}
+		event.Invitations[1].Permissions = core.AttendeePermissions{}
+		return &core.UpdateEventRequest{ID: "123", ActorID: actorID, Event: event, AllowConflicts: true}
+	}
+
+	t.Run("OK - attendee allowed to modify", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(stored(), nil)
+		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, event *core.Event) error {
+				// The attendees keep their invitations.
+				assert.Equal(t, "retro", event.Title)
+				assert.Equal(t, "inv1", event.Invitations[0].ID)
+				assert.Equal(t, core.InvitationStatus_Confirmed, event.Invitations[0].Status)
+				assert.Equal(t, "inv2", event.Invitations[1].TokenHash)
+				return nil
+			})
+
+		e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		assert.NoError(t, e.UpdateEvent(t.Context(), newReq("2")))
+	})
+
+	t.Run("Not OK - attendee without permission", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(stored(), nil)
+
+		e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		assert.ErrorIs(t, e.UpdateEvent(t.Context(), newReq("3")), internal.ErrPermissionDenied)
+	})
+}
+
+func TestEventService_UpdateEvent_KeptSchedules(t *testing.T) {
+	start := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockEventRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(&core.Event{
+		ID:        "123",
+		Title:     "planning",
+		Timezone:  "UTC",
+		CreatedBy: "1",
+		Schedules: []core.Schedule{
+			{ID: "sch1", EventID: "123", StartTime: start.Unix(), DurationInMinutes: 60},
+			{
+				ID: "sch2", EventID: "123", StartTime: start.AddDate(0, 0, 1).Unix(), DurationInMinutes: 60,
+				Exceptions: []core.OccurrenceException{{ScheduleID: "sch2", OriginalStart: start.AddDate(0, 0, 1).Unix(), IsCancelled: true}},
+			},
+		},
+		Invitations: []core.Invitation{
+			{
+				ID: "inv1", EventID: "123", UserID: 2, Status: core.InvitationStatus_Confirmed, Permissions: core.DefaultAttendeePermissions,
+				OccurrenceResponses: []core.OccurrenceResponse{
+					{InvitationID: "inv1", ScheduleID: "sch1", OriginalStart: start.Unix(), Status: core.InvitationStatus_Declined},
+					{InvitationID: "inv1", ScheduleID: "sch2", OriginalStart: start.AddDate(0, 0, 1).Unix(), Status: core.InvitationStatus_Declined},
+				},
+			},
+		},
+	}, nil)
+	repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, event *core.Event) error {
+			// The unchanged schedule is kept along with its exceptions, even under another ID.
+			require.Len(t, event.Schedules, 1)
+			assert.Equal(t, "sch2", event.Schedules[0].ID)
+			assert.Len(t, event.Schedules[0].Exceptions, 1)
+			// The responses to the occurrences of the dropped schedule go with it.
+			require.Len(t, event.Invitations[0].OccurrenceResponses, 1)
+			assert.Equal(t, "sch2", event.Invitations[0].OccurrenceResponses[0].ScheduleID)
+			return nil
+		})
+
+	invitation := core.NewInvitation("123", 2)
+	e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+	err := e.UpdateEvent(t.Context(), &core.UpdateEventRequest{
+		ID:      "123",
+		ActorID: "1",
+		Event: &core.Event{
+			ID:          "123",
+			Title:       "planning",
+			Description: "planning",
+			Timezone:    "UTC",
+			CreatedBy:   "1",
+			Schedules:   []core.Schedule{{ID: "new", EventID: "123", StartTime: start.AddDate(0, 0, 1).Unix(), DurationInMinutes: 60}},
+			Invitations: []core.Invitation{invitation},
+		},
+		AllowConflicts: true,
+	})
+	assert.NoError(t, err)
+}
+
+func TestEventService_CheckAttendees(t *testing.T) {
+	start := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+	deactivatedAt := start.AddDate(0, 0, -7)
+	newEvent := func(userIDs ...int32) *core.Event {
+		event := &core.Event{
+			ID:          "123",
+			Title:       "planning",
+			Description: "planning",
+			Timezone:    "UTC",
+			CreatedBy:   "1",
+			Schedules:   []core.Schedule{{ID: "sch1", EventID: "123", StartTime: start.Unix(), DurationInMinutes: 60}},
+		}
+		for _, id := range userIDs {
+			event.Invitations = append(event.Invitations, core.NewInvitation("123", id))
+		}
+		return event
+	}
+
+	t.Run("Not OK - creating with unknown and deactivated attendees", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepo := mock.NewMockUserRepository(ctrl)
+		userRepo.EXPECT().FindByIDs(gomock.Any(), []int32{2, 8, 9}).Times(1).Return([]core.User{
+			{ID: 2, Name: "Bar", Timezone: "UTC"},
+			{ID: 9, Name: "Baz", Timezone: "UTC", DeactivatedAt: &deactivatedAt},
+		}, nil)
+
+		e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), userRepo, mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		_, err := e.CreateEvent(t.Context(), &core.CreateEventRequest{ActorID: "1", Event: newEvent(2, 8, 9), AllowConflicts: true})
+		assert.ErrorIs(t, err, internal.ErrValidationFailed)
+		assert.ErrorContains(t, err, "unknown attendees 8; deactivated attendees 9")
+	})
+
+	t.Run("Not OK - updating with a new deactivated attendee", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stored := newEvent(3)
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(stored, nil)
+
+		// The attendee already invited isn't checked, even once deactivated.
+		userRepo := mock.NewMockUserRepository(ctrl)
+		userRepo.EXPECT().FindByIDs(gomock.Any(), []int32{4}).Times(1).Return([]core.User{
+			{ID: 4, Name: "Baz", Timezone: "UTC", DeactivatedAt: &deactivatedAt},
+		}, nil)
+
+		e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), userRepo, mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.UpdateEvent(t.Context(), &core.UpdateEventRequest{ID: "123", ActorID: "1", Event: newEvent(3, 4), AllowConflicts: true})
+		assert.ErrorIs(t, err, internal.ErrValidationFailed)
+		assert.ErrorContains(t, err, "deactivated attendees 4")
+	})
+}
+
 func TestEventService_FindEventByID(t *testing.T) {
 	type fields struct {
 		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
@@ -492,18 +742,18 @@ func TestEventService_FindEventByID(t *testing.T) {
 							},
 							Invitations: []core.Invitation{
 								{
-									ID:      "invitation1",
-									EventID: "123",
-									UserID:  2,
-									Status:  core.InvitationStatus_Unknown,
-									Token:   "123",
+									ID:        "invitation1",
+									EventID:   "123",
+									UserID:    2,
+									Status:    core.InvitationStatus_Unknown,
+									TokenHash: "123",
 								},
 								{
-									ID:      "invitation2",
-									EventID: "123",
-									UserID:  3,
-									Status:  core.InvitationStatus_Unknown,
-									Token:   "123",
+									ID:        "invitation2",
+									EventID:   "123",
+									UserID:    3,
+									Status:    core.InvitationStatus_Unknown,
+									TokenHash: "123",
 								},
 							},
 						}, nil)
@@ -533,23 +783,56 @@ func TestEventService_FindEventByID(t *testing.T) {
 				},
 				Invitations: []core.Invitation{
 					{
-						ID:      "invitation1",
-						EventID: "123",
-						UserID:  2,
-						Status:  core.InvitationStatus_Unknown,
-						Token:   "123",
+						ID:        "invitation1",
+						EventID:   "123",
+						UserID:    2,
+						Status:    core.InvitationStatus_Unknown,
+						TokenHash: "123",
 					},
 					{
-						ID:      "invitation2",
-						EventID: "123",
-						UserID:  3,
-						Status:  core.InvitationStatus_Unknown,
-						Token:   "123",
+						ID:        "invitation2",
+						EventID:   "123",
+						UserID:    3,
+						Status:    core.InvitationStatus_Unknown,
+						TokenHash: "123",
 					},
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "OK - guest list hidden",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Times(1).
+						Return(&core.Event{
+							ID:        "123",
+							CreatedBy: "1",
+							Invitations: []core.Invitation{
+								{ID: "invitation1", EventID: "123", UserID: 2},
+								{ID: "invitation2", EventID: "123", UserID: 3},
+							},
+						}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.FindEventByIDRequest{
+					ActorID: "2",
+					EventID: "123",
+				},
+			},
+			want: &core.Event{
+				ID:        "123",
+				CreatedBy: "1",
+				Invitations: []core.Invitation{
+					{ID: "invitation1", EventID: "123", UserID: 2},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Not OK - error from repo",
 			fields: fields{
@@ -592,7 +875,7 @@ func TestEventService_FindEventByID(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl))
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
 			got, err := e.FindEventByID(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -602,3 +885,2380 @@ func TestEventService_FindEventByID(t *testing.T) {
 		})
 	}
 }
+
+func TestEventService_ListOccurrences(t *testing.T) {
+	type fields struct {
+		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.ListOccurrencesRequest
+	}
+
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).
+						Return(&core.Event{
+							ID:       "123",
+							Timezone: "Asia/Jakarta",
+							Schedules: []core.Schedule{
+								{
+									ID:                "sch1",
+									EventID:           "123",
+									StartTime:         start.Unix(),
+									DurationInMinutes: 60,
+									RecurringType:     core.RecurringType_Daily,
+									RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1},
+								},
+							},
+						}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.ListOccurrencesRequest{
+					EventID: "123",
+					From:    start,
+					To:      start.AddDate(0, 0, 7),
+				},
+			},
+			want: 7,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).
+						Return(nil, internal.ErrInvalidRequest)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.ListOccurrencesRequest{
+					EventID: "123",
+					From:    start,
+					To:      start.AddDate(0, 0, 7),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not OK - invalid time range",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.ListOccurrencesRequest{
+					EventID: "123",
+					From:    start,
+					To:      start,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+			got, err := e.ListOccurrences(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got.Occurrences, tt.want)
+			assert.False(t, got.Truncated)
+		})
+	}
+}
+
+func TestEventService_CancelOccurrence(t *testing.T) {
+	type fields struct {
+		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.CancelOccurrenceRequest
+	}
+
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	event := &core.Event{
+		ID:        "123",
+		Timezone:  "Asia/Jakarta",
+		CreatedBy: "1",
+		Invitations: []core.Invitation{
+			{ID: "inv2", EventID: "123", UserID: 2, Permissions: core.DefaultAttendeePermissions},
+		},
+		Schedules: []core.Schedule{
+			{
+				ID:                "sch1",
+				EventID:           "123",
+				StartTime:         start.Unix(),
+				DurationInMinutes: 60,
+				RecurringType:     core.RecurringType_Daily,
+				RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1},
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(event, nil)
+					repo.EXPECT().StoreOccurrenceException(gomock.Any(), gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, exc *core.OccurrenceException) error {
+							assert.Equal(t, "sch1", exc.ScheduleID)
+							assert.Equal(t, start.AddDate(0, 0, 2).Unix(), exc.OriginalStart)
+							assert.True(t, exc.IsCancelled)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.CancelOccurrenceRequest{
+					ActorID:       "1",
+					EventID:       "123",
+					ScheduleID:    "sch1",
+					OriginalStart: start.AddDate(0, 0, 2),
+				},
+			},
+		},
+		{
+			name: "Not OK - unknown schedule",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(event, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.CancelOccurrenceRequest{
+					ActorID:       "1",
+					EventID:       "123",
+					ScheduleID:    "sch2",
+					OriginalStart: start,
+				},
+			},
+			wantErr: internal.ErrNotFound,
+		},
+		{
+			name: "Not OK - no occurrence at the original start",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(event, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.CancelOccurrenceRequest{
+					ActorID:       "1",
+					EventID:       "123",
+					ScheduleID:    "sch1",
+					OriginalStart: start.Add(time.Hour),
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - attendee not allowed to modify the event",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(event, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.CancelOccurrenceRequest{
+					ActorID:       "2",
+					EventID:       "123",
+					ScheduleID:    "sch1",
+					OriginalStart: start.AddDate(0, 0, 2),
+				},
+			},
+			wantErr: internal.ErrPermissionDenied,
+		},
+		{
+			name: "Not OK - invalid request",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.CancelOccurrenceRequest{
+					EventID:    "123",
+					ScheduleID: "sch1",
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+			err := e.CancelOccurrence(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventService_UpdateOccurrence(t *testing.T) {
+	type fields struct {
+		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.UpdateOccurrenceRequest
+	}
+
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	newEvent := func() *core.Event {
+		return &core.Event{
+			ID:        "123",
+			Timezone:  "Asia/Jakarta",
+			CreatedBy: "1",
+			Schedules: []core.Schedule{
+				{
+					ID:                "sch1",
+					EventID:           "123",
+					StartTime:         start.Unix(),
+					DurationInMinutes: 60,
+					RecurringType:     core.RecurringType_Daily,
+					RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1},
+					Exceptions: []core.OccurrenceException{
+						{ScheduleID: "sch1", OriginalStart: start.Unix(), Title: "kept"},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *core.OccurrenceException
+		wantErr error
+	}{
+		{
+			name: "OK - move the occurrence",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(newEvent(), nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.UpdateOccurrenceRequest{
+					ActorID:       "1",
+					EventID:       "123",
+					ScheduleID:    "sch1",
+					OriginalStart: start.AddDate(0, 0, 1),
+					Start:         start.AddDate(0, 0, 1).Add(3 * time.Hour),
+					End:           start.AddDate(0, 0, 1).Add(5 * time.Hour),
+				},
+			},
+			want: &core.OccurrenceException{
+				ScheduleID:        "sch1",
+				OriginalStart:     start.AddDate(0, 0, 1).Unix(),
+				StartTime:         start.AddDate(0, 0, 1).Add(3 * time.Hour).Unix(),
+				DurationInMinutes: 120,
+			},
+		},
+		{
+			name: "OK - update the existing exception",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(newEvent(), nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.UpdateOccurrenceRequest{
+					ActorID:       "1",
+					EventID:       "123",
+					ScheduleID:    "sch1",
+					OriginalStart: start,
+					Description:   "changed",
+				},
+			},
+			want: &core.OccurrenceException{
+				ScheduleID:    "sch1",
+				OriginalStart: start.Unix(),
+				Title:         "kept",
+				Description:   "changed",
+			},
+		},
+		{
+			name: "Not OK - end before start",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.UpdateOccurrenceRequest{
+					ActorID:       "1",
+					EventID:       "123",
+					ScheduleID:    "sch1",
+					OriginalStart: start,
+					Start:         start,
+					End:           start.Add(-time.Hour),
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - not an organizer",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(newEvent(), nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.UpdateOccurrenceRequest{
+					ActorID:       "2",
+					EventID:       "123",
+					ScheduleID:    "sch1",
+					OriginalStart: start,
+					Description:   "changed",
+				},
+			},
+			wantErr: internal.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := tt.fields.eventRepoMock(ctrl)
+			if tt.want != nil {
+				repo.(*mock.MockEventRepository).EXPECT().StoreOccurrenceException(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, exc *core.OccurrenceException) error {
+						exc.UpdatedAt = time.Time{}
+						assert.Equal(t, tt.want, exc)
+						return nil
+					})
+			}
+
+			e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+			err := e.UpdateOccurrence(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventService_UpdateOccurrence_Conflicts(t *testing.T) {
+	start := time.Date(2099, 1, 6, 2, 0, 0, 0, time.UTC)
+	event := &core.Event{
+		ID:          "123",
+		Timezone:    "UTC",
+		CreatedBy:   "1",
+		Invitations: []core.Invitation{{ID: "inv2", EventID: "123", UserID: 2}},
+		Schedules: []core.Schedule{
+			{ID: "sch1", EventID: "123", StartTime: start.Unix(), DurationInMinutes: 60, RecurrenceRule: core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1}},
+		},
+	}
+	// The attendee is busy when the second occurrence is moved to.
+	busy := core.Event{
+		ID:          "456",
+		Timezone:    "UTC",
+		Invitations: []core.Invitation{{ID: "inv3", EventID: "456", UserID: 2}},
+		Schedules: []core.Schedule{
+			{ID: "sch2", EventID: "456", StartTime: start.AddDate(0, 0, 1).Add(3 * time.Hour).Unix(), DurationInMinutes: 60},
+		},
+	}
+	req := func(allowConflicts bool) *core.UpdateOccurrenceRequest {
+		return &core.UpdateOccurrenceRequest{
+			ActorID:        "1",
+			EventID:        "123",
+			ScheduleID:     "sch1",
+			OriginalStart:  start.AddDate(0, 0, 1),
+			Start:          start.AddDate(0, 0, 1).Add(3 * time.Hour),
+			End:            start.AddDate(0, 0, 1).Add(4 * time.Hour),
+			AllowConflicts: allowConflicts,
+		}
+	}
+
+	t.Run("Not OK - conflict", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(event, nil)
+		repo.EXPECT().FindByAttendees(gomock.Any(), []int32{2}, gomock.Any(), gomock.Any()).Times(1).Return([]core.Event{busy}, nil)
+
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.UpdateOccurrence(t.Context(), req(false))
+		var conflictErr *core.ConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+	})
+
+	t.Run("OK - conflicts allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(event, nil)
+		repo.EXPECT().StoreOccurrenceException(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		assert.NoError(t, e.UpdateOccurrence(t.Context(), req(true)))
+	})
+}
+
+func TestEventService_UpdateEvent_Scope(t *testing.T) {
+	// Tuesday, 4 January 2022 09:00 in Asia/Jakarta, repeating weekly 10 times.
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	stored := func() *core.Event {
+		return &core.Event{
+			ID:          "123",
+			Title:       "weekly sync",
+			Description: "weekly sync",
+			Timezone:    "Asia/Jakarta",
+			CreatedBy:   "1",
+			Schedules: []core.Schedule{
+				{
+					ID:                "sch1",
+					EventID:           "123",
+					StartTime:         start.Unix(),
+					DurationInMinutes: 60,
+					RecurringType:     core.RecurringType_Custom,
+					RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1, Count: 10},
+				},
+			},
+			Invitations: []core.Invitation{
+				{ID: "inv1", EventID: "123", UserID: 2, Status: core.InvitationStatus_Confirmed, TokenHash: "inv1"},
+				{ID: "inv2", EventID: "123", UserID: 3, Status: core.InvitationStatus_Declined, TokenHash: "inv2"},
+			},
+		}
+	}
+	newReq := func(scope core.UpdateScope, occurrenceStart, newStart time.Time, title string) *core.UpdateEventRequest {
+		return &core.UpdateEventRequest{
+			ID:      "123",
+			ActorID: "1",
+			Event: &core.Event{
+				ID:          "123",
+				Title:       title,
+				Description: "weekly sync",
+				Timezone:    "Asia/Jakarta",
+				Schedules: []core.Schedule{
+					{
+						ID:                "new",
+						EventID:           "123",
+						StartTime:         newStart.Unix(),
+						DurationInMinutes: 60,
+						RecurringType:     core.RecurringType_Custom,
+						RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1, Count: 10},
+					},
+				},
+			},
+			Scope:           scope,
+			ScheduleID:      "sch1",
+			OccurrenceStart: occurrenceStart,
+		}
+	}
+
+	t.Run("THIS_OCCURRENCE stores an exception", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		occurrence := start.AddDate(0, 0, 7)
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(stored(), nil)
+		repo.EXPECT().FindByAttendees(gomock.Any(), []int32{2}, gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		repo.EXPECT().StoreOccurrenceException(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, exc *core.OccurrenceException) error {
+				assert.Equal(t, "sch1", exc.ScheduleID)
+				assert.Equal(t, occurrence.Unix(), exc.OriginalStart)
+				assert.Equal(t, occurrence.Add(time.Hour).Unix(), exc.StartTime)
+				assert.Equal(t, "moved sync", exc.Title)
+				assert.Empty(t, exc.Description)
+				return nil
+			})
+
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.UpdateEvent(t.Context(), newReq(core.UpdateScope_ThisOccurrence, occurrence, occurrence.Add(time.Hour), "moved sync"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("THIS_AND_FOLLOWING splits the series", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		split := start.AddDate(0, 0, 21)
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(stored(), nil)
+		// The original event is ignored when checking the conflicts of the following series.
+		repo.EXPECT().FindByAttendees(gomock.Any(), []int32{2}, gomock.Any(), gomock.Any()).Times(1).Return([]core.Event{*stored()}, nil)
+		repo.EXPECT().SplitSeries(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, event *core.Event, following *core.Event) error {
+				assert.Equal(t, "123", event.ID)
+				assert.Equal(t, 3, event.Schedules[0].RecurrenceRule.Count)
+
+				assert.NotEqual(t, "123", following.ID)
+				assert.Equal(t, "123", *following.ParentEventID)
+				assert.Equal(t, "1", following.CreatedBy)
+				assert.Equal(t, "later sync", following.Title)
+				assert.Len(t, following.Schedules, 1)
+				assert.Equal(t, following.ID, following.Schedules[0].EventID)
+				assert.Equal(t, 7, following.Schedules[0].RecurrenceRule.Count)
+				assert.Len(t, following.Invitations, 2)
+				for _, inv := range following.Invitations {
+					assert.Equal(t, following.ID, inv.EventID)
+					assert.NotEqual(t, "inv1", inv.ID)
+				}
+				assert.Equal(t, core.InvitationStatus_Confirmed, following.Invitations[0].Status)
+				assert.Equal(t, core.InvitationStatus_Declined, following.Invitations[1].Status)
+				return nil
+			})
+
+		req := newReq(core.UpdateScope_ThisAndFollowing, split, split.Add(2*time.Hour), "later sync")
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.UpdateEvent(t.Context(), req)
+		assert.NoError(t, err)
+		assert.NotEqual(t, "123", req.Event.ID)
+	})
+
+	t.Run("THIS_AND_FOLLOWING carries the overrides following the split point", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		split := start.AddDate(0, 0, 21)
+		before := start.AddDate(0, 0, 7).Unix()
+		after := start.AddDate(0, 0, 35).Unix()
+		event := stored()
+		event.Schedules[0].Exceptions = []core.OccurrenceException{
+			{ScheduleID: "sch1", OriginalStart: before, IsCancelled: true},
+			{ScheduleID: "sch1", OriginalStart: after, Title: "offsite sync"},
+		}
+		event.Invitations[0].OccurrenceResponses = []core.OccurrenceResponse{
+			{InvitationID: "inv1", ScheduleID: "sch1", OriginalStart: before, Status: core.InvitationStatus_Declined},
+			{InvitationID: "inv1", ScheduleID: "sch1", OriginalStart: after, Status: core.InvitationStatus_Tentative},
+		}
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(event, nil)
+		repo.EXPECT().FindByAttendees(gomock.Any(), []int32{2}, gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		repo.EXPECT().SplitSeries(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, event *core.Event, following *core.Event) error {
+				assert.Equal(t, []core.OccurrenceException{{ScheduleID: "sch1", OriginalStart: before, IsCancelled: true}}, event.Schedules[0].Exceptions)
+				assert.Equal(t, []core.OccurrenceResponse{
+					{InvitationID: "inv1", ScheduleID: "sch1", OriginalStart: before, Status: core.InvitationStatus_Declined},
+				}, event.Invitations[0].OccurrenceResponses)
+
+				schedule := following.Schedules[0]
+				assert.Equal(t, []core.OccurrenceException{{ScheduleID: schedule.ID, OriginalStart: after, Title: "offsite sync"}}, schedule.Exceptions)
+				assert.Equal(t, []core.OccurrenceResponse{
+					{InvitationID: following.Invitations[0].ID, ScheduleID: schedule.ID, OriginalStart: after, Status: core.InvitationStatus_Tentative},
+				}, following.Invitations[0].OccurrenceResponses)
+				assert.Empty(t, following.Invitations[1].OccurrenceResponses)
+				return nil
+			})
+
+		req := newReq(core.UpdateScope_ThisAndFollowing, split, split, "later sync")
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.UpdateEvent(t.Context(), req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("THIS_AND_FOLLOWING drops the overrides of the moved occurrences", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		split := start.AddDate(0, 0, 21)
+		after := start.AddDate(0, 0, 35).Unix()
+		event := stored()
+		event.Schedules[0].Exceptions = []core.OccurrenceException{{ScheduleID: "sch1", OriginalStart: after, IsCancelled: true}}
+		event.Invitations[0].OccurrenceResponses = []core.OccurrenceResponse{
+			{InvitationID: "inv1", ScheduleID: "sch1", OriginalStart: after, Status: core.InvitationStatus_Declined},
+		}
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(event, nil)
+		repo.EXPECT().FindByAttendees(gomock.Any(), []int32{2}, gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		repo.EXPECT().SplitSeries(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, event *core.Event, following *core.Event) error {
+				assert.Empty(t, event.Schedules[0].Exceptions)
+				assert.Empty(t, event.Invitations[0].OccurrenceResponses)
+				assert.Empty(t, following.Schedules[0].Exceptions)
+				assert.Empty(t, following.Invitations[0].OccurrenceResponses)
+				return nil
+			})
+
+		// The following occurrences start two hours later, none of them is the one overridden.
+		req := newReq(core.UpdateScope_ThisAndFollowing, split, split.Add(2*time.Hour), "later sync")
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.UpdateEvent(t.Context(), req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("THIS_AND_FOLLOWING keeps the capacity and promotes the waitlist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		waitlistedAt := start.AddDate(0, 0, -1)
+		event := stored()
+		event.MaxAttendees = 1
+		event.Invitations = append(event.Invitations, core.Invitation{
+			ID: "inv3", EventID: "123", UserID: 4, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &waitlistedAt, TokenHash: "inv3",
+		})
+
+		split := start.AddDate(0, 0, 21)
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(event, nil)
+		repo.EXPECT().FindByAttendees(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		repo.EXPECT().SplitSeries(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ *core.Event, following *core.Event) error {
+				assert.Equal(t, int32(2), following.MaxAttendees)
+				require.Len(t, following.Invitations, 3)
+				assert.Equal(t, core.InvitationStatus_Confirmed, following.Invitations[2].Status)
+				assert.Nil(t, following.Invitations[2].WaitlistedAt)
+				return nil
+			})
+
+		notifier := mock.NewMockNotifier(ctrl)
+		notifier.EXPECT().NotifyInvited(gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+		notifier.EXPECT().NotifyPromoted(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ *core.Event, inv *core.Invitation) error {
+				assert.Equal(t, int32(4), inv.UserID)
+				return nil
+			})
+
+		// The following series accepts one more attendee, the waitlisted one takes the place.
+		req := newReq(core.UpdateScope_ThisAndFollowing, split, split, "later sync")
+		req.Event.MaxAttendees = 2
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notifier)
+		err := e.UpdateEvent(t.Context(), req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("THIS_AND_FOLLOWING from the first occurrence updates the whole series", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(stored(), nil)
+		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		req := newReq(core.UpdateScope_ThisAndFollowing, start, start.Add(time.Hour), "weekly sync")
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.UpdateEvent(t.Context(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, "123", req.Event.ID)
+	})
+
+	t.Run("Not OK - no occurrence at the split point", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(stored(), nil)
+
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.UpdateEvent(t.Context(), newReq(core.UpdateScope_ThisAndFollowing, start.Add(time.Hour), start, "weekly sync"))
+		assert.ErrorIs(t, err, internal.ErrValidationFailed)
+	})
+
+	t.Run("Not OK - missing occurrence", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.UpdateEvent(t.Context(), newReq(core.UpdateScope_ThisOccurrence, time.Time{}, start, "weekly sync"))
+		assert.ErrorIs(t, err, internal.ErrValidationFailed)
+	})
+}
+
+func TestEventService_CreateEvent_Conflicts(t *testing.T) {
+	start := time.Date(2030, 1, 8, 2, 0, 0, 0, time.UTC)
+	newReq := func(allowConflicts bool) *core.CreateEventRequest {
+		return &core.CreateEventRequest{
+			ActorID: "1",
+			Event: &core.Event{
+				ID:          "new",
+				Title:       "planning",
+				Description: "planning",
+				Timezone:    "Asia/Jakarta",
+				Schedules: []core.Schedule{
+					{ID: "sch1", EventID: "new", StartTime: start.AddDate(0, 0, 7).Unix(), DurationInMinutes: 60},
+				},
+				Invitations: []core.Invitation{
+					{ID: "inv1", EventID: "new", UserID: 2, TokenHash: "inv1"},
+				},
+			},
+			AllowConflicts: allowConflicts,
+		}
+	}
+	booked := []core.Event{
+		{
+			ID:       "standup",
+			Title:    "standup",
+			Timezone: "Asia/Jakarta",
+			Schedules: []core.Schedule{
+				{
+					ID:                "sch2",
+					EventID:           "standup",
+					StartTime:         start.Unix(),
+					DurationInMinutes: 30,
+					RecurrenceRule:    core.RecurrenceRule{Freq: core.Frequency_Daily, Interval: 1},
+				},
+			},
+			Invitations: []core.Invitation{
+				{ID: "inv2", EventID: "standup", UserID: 2, Status: core.InvitationStatus_Confirmed, TokenHash: "inv2"},
+			},
+		},
+	}
+
+	t.Run("Not OK - attendee already booked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByAttendees(gomock.Any(), []int32{2}, gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ []int32, from, to time.Time) ([]core.Event, error) {
+				assert.True(t, from.Equal(start.AddDate(0, 0, 7).Add(-core.MaxBuffer)))
+				assert.Equal(t, core.ConflictHorizon+2*core.MaxBuffer, to.Sub(from))
+				return booked, nil
+			})
+
+		availabilityRepo := mock.NewMockAvailabilityRepository(ctrl)
+		availabilityRepo.EXPECT().FindByUserIDs(gomock.Any(), []int32{2}).Times(1).Return(nil, nil)
+
+		e := scheduling.NewService(repo, availabilityRepo, mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		_, err := e.CreateEvent(t.Context(), newReq(false))
+
+		var conflictErr *core.ConflictError
+		assert.ErrorIs(t, err, internal.ErrConflict)
+		assert.True(t, errors.As(err, &conflictErr))
+		assert.Equal(t, "standup", conflictErr.Conflicts[0].EventID)
+		assert.Equal(t, []int32{2}, conflictErr.Conflicts[0].UserIDs)
+	})
+
+	t.Run("OK - conflicts are allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		availabilityRepo := mock.NewMockAvailabilityRepository(ctrl)
+		availabilityRepo.EXPECT().FindByUserIDs(gomock.Any(), []int32{2}).Times(1).Return(nil, nil)
+
+		e := scheduling.NewService(repo, availabilityRepo, mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		_, err := e.CreateEvent(t.Context(), newReq(true))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Not OK - error from repo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByAttendees(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			Return(nil, errors.New("error")) //nolint:goerr113
+
+		availabilityRepo := mock.NewMockAvailabilityRepository(ctrl)
+		availabilityRepo.EXPECT().FindByUserIDs(gomock.Any(), []int32{2}).Times(1).Return(nil, nil)
+
+		e := scheduling.NewService(repo, availabilityRepo, mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		_, err := e.CreateEvent(t.Context(), newReq(false))
+		assert.Error(t, err)
+	})
+}
+
+func TestEventService_QueryFreeBusy(t *testing.T) {
+	type fields struct {
+		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.QueryFreeBusyRequest
+	}
+
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []core.FreeBusy
+		wantErr error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByAttendees(gomock.Any(), []int32{1, 2}, start.Add(-core.MaxBuffer), start.AddDate(0, 0, 1).Add(core.MaxBuffer)).Times(1).
+						Return([]core.Event{
+							{
+								ID:       "123",
+								Title:    "standup",
+								Timezone: "Asia/Jakarta",
+								Schedules: []core.Schedule{
+									{ID: "sch1", EventID: "123", StartTime: start.Unix(), DurationInMinutes: 60},
+								},
+								Invitations: []core.Invitation{
+									{EventID: "123", UserID: 1, Status: core.InvitationStatus_Confirmed},
+								},
+							},
+						}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.QueryFreeBusyRequest{
+					UserIDs: []int32{1, 2},
+					From:    start,
+					To:      start.AddDate(0, 0, 1),
+				},
+			},
+			want: []core.FreeBusy{
+				{UserID: 1, Busy: []core.BusyInterval{{Start: start, End: start.Add(time.Hour)}}},
+				{UserID: 2, Busy: []core.BusyInterval{}},
+			},
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByAttendees(gomock.Any(), []int32{1}, start.Add(-core.MaxBuffer), start.AddDate(0, 0, 1).Add(core.MaxBuffer)).Times(1).
+						Return(nil, internal.ErrInvalidRequest)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.QueryFreeBusyRequest{
+					UserIDs: []int32{1},
+					From:    start,
+					To:      start.AddDate(0, 0, 1),
+				},
+			},
+			wantErr: internal.ErrInvalidRequest,
+		},
+		{
+			name: "Not OK - time range too long",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.QueryFreeBusyRequest{
+					UserIDs: []int32{1},
+					From:    start,
+					To:      start.AddDate(1, 0, 0),
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - invalid timezone",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.QueryFreeBusyRequest{
+					UserIDs:  []int32{1},
+					From:     start,
+					To:       start.AddDate(0, 0, 1),
+					Timezone: "Mars/Olympus",
+				},
+			},
+			wantErr: internal.ErrInvalidTimezone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+			got, err := e.QueryFreeBusy(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].UserID, got[i].UserID)
+				require.Len(t, got[i].Busy, len(tt.want[i].Busy))
+				for j, interval := range tt.want[i].Busy {
+					assert.True(t, interval.Start.Equal(got[i].Busy[j].Start))
+					assert.True(t, interval.End.Equal(got[i].Busy[j].End))
+				}
+			}
+		})
+	}
+}
+
+func TestEventService_SuggestMeetingTimes(t *testing.T) {
+	type fields struct {
+		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.SuggestMeetingTimesRequest
+	}
+
+	start := time.Date(2022, 1, 4, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []time.Time
+		wantErr error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByAttendees(gomock.Any(), []int32{1, 2}, start.Add(-core.MaxBuffer), start.Add(3*time.Hour+core.MaxBuffer)).Times(1).
+						Return([]core.Event{
+							{
+								ID:       "123",
+								Title:    "standup",
+								Timezone: "Asia/Jakarta",
+								Schedules: []core.Schedule{
+									{ID: "sch1", EventID: "123", StartTime: start.Unix(), DurationInMinutes: 60},
+								},
+								Invitations: []core.Invitation{
+									{EventID: "123", UserID: 1, Status: core.InvitationStatus_Confirmed},
+								},
+							},
+						}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.SuggestMeetingTimesRequest{
+					RequiredUserIDs: []int32{1},
+					OptionalUserIDs: []int32{2},
+					Duration:        time.Hour,
+					From:            start,
+					To:              start.Add(3 * time.Hour),
+					Timezone:        "Asia/Jakarta",
+					Limit:           2,
+				},
+			},
+			want: []time.Time{start.Add(time.Hour), start.Add(90 * time.Minute)},
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByAttendees(gomock.Any(), []int32{1}, start.Add(-core.MaxBuffer), start.Add(3*time.Hour+core.MaxBuffer)).Times(1).
+						Return(nil, internal.ErrInvalidRequest)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.SuggestMeetingTimesRequest{
+					RequiredUserIDs: []int32{1},
+					Duration:        time.Hour,
+					From:            start,
+					To:              start.Add(3 * time.Hour),
+				},
+			},
+			wantErr: internal.ErrInvalidRequest,
+		},
+		{
+			name: "Not OK - no required attendees",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.SuggestMeetingTimesRequest{
+					OptionalUserIDs: []int32{2},
+					Duration:        time.Hour,
+					From:            start,
+					To:              start.Add(3 * time.Hour),
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - duration longer than the time range",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.SuggestMeetingTimesRequest{
+					RequiredUserIDs: []int32{1},
+					Duration:        4 * time.Hour,
+					From:            start,
+					To:              start.Add(3 * time.Hour),
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+			got, err := e.SuggestMeetingTimes(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.True(t, tt.want[i].Equal(got[i].Start))
+				assert.Empty(t, got[i].ConflictingRequired)
+			}
+		})
+	}
+}
+
+func TestEventService_CreateEvent_WorkingHours(t *testing.T) {
+	// Tuesday, 8 January 2030 18:00 in Asia/Jakarta
+	start := time.Date(2030, 1, 8, 11, 0, 0, 0, time.UTC)
+	newReq := func(respectWorkingHours bool) *core.CreateEventRequest {
+		return &core.CreateEventRequest{
+			ActorID: "1",
+			Event: &core.Event{
+				ID:          "new",
+				Title:       "retro",
+				Description: "retro",
+				Timezone:    "Asia/Jakarta",
+				Schedules: []core.Schedule{
+					{ID: "sch1", EventID: "new", StartTime: start.Unix(), DurationInMinutes: 60},
+				},
+				Invitations: []core.Invitation{
+					{ID: "inv1", EventID: "new", UserID: 2, TokenHash: "inv1"},
+					{ID: "inv2", EventID: "new", UserID: 3, TokenHash: "inv2"},
+				},
+			},
+			RespectWorkingHours: respectWorkingHours,
+		}
+	}
+	availabilities := []core.Availability{
+		{
+			UserID:       2,
+			Timezone:     "Asia/Jakarta",
+			WorkingHours: []core.WorkingHours{{Days: []time.Weekday{time.Tuesday}, StartMinute: 9 * 60, EndMinute: 17 * 60}},
+		},
+		{
+			// 18:00 in Asia/Jakarta is 12:00 in Europe/Paris.
+			UserID:       3,
+			Timezone:     "Europe/Paris",
+			WorkingHours: []core.WorkingHours{{Days: []time.Weekday{time.Tuesday}, StartMinute: 9 * 60, EndMinute: 17 * 60}},
+		},
+	}
+
+	t.Run("OK - warns about the working hours", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByAttendees(gomock.Any(), []int32{2, 3}, gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		availabilityRepo := mock.NewMockAvailabilityRepository(ctrl)
+		availabilityRepo.EXPECT().FindByUserIDs(gomock.Any(), []int32{2, 3}).Times(1).Return(availabilities, nil)
+
+		e := scheduling.NewService(repo, availabilityRepo, mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		got, err := e.CreateEvent(t.Context(), newReq(false))
+		assert.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, int32(2), got[0].UserID)
+		assert.True(t, start.Equal(got[0].Start))
+	})
+
+	t.Run("Not OK - outside of the working hours", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByAttendees(gomock.Any(), []int32{2, 3}, gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+
+		availabilityRepo := mock.NewMockAvailabilityRepository(ctrl)
+		availabilityRepo.EXPECT().FindByUserIDs(gomock.Any(), []int32{2, 3}).Times(1).Return(availabilities, nil)
+
+		e := scheduling.NewService(repo, availabilityRepo, mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		_, err := e.CreateEvent(t.Context(), newReq(true))
+
+		var conflictErr *core.ConflictError
+		assert.ErrorIs(t, err, internal.ErrConflict)
+		require.True(t, errors.As(err, &conflictErr))
+		assert.Empty(t, conflictErr.Conflicts)
+		require.Len(t, conflictErr.OutsideWorkingHours, 1)
+		assert.Equal(t, int32(2), conflictErr.OutsideWorkingHours[0].UserID)
+	})
+}
+
+func TestEventService_GetAvailability(t *testing.T) {
+	type fields struct {
+		availabilityRepoMock func(ctrl *gomock.Controller) core.AvailabilityRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.GetAvailabilityRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *core.Availability
+		wantErr error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				availabilityRepoMock: func(ctrl *gomock.Controller) core.AvailabilityRepository {
+					repo := mock.NewMockAvailabilityRepository(ctrl)
+					repo.EXPECT().FindByUserIDs(gomock.Any(), []int32{1}).Times(1).
+						Return([]core.Availability{{UserID: 1, Timezone: "Asia/Jakarta"}}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.GetAvailabilityRequest{UserID: 1},
+			},
+			want: &core.Availability{UserID: 1, Timezone: "Asia/Jakarta"},
+		},
+		{
+			name: "Not OK - unknown user",
+			fields: fields{
+				availabilityRepoMock: func(ctrl *gomock.Controller) core.AvailabilityRepository {
+					repo := mock.NewMockAvailabilityRepository(ctrl)
+					repo.EXPECT().FindByUserIDs(gomock.Any(), []int32{4}).Times(1).Return(nil, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.GetAvailabilityRequest{UserID: 4},
+			},
+			wantErr: internal.ErrNotFound,
+		},
+		{
+			name: "Not OK - invalid user id",
+			fields: fields{
+				availabilityRepoMock: func(ctrl *gomock.Controller) core.AvailabilityRepository {
+					return mock.NewMockAvailabilityRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.GetAvailabilityRequest{},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(mock.NewMockEventRepository(ctrl), tt.fields.availabilityRepoMock(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+			got, err := e.GetAvailability(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventService_UpdateAvailability(t *testing.T) {
+	type fields struct {
+		availabilityRepoMock func(ctrl *gomock.Controller) core.AvailabilityRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.UpdateAvailabilityRequest
+	}
+	workingHours := []core.WorkingHours{
+		{Days: []time.Weekday{time.Monday, time.Tuesday}, StartMinute: 9 * 60, EndMinute: 17 * 60},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				availabilityRepoMock: func(ctrl *gomock.Controller) core.AvailabilityRepository {
+					repo := mock.NewMockAvailabilityRepository(ctrl)
+					repo.EXPECT().Update(gomock.Any(), &core.Availability{UserID: 1, Timezone: "Asia/Jakarta", WorkingHours: workingHours}).
+						Times(1).Return(nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.UpdateAvailabilityRequest{
+					ActorID:      "1",
+					Availability: &core.Availability{UserID: 1, Timezone: "Asia/Jakarta", WorkingHours: workingHours},
+				},
+			},
+		},
+		{
+			name: "Not OK - weekday listed twice",
+			fields: fields{
+				availabilityRepoMock: func(ctrl *gomock.Controller) core.AvailabilityRepository {
+					return mock.NewMockAvailabilityRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.UpdateAvailabilityRequest{
+					ActorID: "1",
+					Availability: &core.Availability{
+						UserID:   1,
+						Timezone: "Asia/Jakarta",
+						WorkingHours: append(workingHours, core.WorkingHours{
+							Days: []time.Weekday{time.Tuesday}, StartMinute: 13 * 60, EndMinute: 18 * 60,
+						}),
+					},
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - invalid timezone",
+			fields: fields{
+				availabilityRepoMock: func(ctrl *gomock.Controller) core.AvailabilityRepository {
+					return mock.NewMockAvailabilityRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.UpdateAvailabilityRequest{
+					ActorID:      "1",
+					Availability: &core.Availability{UserID: 1, Timezone: "Mars/Olympus"},
+				},
+			},
+			wantErr: internal.ErrInvalidTimezone,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				availabilityRepoMock: func(ctrl *gomock.Controller) core.AvailabilityRepository {
+					repo := mock.NewMockAvailabilityRepository(ctrl)
+					repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(internal.ErrNotFound)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.UpdateAvailabilityRequest{
+					ActorID:      "4",
+					Availability: &core.Availability{UserID: 4, Timezone: "UTC"},
+				},
+			},
+			wantErr: internal.ErrNotFound,
+		},
+		{
+			name: "Not OK - availability of another user",
+			fields: fields{
+				availabilityRepoMock: func(ctrl *gomock.Controller) core.AvailabilityRepository {
+					return mock.NewMockAvailabilityRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.UpdateAvailabilityRequest{
+					ActorID:      "1",
+					Availability: &core.Availability{UserID: 2, Timezone: "UTC"},
+				},
+			},
+			wantErr: internal.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(mock.NewMockEventRepository(ctrl), tt.fields.availabilityRepoMock(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+			err := e.UpdateAvailability(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventService_CreateResource(t *testing.T) {
+	type fields struct {
+		resourceRepoMock func(ctrl *gomock.Controller) core.ResourceRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.CreateResourceRequest
+	}
+	resource := &core.Resource{ID: "room1", Name: "Room 1", Capacity: 8, Timezone: "Asia/Jakarta"}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				resourceRepoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					repo := mock.NewMockResourceRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), resource).Times(1).Return(nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.CreateResourceRequest{ActorID: "1", Resource: resource},
+			},
+		},
+		{
+			name: "Not OK - invalid resource",
+			fields: fields{
+				resourceRepoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					return mock.NewMockResourceRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.CreateResourceRequest{ActorID: "1", Resource: &core.Resource{ID: "room1", Timezone: "UTC"}},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - missing actor",
+			fields: fields{
+				resourceRepoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					return mock.NewMockResourceRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.CreateResourceRequest{Resource: resource},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), tt.fields.resourceRepoMock(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+			err := e.CreateResource(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventService_FindResourceByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resource := &core.Resource{ID: "room1", Name: "Room 1", Timezone: "UTC"}
+	repo := mock.NewMockResourceRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), "room1").Times(1).Return(resource, nil)
+	repo.EXPECT().FindAll(gomock.Any()).Times(1).Return([]core.Resource{*resource}, nil)
+
+	e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), repo, mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+	got, err := e.FindResourceByID(t.Context(), &core.FindResourceByIDRequest{ID: "room1"})
+	require.NoError(t, err)
+	assert.Equal(t, resource, got)
+
+	_, err = e.FindResourceByID(t.Context(), &core.FindResourceByIDRequest{})
+	assert.ErrorIs(t, err, internal.ErrValidationFailed)
+
+	list, err := e.ListResources(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []core.Resource{*resource}, list)
+}
+
+func TestEventService_CreateEvent_Holidays(t *testing.T) {
+	calendarID := "id-holidays"
+	holidays := []core.Holiday{{Date: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), Name: "New Year's Day"}}
+	newReq := func() *core.CreateEventRequest {
+		return &core.CreateEventRequest{
+			ActorID: "123",
+			Event: &core.Event{
+				ID:                "123",
+				Title:             "test",
+				Description:       "test123",
+				Timezone:          "Asia/Jakarta",
+				HolidayCalendarID: &calendarID,
+				SkipHolidays:      true,
+				Schedules: []core.Schedule{
+					{ID: "test123", EventID: "123", StartTime: time.Now().Unix(), DurationInMinutes: 60},
+				},
+			},
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		holidayRepo := mock.NewMockHolidayCalendarRepository(ctrl)
+		holidayRepo.EXPECT().FindByID(gomock.Any(), calendarID).Times(1).
+			Return(&core.HolidayCalendar{ID: calendarID, Name: "Indonesia", Holidays: holidays}, nil)
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, event *core.Event) error {
+			assert.Equal(t, holidays, event.Holidays)
+			return nil
+		})
+
+		e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), holidayRepo, mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		_, err := e.CreateEvent(t.Context(), newReq())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Not OK - unknown holiday calendar", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		holidayRepo := mock.NewMockHolidayCalendarRepository(ctrl)
+		holidayRepo.EXPECT().FindByID(gomock.Any(), calendarID).Times(1).
+			Return(nil, internal.WrapErr(internal.ErrNotFound, "holiday calendar "+calendarID))
+
+		e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), holidayRepo, mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		_, err := e.CreateEvent(t.Context(), newReq())
+		assert.ErrorIs(t, err, internal.ErrValidationFailed)
+	})
+}
+
+func TestEventService_CreateHolidayCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	calendar := &core.HolidayCalendar{
+		ID:       "id-holidays",
+		Name:     "Indonesia",
+		Holidays: []core.Holiday{{Date: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), Name: "New Year's Day"}},
+	}
+	repo := mock.NewMockHolidayCalendarRepository(ctrl)
+	repo.EXPECT().Store(gomock.Any(), calendar).Times(1).Return(nil)
+	repo.EXPECT().FindByID(gomock.Any(), "id-holidays").Times(1).Return(calendar, nil)
+
+	e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), repo, mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+	require.NoError(t, e.CreateHolidayCalendar(t.Context(), &core.CreateHolidayCalendarRequest{ActorID: "1", HolidayCalendar: calendar}))
+
+	err := e.CreateHolidayCalendar(t.Context(), &core.CreateHolidayCalendarRequest{ActorID: "1", HolidayCalendar: &core.HolidayCalendar{ID: "empty", Name: "Empty"}})
+	assert.ErrorIs(t, err, internal.ErrValidationFailed)
+
+	got, err := e.FindHolidayCalendarByID(t.Context(), &core.FindHolidayCalendarByIDRequest{ID: "id-holidays"})
+	require.NoError(t, err)
+	assert.Equal(t, calendar, got)
+}
+
+func TestEventService_RespondToInvitation(t *testing.T) {
+	token, err := tokenSigner.Issue(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	expired, err := tokenSigner.Issue(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	proposal := &core.TimeProposal{
+		ScheduleID: "sch1",
+		Start:      time.Date(2030, time.January, 8, 9, 0, 0, 0, time.UTC),
+		End:        time.Date(2030, time.January, 8, 10, 0, 0, 0, time.UTC),
+	}
+	event := &core.Event{ID: "id-event", Schedules: []core.Schedule{{ID: "sch1", EventID: "id-event"}}}
+	weeklyStart := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+	weekly := &core.Event{ID: "id-event", Timezone: "UTC", Schedules: []core.Schedule{
+		{ID: "sch1", EventID: "id-event", StartTime: weeklyStart.Unix(), DurationInMinutes: 60, RecurrenceRule: core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1}},
+	}}
+
+	type fields struct {
+		eventRepoMock      func(ctrl *gomock.Controller) core.EventRepository
+		invitationRepoMock func(ctrl *gomock.Controller) core.InvitationRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.RespondToInvitationRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    core.InvitationStatus
+		wantErr error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					repo := mock.NewMockInvitationRepository(ctrl)
+					repo.EXPECT().FindByTokenHash(gomock.Any(), core.HashInvitationToken(token)).Times(1).
+						Return(&core.Invitation{ID: "id-invitation", EventID: "id-event", UserID: 2, TokenHash: core.HashInvitationToken(token)}, nil)
+					repo.EXPECT().Respond(gomock.Any(), "id-event", gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, _ string, respond func(*core.Event) ([]*core.Invitation, error)) error {
+							invitations, err := respond(&core.Event{ID: "id-event", Invitations: []core.Invitation{
+								{ID: "id-invitation", EventID: "id-event", UserID: 2, Status: core.InvitationStatus_Confirmed},
+							}})
+							require.NoError(t, err)
+							require.Len(t, invitations, 1)
+							assert.Equal(t, core.InvitationStatus_Declined, invitations[0].Status)
+							assert.Equal(t, "I'm on leave", invitations[0].Comment)
+							assert.NotNil(t, invitations[0].UpdatedAt)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{Token: token, Response: core.InvitationStatus_Declined, Comment: "I'm on leave"},
+			},
+			want: core.InvitationStatus_Declined,
+		},
+		{
+			name: "OK - tentative with a proposal",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "id-event").Times(1).Return(event, nil)
+					return repo
+				},
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					repo := mock.NewMockInvitationRepository(ctrl)
+					repo.EXPECT().FindByTokenHash(gomock.Any(), core.HashInvitationToken(token)).Times(1).
+						Return(&core.Invitation{ID: "id-invitation", EventID: "id-event", UserID: 2, TokenHash: core.HashInvitationToken(token)}, nil)
+					repo.EXPECT().Respond(gomock.Any(), "id-event", gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, _ string, respond func(*core.Event) ([]*core.Invitation, error)) error {
+							invitations, err := respond(&core.Event{ID: "id-event", Invitations: []core.Invitation{
+								{ID: "id-invitation", EventID: "id-event", UserID: 2},
+							}})
+							require.NoError(t, err)
+							require.Len(t, invitations, 1)
+							assert.Equal(t, core.InvitationStatus_Tentative, invitations[0].Status)
+							assert.Equal(t, proposal, invitations[0].Proposal)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{Token: token, Response: core.InvitationStatus_Tentative, Proposal: proposal},
+			},
+			want: core.InvitationStatus_Tentative,
+		},
+		{
+			name: "OK - waitlisted on a full event",
+			fields: fields{
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					repo := mock.NewMockInvitationRepository(ctrl)
+					repo.EXPECT().FindByTokenHash(gomock.Any(), core.HashInvitationToken(token)).Times(1).
+						Return(&core.Invitation{ID: "id-invitation", EventID: "id-event", UserID: 2}, nil)
+					repo.EXPECT().Respond(gomock.Any(), "id-event", gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, _ string, respond func(*core.Event) ([]*core.Invitation, error)) error {
+							invitations, err := respond(&core.Event{ID: "id-event", MaxAttendees: 1, Invitations: []core.Invitation{
+								{ID: "id-confirmed", EventID: "id-event", UserID: 3, Status: core.InvitationStatus_Confirmed},
+								{ID: "id-invitation", EventID: "id-event", UserID: 2},
+							}})
+							require.NoError(t, err)
+							require.Len(t, invitations, 1)
+							assert.NotNil(t, invitations[0].WaitlistedAt)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{Token: token, Response: core.InvitationStatus_Confirmed},
+			},
+			want: core.InvitationStatus_Waitlisted,
+		},
+		{
+			name: "Not OK - proposal of an unknown schedule",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "id-event").Times(1).Return(&core.Event{ID: "id-event"}, nil)
+					return repo
+				},
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					repo := mock.NewMockInvitationRepository(ctrl)
+					repo.EXPECT().FindByTokenHash(gomock.Any(), core.HashInvitationToken(token)).Times(1).
+						Return(&core.Invitation{ID: "id-invitation", EventID: "id-event", UserID: 2}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{Token: token, Response: core.InvitationStatus_Tentative, Proposal: proposal},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - invalid proposal",
+			fields: fields{
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					return mock.NewMockInvitationRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{
+					Token:    token,
+					Response: core.InvitationStatus_Tentative,
+					Proposal: &core.TimeProposal{ScheduleID: "sch1", Start: proposal.End, End: proposal.Start},
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "OK - single occurrence",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "id-event").Times(1).Return(weekly, nil)
+					return repo
+				},
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					repo := mock.NewMockInvitationRepository(ctrl)
+					repo.EXPECT().FindByTokenHash(gomock.Any(), core.HashInvitationToken(token)).Times(1).
+						Return(&core.Invitation{ID: "id-invitation", EventID: "id-event", UserID: 2, Status: core.InvitationStatus_Confirmed}, nil)
+					repo.EXPECT().StoreOccurrenceResponse(gomock.Any(), gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, resp *core.OccurrenceResponse) error {
+							assert.Equal(t, "id-invitation", resp.InvitationID)
+							assert.Equal(t, "sch1", resp.ScheduleID)
+							assert.Equal(t, weeklyStart.AddDate(0, 0, 7).Unix(), resp.OriginalStart)
+							assert.Equal(t, core.InvitationStatus_Declined, resp.Status)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{
+					Token:         token,
+					Response:      core.InvitationStatus_Declined,
+					ScheduleID:    "sch1",
+					OriginalStart: weeklyStart.AddDate(0, 0, 7),
+				},
+			},
+			want: core.InvitationStatus_Declined,
+		},
+		{
+			name: "Not OK - no occurrence at the original start",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "id-event").Times(1).Return(weekly, nil)
+					return repo
+				},
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					repo := mock.NewMockInvitationRepository(ctrl)
+					repo.EXPECT().FindByTokenHash(gomock.Any(), core.HashInvitationToken(token)).Times(1).
+						Return(&core.Invitation{ID: "id-invitation", EventID: "id-event", UserID: 2}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{
+					Token:         token,
+					Response:      core.InvitationStatus_Declined,
+					ScheduleID:    "sch1",
+					OriginalStart: weeklyStart.AddDate(0, 0, 8),
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - confirming an occurrence while waitlisted",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "id-event").Times(1).Return(weekly, nil)
+					return repo
+				},
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					repo := mock.NewMockInvitationRepository(ctrl)
+					repo.EXPECT().FindByTokenHash(gomock.Any(), core.HashInvitationToken(token)).Times(1).
+						Return(&core.Invitation{ID: "id-invitation", EventID: "id-event", UserID: 2, Status: core.InvitationStatus_Waitlisted}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{
+					Token:         token,
+					Response:      core.InvitationStatus_Confirmed,
+					ScheduleID:    "sch1",
+					OriginalStart: weeklyStart,
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - proposal for a single occurrence",
+			fields: fields{
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					return mock.NewMockInvitationRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{
+					Token:         token,
+					Response:      core.InvitationStatus_Tentative,
+					Proposal:      proposal,
+					ScheduleID:    "sch1",
+					OriginalStart: weeklyStart,
+				},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - revoked token",
+			fields: fields{
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					repo := mock.NewMockInvitationRepository(ctrl)
+					repo.EXPECT().FindByTokenHash(gomock.Any(), core.HashInvitationToken(token)).Times(1).
+						Return(nil, internal.WrapErr(internal.ErrNotFound, "invitation"))
+					return repo
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{Token: token, Response: core.InvitationStatus_Confirmed},
+			},
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name: "Not OK - forged token",
+			fields: fields{
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					return mock.NewMockInvitationRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{Token: "test.aW52aXRhdGlvbg.c2lnbmF0dXJl", Response: core.InvitationStatus_Confirmed},
+			},
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name: "Not OK - expired token",
+			fields: fields{
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					return mock.NewMockInvitationRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{Token: expired, Response: core.InvitationStatus_Confirmed},
+			},
+			wantErr: internal.ErrInvalidToken,
+		},
+		{
+			name: "Not OK - missing response",
+			fields: fields{
+				invitationRepoMock: func(ctrl *gomock.Controller) core.InvitationRepository {
+					return mock.NewMockInvitationRepository(ctrl)
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				req: &core.RespondToInvitationRequest{Token: token},
+			},
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			eventRepo := core.EventRepository(mock.NewMockEventRepository(ctrl))
+			if tt.fields.eventRepoMock != nil {
+				eventRepo = tt.fields.eventRepoMock(ctrl)
+			}
+
+			e := scheduling.NewService(eventRepo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), tt.fields.invitationRepoMock(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+			got, err := e.RespondToInvitation(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.args.req.IsForOccurrence() {
+				assert.Equal(t, tt.want, got.StatusAt(tt.args.req.ScheduleID, tt.args.req.OriginalStart))
+				assert.Equal(t, core.InvitationStatus_Confirmed, got.Status)
+				return
+			}
+			assert.Equal(t, tt.want, got.Status)
+		})
+	}
+}
+
+func TestEventService_CreateEvent_InvitationTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := core.NewEvent("1")
+	event.Title = "planning"
+	event.Description = "planning"
+	event.Timezone = "UTC"
+	event.Schedules = []core.Schedule{
+		{ID: "sch1", EventID: event.ID, StartTime: time.Now().Add(time.Hour).Unix(), DurationInMinutes: 60},
+	}
+	event.Invitations = []core.Invitation{core.NewInvitation(event.ID, 2), core.NewInvitation(event.ID, 3)}
+
+	repo := mock.NewMockEventRepository(ctrl)
+	repo.EXPECT().Store(gomock.Any(), event).Times(1).
+		DoAndReturn(func(_ context.Context, e *core.Event) error {
+			for _, inv := range e.Invitations {
+				assert.Equal(t, core.HashInvitationToken(inv.Token), inv.TokenHash)
+				assert.NoError(t, tokenSigner.Verify(inv.Token, time.Now()))
+				assert.True(t, time.Unix(e.Schedules[0].StartTime, 0).Add(time.Hour).Equal(inv.TokenExpiresAt))
+			}
+			return nil
+		})
+
+	// The attendees get the tokens to respond with.
+	var notified []string
+	notifier := mock.NewMockNotifier(ctrl)
+	notifier.EXPECT().NotifyInvited(gomock.Any(), event, gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, _ *core.Event, inv *core.Invitation) error {
+			notified = append(notified, inv.Token)
+			return nil
+		})
+
+	e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notifier)
+	_, err := e.CreateEvent(t.Context(), &core.CreateEventRequest{ActorID: "1", Event: event, AllowConflicts: true})
+	require.NoError(t, err)
+	assert.NotEqual(t, event.Invitations[0].Token, event.Invitations[1].Token)
+	assert.Equal(t, []string{event.Invitations[0].Token, event.Invitations[1].Token}, notified)
+}
+
+func TestEventService_RespondToInvitation_Promotion(t *testing.T) {
+	token, err := tokenSigner.Issue(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	earlier := time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	invitationRepo := mock.NewMockInvitationRepository(ctrl)
+	invitationRepo.EXPECT().FindByTokenHash(gomock.Any(), core.HashInvitationToken(token)).Times(1).
+		Return(&core.Invitation{ID: "id-invitation", EventID: "id-event", UserID: 2, Status: core.InvitationStatus_Confirmed}, nil)
+	invitationRepo.EXPECT().Respond(gomock.Any(), "id-event", gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _ string, respond func(*core.Event) ([]*core.Invitation, error)) error {
+			invitations, err := respond(&core.Event{ID: "id-event", MaxAttendees: 1, Invitations: []core.Invitation{
+				{ID: "id-invitation", EventID: "id-event", UserID: 2, Status: core.InvitationStatus_Confirmed},
+				{ID: "id-later", EventID: "id-event", UserID: 4, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &later},
+				{ID: "id-earlier", EventID: "id-event", UserID: 3, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &earlier},
+			}})
+			require.NoError(t, err)
+			require.Len(t, invitations, 2)
+			assert.Equal(t, "id-earlier", invitations[1].ID)
+			assert.Equal(t, core.InvitationStatus_Confirmed, invitations[1].Status)
+			return nil
+		})
+
+	notifier := mock.NewMockNotifier(ctrl)
+	notifier.EXPECT().NotifyPromoted(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, event *core.Event, inv *core.Invitation) error {
+			assert.Equal(t, "id-event", event.ID)
+			assert.Equal(t, "id-earlier", inv.ID)
+			return errors.New("webhook down") //nolint:goerr113
+		})
+
+	e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), invitationRepo, activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notifier)
+	got, err := e.RespondToInvitation(t.Context(), &core.RespondToInvitationRequest{Token: token, Response: core.InvitationStatus_Declined})
+	require.NoError(t, err, "a failing notification doesn't fail the response")
+	assert.Equal(t, core.InvitationStatus_Declined, got.Status)
+}
+
+func TestEventService_RegenerateInvitationToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+	event := &core.Event{
+		ID:        "123",
+		Timezone:  "UTC",
+		CreatedBy: "1",
+		Schedules: []core.Schedule{
+			{ID: "sch1", EventID: "123", StartTime: start.Unix(), DurationInMinutes: 60, RecurrenceRule: core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1, Count: 3}, Count: 3},
+		},
+		Invitations: []core.Invitation{
+			{ID: "inv1", EventID: "123", UserID: 2, TokenHash: "leaked"},
+			{ID: "inv2", EventID: "123", Guest: &core.Guest{Email: "jane@example.com", Name: "Jane"}},
+		},
+	}
+	repo := mock.NewMockEventRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), "123").AnyTimes().Return(event, nil)
+	invitationRepo := mock.NewMockInvitationRepository(ctrl)
+	invitationRepo.EXPECT().UpdateTokenHash(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, inv *core.Invitation) error {
+			assert.Equal(t, "inv1", inv.ID)
+			assert.Equal(t, core.HashInvitationToken(inv.Token), inv.TokenHash)
+			return nil
+		})
+	invitationRepo.EXPECT().UpdateTokenHash(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, inv *core.Invitation) error {
+			assert.Equal(t, "inv2", inv.ID)
+			return nil
+		})
+
+	e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), invitationRepo, activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+	got, err := e.RegenerateInvitationToken(t.Context(), &core.RegenerateInvitationTokenRequest{ActorID: "1", EventID: "123", UserID: 2})
+	require.NoError(t, err)
+	assert.NotEqual(t, "leaked", got.TokenHash)
+	// The token expires at the end of the last occurrence.
+	assert.Equal(t, start.AddDate(0, 0, 14).Add(time.Hour), got.TokenExpiresAt)
+	assert.NoError(t, tokenSigner.Verify(got.Token, time.Now()))
+
+	_, err = e.RegenerateInvitationToken(t.Context(), &core.RegenerateInvitationTokenRequest{ActorID: "1", EventID: "123", GuestEmail: "Jane@Example.com"})
+	require.NoError(t, err)
+
+	_, err = e.RegenerateInvitationToken(t.Context(), &core.RegenerateInvitationTokenRequest{ActorID: "1", EventID: "123", UserID: 3})
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	_, err = e.RegenerateInvitationToken(t.Context(), &core.RegenerateInvitationTokenRequest{ActorID: "1", EventID: "123", GuestEmail: "john@example.com"})
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	_, err = e.RegenerateInvitationToken(t.Context(), &core.RegenerateInvitationTokenRequest{ActorID: "1", EventID: "123", UserID: 2, GuestEmail: "jane@example.com"})
+	assert.ErrorIs(t, err, internal.ErrValidationFailed)
+
+	// Only the organizers can hand out the links, an attendee can't take over another one's.
+	_, err = e.RegenerateInvitationToken(t.Context(), &core.RegenerateInvitationTokenRequest{ActorID: "2", EventID: "123", GuestEmail: "jane@example.com"})
+	assert.ErrorIs(t, err, internal.ErrPermissionDenied)
+}
+
+func TestEventService_AcceptProposal(t *testing.T) {
+	start := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+	proposal := &core.TimeProposal{ScheduleID: "sch1", Start: start.Add(24 * time.Hour), End: start.Add(24*time.Hour + 90*time.Minute)}
+	newEvent := func() *core.Event {
+		return &core.Event{
+			ID:        "123",
+			Title:     "planning",
+			Timezone:  "UTC",
+			CreatedBy: "1",
+			Schedules: []core.Schedule{{ID: "sch1", EventID: "123", StartTime: start.Unix(), DurationInMinutes: 60}},
+			Invitations: []core.Invitation{
+				{ID: "inv1", EventID: "123", UserID: 2, Status: core.InvitationStatus_Tentative, Comment: "later?", Proposal: proposal},
+				{ID: "inv2", EventID: "123", UserID: 3, Status: core.InvitationStatus_Confirmed, Comment: "see you"},
+				{ID: "inv3", EventID: "123", UserID: 1, Status: core.InvitationStatus_Confirmed},
+				{ID: "inv4", EventID: "123", UserID: 4, Role: core.AttendeeRole_Organizer, Status: core.InvitationStatus_Tentative},
+				{ID: "inv5", EventID: "123", UserID: 5, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &start},
+			},
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(newEvent(), nil)
+		repo.EXPECT().FindByAttendees(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, event *core.Event) error {
+				assert.Equal(t, proposal.Start.Unix(), event.Schedules[0].StartTime)
+				assert.Equal(t, int64(90), event.Schedules[0].DurationInMinutes)
+				assert.Equal(t, core.InvitationStatus_Confirmed, event.Invitations[0].Status)
+				assert.Nil(t, event.Invitations[0].Proposal)
+				assert.Equal(t, core.InvitationStatus_Unknown, event.Invitations[1].Status)
+				assert.Empty(t, event.Invitations[1].Comment)
+				// The organizers and the waitlist are left alone.
+				assert.Equal(t, core.InvitationStatus_Confirmed, event.Invitations[2].Status)
+				assert.Equal(t, core.InvitationStatus_Tentative, event.Invitations[3].Status)
+				assert.Equal(t, core.InvitationStatus_Waitlisted, event.Invitations[4].Status)
+				assert.Equal(t, &start, event.Invitations[4].WaitlistedAt)
+				return nil
+			})
+
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.AcceptProposal(t.Context(), &core.AcceptProposalRequest{ActorID: "1", EventID: "123", InvitationID: "inv1"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Not OK - not the organizer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(newEvent(), nil)
+
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.AcceptProposal(t.Context(), &core.AcceptProposalRequest{ActorID: "2", EventID: "123", InvitationID: "inv1"})
+		assert.ErrorIs(t, err, internal.ErrPermissionDenied)
+	})
+
+	t.Run("Not OK - no proposal", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(newEvent(), nil)
+
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		err := e.AcceptProposal(t.Context(), &core.AcceptProposalRequest{ActorID: "1", EventID: "123", InvitationID: "inv2"})
+		assert.ErrorIs(t, err, internal.ErrNotFound)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(newEvent(), nil)
+
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		got, err := e.ListProposals(t.Context(), &core.ListProposalsRequest{ActorID: "1", EventID: "123"})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "inv1", got[0].ID)
+	})
+}
+
+func TestEventService_ListMyInvitations(t *testing.T) {
+	start := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+	event := &core.Event{
+		ID:        "123",
+		Title:     "planning",
+		Timezone:  "UTC",
+		CreatedBy: "1",
+		Schedules: []core.Schedule{
+			{ID: "sch1", EventID: "123", StartTime: start.Unix(), DurationInMinutes: 60, RecurrenceRule: core.RecurrenceRule{Freq: core.Frequency_Weekly, Interval: 1, Count: 3}, Count: 3},
+		},
+		Invitations: []core.Invitation{
+			{ID: "inv1", EventID: "123", UserID: 2, Status: core.InvitationStatus_Confirmed},
+		},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByIDs(gomock.Any(), []string{"123"}).Times(1).Return([]core.Event{*event}, nil)
+		invitationRepo := mock.NewMockInvitationRepository(ctrl)
+		invitationRepo.EXPECT().FindByFilter(gomock.Any(), core.InvitationFilter{
+			UserID:   2,
+			Statuses: []core.InvitationStatus{core.InvitationStatus_Confirmed},
+			From:     start.AddDate(0, 0, 1),
+			Limit:    core.DefaultPageSize + 1,
+		}).Times(1).Return([]core.Invitation{{ID: "inv1", EventID: "123", UserID: 2, Status: core.InvitationStatus_Confirmed}}, nil)
+
+		e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), invitationRepo, activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		got, err := e.ListMyInvitations(t.Context(), &core.ListMyInvitationsRequest{
+			ActorID:  "2",
+			Statuses: []core.InvitationStatus{core.InvitationStatus_Confirmed},
+			From:     start.AddDate(0, 0, 1),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, got.NextPageToken)
+		require.Len(t, got.Summaries, 1)
+		assert.Equal(t, "inv1", got.Summaries[0].Invitation.ID)
+		assert.Equal(t, "planning", got.Summaries[0].Event.Title)
+		require.NotNil(t, got.Summaries[0].NextOccurrence)
+		// The first occurrence is over by the start of the time range.
+		assert.Equal(t, start.AddDate(0, 0, 7), got.Summaries[0].NextOccurrence.Start)
+	})
+
+	t.Run("OK - next page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByIDs(gomock.Any(), []string{"123"}).Times(1).Return([]core.Event{*event}, nil)
+		invitationRepo := mock.NewMockInvitationRepository(ctrl)
+		invitationRepo.EXPECT().FindByFilter(gomock.Any(), core.InvitationFilter{UserID: 2, Limit: 2, Offset: 1}).Times(1).
+			Return([]core.Invitation{{ID: "inv1", EventID: "123"}, {ID: "inv2", EventID: "456"}}, nil)
+
+		e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), invitationRepo, activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		got, err := e.ListMyInvitations(t.Context(), &core.ListMyInvitationsRequest{ActorID: "2", PageSize: 1, PageToken: core.PageToken(1)})
+		require.NoError(t, err)
+		assert.Len(t, got.Summaries, 1)
+		assert.Equal(t, core.PageToken(2), got.NextPageToken)
+	})
+
+	t.Run("OK - events loaded at once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		other := *event
+		other.ID = "456"
+		other.Title = "retro"
+		other.Invitations = []core.Invitation{{ID: "inv2", EventID: "456", UserID: 2, Status: core.InvitationStatus_Unknown}}
+
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByIDs(gomock.Any(), []string{"456", "123"}).Times(1).Return([]core.Event{other, *event}, nil)
+		invitationRepo := mock.NewMockInvitationRepository(ctrl)
+		invitationRepo.EXPECT().FindByFilter(gomock.Any(), gomock.Any()).Times(1).
+			Return([]core.Invitation{{ID: "inv2", EventID: "456"}, {ID: "inv1", EventID: "123"}}, nil)
+
+		e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), invitationRepo, activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		got, err := e.ListMyInvitations(t.Context(), &core.ListMyInvitationsRequest{ActorID: "2"})
+		require.NoError(t, err)
+		require.Len(t, got.Summaries, 2)
+		assert.Equal(t, "retro", got.Summaries[0].Event.Title)
+		assert.Equal(t, core.InvitationStatus_Unknown, got.Summaries[0].Invitation.Status)
+		assert.Equal(t, "planning", got.Summaries[1].Event.Title)
+		assert.Equal(t, core.InvitationStatus_Confirmed, got.Summaries[1].Invitation.Status)
+	})
+
+	t.Run("Not OK - invalid page token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), mock.NewMockGroupRepository(ctrl), tokenSigner, notification.Nop{})
+		_, err := e.ListMyInvitations(t.Context(), &core.ListMyInvitationsRequest{ActorID: "2", PageToken: "not a token"})
+		assert.ErrorIs(t, err, internal.ErrValidationFailed)
+	})
+}
+
+func TestEventService_CreateEvent_Groups(t *testing.T) {
+	deactivatedAt := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	newReq := func() *core.CreateEventRequest {
+		return &core.CreateEventRequest{
+			ActorID: "1",
+			Event: &core.Event{
+				ID:          "123",
+				Title:       "all hands",
+				Description: "all hands",
+				Timezone:    "UTC",
+				Schedules:   []core.Schedule{{ID: "sch1", EventID: "123", StartTime: time.Now().Add(time.Hour).Unix(), DurationInMinutes: 60}},
+				Invitations: []core.Invitation{core.NewInvitation("123", 2)},
+				Groups:      []core.EventGroup{{GroupID: "platform", Sync: true}},
+			},
+			AllowConflicts: true,
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		groupRepo := mock.NewMockGroupRepository(ctrl)
+		groupRepo.EXPECT().FindByID(gomock.Any(), "platform").Times(1).Return(&core.Group{ID: "platform", Name: "Platform"}, nil)
+		groupRepo.EXPECT().FindMemberIDs(gomock.Any(), "platform").Times(1).Return([]int32{2, 4, 5}, nil)
+		userRepo := mock.NewMockUserRepository(ctrl)
+		userRepo.EXPECT().FindByIDs(gomock.Any(), []int32{2}).Times(1).Return([]core.User{{ID: 2, Name: "Bar", Timezone: "UTC"}}, nil)
+		userRepo.EXPECT().FindByIDs(gomock.Any(), []int32{2, 4, 5}).Times(1).Return([]core.User{
+			{ID: 2, Name: "Bar", Timezone: "UTC"},
+			{ID: 4, Name: "Baz", Timezone: "UTC"},
+			{ID: 5, Name: "Qux", Timezone: "UTC", DeactivatedAt: &deactivatedAt},
+		}, nil)
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, event *core.Event) error {
+			require.Len(t, event.Invitations, 2, "the deactivated member is left out")
+			assert.Nil(t, event.Invitations[0].GroupID, "the user invited directly isn't invited twice")
+			assert.Equal(t, int32(4), event.Invitations[1].UserID)
+			assert.Equal(t, "platform", *event.Invitations[1].GroupID)
+			assert.NotEmpty(t, event.Invitations[1].TokenHash)
+			return nil
+		})
+
+		e := scheduling.NewService(repo, noAvailabilities(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), userRepo, groupRepo, tokenSigner, notification.Nop{})
+		_, err := e.CreateEvent(t.Context(), newReq())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Not OK - unknown group", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		groupRepo := mock.NewMockGroupRepository(ctrl)
+		groupRepo.EXPECT().FindByID(gomock.Any(), "platform").Times(1).Return(nil, internal.WrapErr(internal.ErrNotFound, "group platform"))
+
+		e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), groupRepo, tokenSigner, notification.Nop{})
+		_, err := e.CreateEvent(t.Context(), newReq())
+		assert.ErrorIs(t, err, internal.ErrValidationFailed)
+		assert.ErrorContains(t, err, "unknown group platform")
+	})
+}
+
+func TestEventService_CreateGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	group := &core.Group{ID: "platform", Name: "Platform", CreatedBy: "1", MemberIDs: []int32{4}, SubgroupIDs: []string{"sre"}}
+	repo := mock.NewMockGroupRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), "sre").Times(1).Return(&core.Group{ID: "sre", Name: "SRE"}, nil)
+	repo.EXPECT().FindSubgroupIDs(gomock.Any(), "sre").Times(1).Return(nil, nil)
+	repo.EXPECT().Store(gomock.Any(), group).Times(1).Return(nil)
+	repo.EXPECT().FindByID(gomock.Any(), "unknown").Times(1).Return(nil, internal.WrapErr(internal.ErrNotFound, "group unknown"))
+	repo.EXPECT().FindByID(gomock.Any(), "platform").Times(1).Return(group, nil)
+
+	e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), repo, tokenSigner, notification.Nop{})
+	require.NoError(t, e.CreateGroup(t.Context(), &core.CreateGroupRequest{ActorID: "1", Group: group}))
+
+	err := e.CreateGroup(t.Context(), &core.CreateGroupRequest{ActorID: "1", Group: &core.Group{ID: "sales", Name: "Sales", SubgroupIDs: []string{"unknown"}}})
+	assert.ErrorIs(t, err, internal.ErrValidationFailed)
+
+	got, err := e.FindGroupByID(t.Context(), &core.FindGroupByIDRequest{ID: "platform"})
+	require.NoError(t, err)
+	assert.Equal(t, group, got)
+}
+
+func TestEventService_UpdateGroupMembers(t *testing.T) {
+	newGroup := func() *core.Group {
+		return &core.Group{ID: "platform", Name: "Platform", CreatedBy: "1", MemberIDs: []int32{4, 5}}
+	}
+
+	t.Run("OK - syncing the events to come", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		newEvent := func(id string, start time.Time) *core.Event {
+			event := &core.Event{
+				ID:          id,
+				Title:       "weekly",
+				Description: "weekly",
+				Timezone:    "UTC",
+				CreatedBy:   "1",
+				Schedules:   []core.Schedule{{ID: "sch-" + id, EventID: id, StartTime: start.Unix(), DurationInMinutes: 60}},
+				Groups:      []core.EventGroup{{GroupID: "platform", Sync: true}},
+			}
+			event.InviteGroup("platform", []int32{4, 5})
+			return event
+		}
+		upcoming := newEvent("123", time.Now().Add(time.Hour))
+		past := newEvent("456", time.Now().Add(-time.Hour*24))
+
+		groupRepo := mock.NewMockGroupRepository(ctrl)
+		groupRepo.EXPECT().FindByID(gomock.Any(), "platform").Times(1).Return(newGroup(), nil)
+		groupRepo.EXPECT().UpdateMembers(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, g *core.Group) error {
+			assert.Equal(t, []int32{4, 6}, g.MemberIDs)
+			return nil
+		})
+		groupRepo.EXPECT().FindSyncedEventIDs(gomock.Any(), "platform").Times(1).Return([]string{"123", "456"}, nil)
+		groupRepo.EXPECT().FindMemberIDs(gomock.Any(), "platform").Times(1).Return([]int32{4, 6}, nil)
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(upcoming, nil)
+		repo.EXPECT().FindByID(gomock.Any(), "456").Times(1).Return(past, nil)
+		invitationRepo := mock.NewMockInvitationRepository(ctrl)
+		invitationRepo.EXPECT().SyncGroup(gomock.Any(), gomock.Any(), []string{upcoming.Invitations[1].ID}).Times(1).
+			DoAndReturn(func(_ context.Context, invited []core.Invitation, _ []string) error {
+				require.Len(t, invited, 1)
+				assert.Equal(t, int32(6), invited[0].UserID)
+				assert.Equal(t, "123", invited[0].EventID)
+				assert.NotEmpty(t, invited[0].TokenHash)
+				return nil
+			})
+
+		e := scheduling.NewService(repo, mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), invitationRepo, activeUsers(ctrl), groupRepo, tokenSigner, notification.Nop{})
+		err := e.UpdateGroupMembers(t.Context(), &core.UpdateGroupMembersRequest{ActorID: "1", GroupID: "platform", MemberIDs: []int32{4, 6}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Not OK - not the creator", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		groupRepo := mock.NewMockGroupRepository(ctrl)
+		groupRepo.EXPECT().FindByID(gomock.Any(), "platform").Times(1).Return(newGroup(), nil)
+
+		e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), groupRepo, tokenSigner, notification.Nop{})
+		err := e.UpdateGroupMembers(t.Context(), &core.UpdateGroupMembersRequest{ActorID: "4", GroupID: "platform", MemberIDs: []int32{4}})
+		assert.ErrorIs(t, err, internal.ErrPermissionDenied)
+	})
+
+	t.Run("Not OK - nesting the group in itself", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		groupRepo := mock.NewMockGroupRepository(ctrl)
+		groupRepo.EXPECT().FindByID(gomock.Any(), "platform").Times(1).Return(newGroup(), nil)
+		groupRepo.EXPECT().FindByID(gomock.Any(), "engineering").Times(1).Return(&core.Group{ID: "engineering", Name: "Engineering"}, nil)
+		groupRepo.EXPECT().FindSubgroupIDs(gomock.Any(), "engineering").Times(1).Return([]string{"backend", "platform"}, nil)
+
+		e := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockAvailabilityRepository(ctrl), mock.NewMockResourceRepository(ctrl), mock.NewMockHolidayCalendarRepository(ctrl), mock.NewMockInvitationRepository(ctrl), activeUsers(ctrl), groupRepo, tokenSigner, notification.Nop{})
+		err := e.UpdateGroupMembers(t.Context(), &core.UpdateGroupMembersRequest{ActorID: "1", GroupID: "platform", MemberIDs: []int32{4, 5}, SubgroupIDs: []string{"engineering"}})
+		assert.ErrorIs(t, err, internal.ErrValidationFailed)
+	})
+}
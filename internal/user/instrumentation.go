@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Instrumentation struct {
+	next   core.UserService
+	tracer trace.Tracer
+}
+
+func NewInstrumentation(next core.UserService) *Instrumentation {
+	return &Instrumentation{
+		next:   next,
+		tracer: otel.Tracer("user-service"),
+	}
+}
+
+func (i *Instrumentation) CreateUser(ctx context.Context, req *core.CreateUserRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "create-user")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.CreateUser(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) GetUser(ctx context.Context, req *core.GetUserRequest) (*core.User, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "get-user")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.GetUser(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) ListUsers(ctx context.Context, req *core.ListUsersRequest) (*core.UserPage, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-users")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	res, err := i.next.ListUsers(ctx, req)
+	return res, err
+}
+
+func (i *Instrumentation) DeactivateUser(ctx context.Context, req *core.DeactivateUserRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "deactivate-user")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.DeactivateUser(ctx, req)
+	return err
+}
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+type Service struct {
+	userRepo core.UserRepository
+}
+
+func NewService(userRepo core.UserRepository) *Service {
+	return &Service{
+		userRepo: userRepo,
+	}
+}
+
+func (s *Service) CreateUser(ctx context.Context, req *core.CreateUserRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	return s.userRepo.Store(ctx, req.User)
+}
+
+func (s *Service) GetUser(ctx context.Context, req *core.GetUserRequest) (*core.User, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.FindByID(ctx, req.ID)
+}
+
+func (s *Service) ListUsers(ctx context.Context, req *core.ListUsersRequest) (*core.UserPage, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := req.Filter()
+	users, err := s.userRepo.FindByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &core.UserPage{Users: users}
+	if pageSize := filter.Limit - 1; len(users) > pageSize {
+		page.Users = users[:pageSize]
+		page.NextPageToken = core.PageToken(filter.Offset + pageSize)
+	}
+	return page, nil
+}
+
+// DeactivateUser keeps the user on the events already attended, only new invitations are rejected.
+// Users can only deactivate themselves.
+func (s *Service) DeactivateUser(ctx context.Context, req *core.DeactivateUserRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	if req.ActorID != strconv.Itoa(int(req.ID)) {
+		return internal.WrapErr(internal.ErrPermissionDenied, "the user can only deactivate themself")
+	}
+
+	return s.userRepo.Deactivate(ctx, req.ID, time.Now())
+}
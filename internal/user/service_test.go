@@ -0,0 +1,156 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/user"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_CreateUser(t *testing.T) {
+	tests := []struct {
+		name         string
+		userRepoMock func(ctrl *gomock.Controller) core.UserRepository
+		req          *core.CreateUserRequest
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			userRepoMock: func(ctrl *gomock.Controller) core.UserRepository {
+				repo := mock.NewMockUserRepository(ctrl)
+				repo.EXPECT().Store(gomock.Any(), &core.User{Name: "Jane", Timezone: "Asia/Jakarta"}).Times(1).Return(nil)
+				return repo
+			},
+			req: &core.CreateUserRequest{User: &core.User{Name: "Jane", Timezone: "Asia/Jakarta"}},
+		},
+		{
+			name: "Not OK - missing name",
+			userRepoMock: func(ctrl *gomock.Controller) core.UserRepository {
+				return mock.NewMockUserRepository(ctrl)
+			},
+			req:     &core.CreateUserRequest{User: &core.User{Timezone: "Asia/Jakarta"}},
+			wantErr: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - invalid timezone",
+			userRepoMock: func(ctrl *gomock.Controller) core.UserRepository {
+				return mock.NewMockUserRepository(ctrl)
+			},
+			req:     &core.CreateUserRequest{User: &core.User{Name: "Jane", Timezone: "Mars/Olympus"}},
+			wantErr: internal.ErrInvalidTimezone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := user.NewService(tt.userRepoMock(ctrl))
+			err := s.CreateUser(t.Context(), tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_GetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockUserRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), int32(2)).Times(1).Return(&core.User{ID: 2, Name: "Bar", Timezone: "UTC"}, nil)
+
+	s := user.NewService(repo)
+	got, err := s.GetUser(t.Context(), &core.GetUserRequest{ID: 2})
+	require.NoError(t, err)
+	assert.Equal(t, &core.User{ID: 2, Name: "Bar", Timezone: "UTC"}, got)
+
+	_, err = s.GetUser(t.Context(), &core.GetUserRequest{})
+	assert.ErrorIs(t, err, internal.ErrValidationFailed)
+}
+
+func TestService_ListUsers(t *testing.T) {
+	users := []core.User{{ID: 1, Name: "Foo"}, {ID: 2, Name: "Bar"}, {ID: 3, Name: "Jack"}}
+
+	tests := []struct {
+		name         string
+		userRepoMock func(ctrl *gomock.Controller) core.UserRepository
+		req          *core.ListUsersRequest
+		want         *core.UserPage
+		wantErr      error
+	}{
+		{
+			name: "OK - first page",
+			userRepoMock: func(ctrl *gomock.Controller) core.UserRepository {
+				repo := mock.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindByFilter(gomock.Any(), core.UserFilter{Limit: 3}).Times(1).Return(users, nil)
+				return repo
+			},
+			req:  &core.ListUsersRequest{PageSize: 2},
+			want: &core.UserPage{Users: users[:2], NextPageToken: core.PageToken(2)},
+		},
+		{
+			name: "OK - last page with the deactivated users",
+			userRepoMock: func(ctrl *gomock.Controller) core.UserRepository {
+				repo := mock.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindByFilter(gomock.Any(), core.UserFilter{IncludeDeactivated: true, Limit: 3, Offset: 2}).Times(1).Return(users[2:], nil)
+				return repo
+			},
+			req:  &core.ListUsersRequest{IncludeDeactivated: true, PageSize: 2, PageToken: core.PageToken(2)},
+			want: &core.UserPage{Users: users[2:]},
+		},
+		{
+			name: "Not OK - invalid page token",
+			userRepoMock: func(ctrl *gomock.Controller) core.UserRepository {
+				return mock.NewMockUserRepository(ctrl)
+			},
+			req:     &core.ListUsersRequest{PageToken: "!"},
+			wantErr: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := user.NewService(tt.userRepoMock(ctrl))
+			got, err := s.ListUsers(t.Context(), tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_DeactivateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockUserRepository(ctrl)
+	repo.EXPECT().Deactivate(gomock.Any(), int32(2), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _ int32, at time.Time) error {
+			assert.WithinDuration(t, time.Now(), at, time.Minute)
+			return nil
+		})
+	repo.EXPECT().Deactivate(gomock.Any(), int32(404), gomock.Any()).Times(1).
+		Return(internal.WrapErr(internal.ErrNotFound, "user 404"))
+
+	s := user.NewService(repo)
+	assert.NoError(t, s.DeactivateUser(t.Context(), &core.DeactivateUserRequest{ActorID: "2", ID: 2}))
+	assert.ErrorIs(t, s.DeactivateUser(t.Context(), &core.DeactivateUserRequest{ActorID: "404", ID: 404}), internal.ErrNotFound)
+	assert.ErrorIs(t, s.DeactivateUser(t.Context(), &core.DeactivateUserRequest{ActorID: "1", ID: 2}), internal.ErrPermissionDenied)
+	assert.ErrorIs(t, s.DeactivateUser(t.Context(), &core.DeactivateUserRequest{ID: 2}), internal.ErrValidationFailed)
+}
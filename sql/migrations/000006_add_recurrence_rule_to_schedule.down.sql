@@ -0,0 +1 @@
+ALTER TABLE "schedule" DROP COLUMN IF EXISTS "recurrence_rule";
\ No newline at end of file
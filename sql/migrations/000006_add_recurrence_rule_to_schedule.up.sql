@@ -0,0 +1,4 @@
+ALTER TABLE "schedule" ADD COLUMN IF NOT EXISTS "recurrence_rule" TEXT NOT NULL DEFAULT '';
+
+UPDATE "schedule" SET "recurrence_rule" = 'FREQ=DAILY' WHERE "recurring_type" = 'DAILY';
+UPDATE "schedule" SET "recurrence_rule" = 'FREQ=WEEKLY' WHERE "recurring_type" = 'WEEK';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "occurrence_exception";
\ No newline at end of file
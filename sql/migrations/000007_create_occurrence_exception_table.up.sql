@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "occurrence_exception"(
+    "schedule_id" VARCHAR(50) NOT NULL,
+    "original_start" BIGINT NOT NULL,
+    "is_cancelled" BOOLEAN NOT NULL DEFAULT FALSE,
+    "start_time" BIGINT NOT NULL DEFAULT 0,
+    "duration" BIGINT NOT NULL DEFAULT 0,
+    "title" VARCHAR(200) NOT NULL DEFAULT '',
+    "description" TEXT NOT NULL DEFAULT '',
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("schedule_id", "original_start"),
+    CONSTRAINT "fk_schedule" FOREIGN KEY ("schedule_id") REFERENCES schedule("id") ON DELETE CASCADE
+);
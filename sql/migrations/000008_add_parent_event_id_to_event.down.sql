@@ -0,0 +1 @@
+ALTER TABLE "event" DROP COLUMN IF EXISTS "parent_event_id";
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "event" ADD COLUMN IF NOT EXISTS "parent_event_id" VARCHAR(50) NULL
+    CONSTRAINT "fk_parent_event" REFERENCES event("id") ON DELETE SET NULL;
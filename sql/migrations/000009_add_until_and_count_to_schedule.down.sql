@@ -0,0 +1,2 @@
+ALTER TABLE "schedule" DROP COLUMN IF EXISTS "count";
+ALTER TABLE "schedule" DROP COLUMN IF EXISTS "until";
\ No newline at end of file
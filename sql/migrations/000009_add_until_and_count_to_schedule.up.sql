@@ -0,0 +1,7 @@
+ALTER TABLE "schedule" ADD COLUMN IF NOT EXISTS "until" BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE "schedule" ADD COLUMN IF NOT EXISTS "count" INTEGER NOT NULL DEFAULT 0;
+
+UPDATE "schedule" SET "count" = substring("recurrence_rule" from 'COUNT=([0-9]+)')::INTEGER
+WHERE "recurrence_rule" ~ 'COUNT=[0-9]+';
+UPDATE "schedule" SET "until" = EXTRACT(EPOCH FROM to_timestamp(substring("recurrence_rule" from 'UNTIL=([0-9]{8}T[0-9]{6})'), 'YYYYMMDD"T"HH24MISS') AT TIME ZONE 'UTC')::BIGINT
+WHERE "recurrence_rule" ~ 'UNTIL=[0-9]{8}T[0-9]{6}';
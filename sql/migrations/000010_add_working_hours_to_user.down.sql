@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "working_hours";
+ALTER TABLE "user" DROP COLUMN IF EXISTS "timezone";
\ No newline at end of file
@@ -0,0 +1,11 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "timezone" VARCHAR(50) NOT NULL DEFAULT 'UTC';
+
+CREATE TABLE IF NOT EXISTS "working_hours"(
+    "user_id" INTEGER NOT NULL,
+    "weekday" SMALLINT NOT NULL CHECK ("weekday" BETWEEN 0 AND 6),
+    "start_minute" SMALLINT NOT NULL,
+    "end_minute" SMALLINT NOT NULL,
+    PRIMARY KEY ("user_id", "weekday"),
+    CONSTRAINT "chk_working_hours" CHECK ("start_minute" >= 0 AND "start_minute" < "end_minute" AND "end_minute" <= 1440),
+    CONSTRAINT "fk_user" FOREIGN KEY ("user_id") REFERENCES "user"("id") ON DELETE CASCADE
+);
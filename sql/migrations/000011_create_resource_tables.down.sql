@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "reservation";
+DROP TABLE IF EXISTS "event_resource";
+DROP TABLE IF EXISTS "resource";
\ No newline at end of file
@@ -0,0 +1,32 @@
+-- Allows the equality of resource_id in the exclusion constraint of reservation.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE IF NOT EXISTS "resource"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL,
+    "capacity" INTEGER NOT NULL DEFAULT 0,
+    "location" VARCHAR(200) NOT NULL DEFAULT '',
+    "timezone" VARCHAR(50) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "event_resource"(
+    "event_id" VARCHAR(50) NOT NULL,
+    "resource_id" VARCHAR(50) NOT NULL,
+    PRIMARY KEY ("event_id", "resource_id"),
+    CONSTRAINT "fk_event" FOREIGN KEY ("event_id") REFERENCES event("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_resource" FOREIGN KEY ("resource_id") REFERENCES resource("id")
+);
+
+-- A reservation holds a resource during an occurrence, in unix seconds. Overlapping reservations
+-- of the same resource are rejected by the database, whatever the transactions racing for it.
+CREATE TABLE IF NOT EXISTS "reservation"(
+    "resource_id" VARCHAR(50) NOT NULL,
+    "event_id" VARCHAR(50) NOT NULL,
+    "schedule_id" VARCHAR(50) NOT NULL,
+    "original_start" BIGINT NOT NULL,
+    "period" INT8RANGE NOT NULL,
+    PRIMARY KEY ("resource_id", "schedule_id", "original_start"),
+    CONSTRAINT "fk_event_resource" FOREIGN KEY ("event_id", "resource_id") REFERENCES event_resource("event_id", "resource_id") ON DELETE CASCADE,
+    CONSTRAINT "fk_schedule" FOREIGN KEY ("schedule_id") REFERENCES schedule("id") ON DELETE CASCADE,
+    CONSTRAINT "excl_reservation_period" EXCLUDE USING gist ("resource_id" WITH =, "period" WITH &&)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE "user" DROP COLUMN IF EXISTS "default_buffer_after";
+ALTER TABLE "user" DROP COLUMN IF EXISTS "default_buffer_before";
+
+ALTER TABLE "schedule" DROP COLUMN IF EXISTS "buffer_after";
+ALTER TABLE "schedule" DROP COLUMN IF EXISTS "buffer_before";
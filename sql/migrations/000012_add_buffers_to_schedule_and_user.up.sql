@@ -0,0 +1,5 @@
+ALTER TABLE "schedule" ADD COLUMN IF NOT EXISTS "buffer_before" BIGINT;
+ALTER TABLE "schedule" ADD COLUMN IF NOT EXISTS "buffer_after" BIGINT;
+
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "default_buffer_before" BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "default_buffer_after" BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,7 @@
+ALTER TABLE "event" DROP COLUMN IF EXISTS "skip_holidays";
+ALTER TABLE "event" DROP COLUMN IF EXISTS "holiday_calendar_id";
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "holiday_calendar_id";
+
+DROP TABLE IF EXISTS "holiday";
+DROP TABLE IF EXISTS "holiday_calendar";
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "holiday_calendar"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "holiday"(
+    "calendar_id" VARCHAR(50) NOT NULL,
+    "date" DATE NOT NULL,
+    "name" VARCHAR(200) NOT NULL DEFAULT '',
+    PRIMARY KEY ("calendar_id", "date"),
+    CONSTRAINT "fk_holiday_calendar" FOREIGN KEY ("calendar_id") REFERENCES holiday_calendar("id") ON DELETE CASCADE
+);
+
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "holiday_calendar_id" VARCHAR(50) REFERENCES holiday_calendar("id") ON DELETE SET NULL;
+
+ALTER TABLE "event" ADD COLUMN IF NOT EXISTS "holiday_calendar_id" VARCHAR(50) REFERENCES holiday_calendar("id") ON DELETE SET NULL;
+ALTER TABLE "event" ADD COLUMN IF NOT EXISTS "skip_holidays" BOOLEAN NOT NULL DEFAULT FALSE;
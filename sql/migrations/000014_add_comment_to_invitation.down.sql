@@ -0,0 +1 @@
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "comment";
@@ -0,0 +1 @@
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "comment" TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE "invitation" RENAME COLUMN "token_hash" TO "token";
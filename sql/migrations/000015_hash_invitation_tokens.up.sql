@@ -0,0 +1,4 @@
+ALTER TABLE "invitation" RENAME COLUMN "token" TO "token_hash";
+
+-- The former tokens were derived from the invitation id, they are revoked rather than hashed.
+UPDATE "invitation" SET "token_hash" = 'revoked:' || "id";
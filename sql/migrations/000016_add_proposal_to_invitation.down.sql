@@ -0,0 +1,3 @@
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "proposed_end_time";
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "proposed_start_time";
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "proposed_schedule_id";
@@ -0,0 +1,3 @@
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "proposed_schedule_id" VARCHAR(50) NULL;
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "proposed_start_time" BIGINT NULL;
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "proposed_end_time" BIGINT NULL;
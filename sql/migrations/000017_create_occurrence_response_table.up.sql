@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "occurrence_response"(
+    "invitation_id" VARCHAR(50) NOT NULL,
+    "schedule_id" VARCHAR(50) NOT NULL,
+    "original_start" BIGINT NOT NULL,
+    "status" SMALLINT NOT NULL,
+    "comment" TEXT NOT NULL DEFAULT '',
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("invitation_id", "schedule_id", "original_start"),
+    CONSTRAINT "fk_invitation" FOREIGN KEY ("invitation_id") REFERENCES invitation("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_schedule" FOREIGN KEY ("schedule_id") REFERENCES schedule("id") ON DELETE CASCADE
+);
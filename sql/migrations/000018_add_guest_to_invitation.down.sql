@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "invitation_event_id_guest_email_key";
+
+ALTER TABLE "invitation" DROP CONSTRAINT IF EXISTS "invitation_user_or_guest";
+DELETE FROM "invitation" WHERE "user_id" IS NULL;
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "guest_name";
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "guest_email";
+ALTER TABLE "invitation" ALTER COLUMN "user_id" SET NOT NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE "invitation" ALTER COLUMN "user_id" DROP NOT NULL;
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "guest_email" VARCHAR(254) NULL;
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "guest_name" VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE "invitation" ADD CONSTRAINT "invitation_user_or_guest" CHECK (("user_id" IS NULL) <> ("guest_email" IS NULL));
+
+CREATE UNIQUE INDEX IF NOT EXISTS "invitation_event_id_guest_email_key" ON "invitation" ("event_id", "guest_email") WHERE "guest_email" IS NOT NULL;
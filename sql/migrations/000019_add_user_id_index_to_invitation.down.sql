@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "invitation_user_id_idx";
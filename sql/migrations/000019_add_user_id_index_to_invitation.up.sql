@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS "invitation_user_id_idx" ON "invitation" ("user_id", "status");
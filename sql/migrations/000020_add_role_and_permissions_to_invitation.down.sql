@@ -0,0 +1,4 @@
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "can_see_guest_list";
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "can_invite_others";
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "can_modify_event";
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "role";
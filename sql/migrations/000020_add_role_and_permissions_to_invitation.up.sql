@@ -0,0 +1,4 @@
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "role" SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "can_modify_event" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "can_invite_others" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "can_see_guest_list" BOOLEAN NOT NULL DEFAULT TRUE;
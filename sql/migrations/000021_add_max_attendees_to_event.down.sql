@@ -0,0 +1,2 @@
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "waitlisted_at";
+ALTER TABLE "event" DROP COLUMN IF EXISTS "max_attendees";
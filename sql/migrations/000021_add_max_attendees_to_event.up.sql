@@ -0,0 +1,2 @@
+ALTER TABLE "event" ADD COLUMN IF NOT EXISTS "max_attendees" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "waitlisted_at" TIMESTAMP NULL;
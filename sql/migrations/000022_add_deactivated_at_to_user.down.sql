@@ -0,0 +1,2 @@
+ALTER TABLE "invitation" DROP CONSTRAINT IF EXISTS "fk_invitation_user";
+ALTER TABLE "user" DROP COLUMN IF EXISTS "deactivated_at";
@@ -0,0 +1,5 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "deactivated_at" TIMESTAMP NULL;
+-- The seeded users were inserted with their IDs, leaving the sequence behind them.
+SELECT setval(pg_get_serial_sequence('"user"', 'id'), COALESCE(MAX("id"), 1)) FROM "user";
+-- NOT VALID leaves the existing invitations unchecked, the new ones must invite a known user.
+ALTER TABLE "invitation" ADD CONSTRAINT "fk_invitation_user" FOREIGN KEY ("user_id") REFERENCES "user"("id") ON DELETE CASCADE NOT VALID;
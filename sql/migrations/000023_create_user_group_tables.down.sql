@@ -0,0 +1,6 @@
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "group_id";
+
+DROP TABLE IF EXISTS "event_group";
+DROP TABLE IF EXISTS "user_group_subgroup";
+DROP TABLE IF EXISTS "user_group_member";
+DROP TABLE IF EXISTS "user_group";
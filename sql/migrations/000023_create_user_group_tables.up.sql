@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS "user_group"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL,
+    "created_by" VARCHAR(50) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "user_group_member"(
+    "group_id" VARCHAR(50) NOT NULL,
+    "user_id" INTEGER NOT NULL,
+    PRIMARY KEY ("group_id", "user_id"),
+    CONSTRAINT "fk_user_group" FOREIGN KEY ("group_id") REFERENCES user_group("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_user" FOREIGN KEY ("user_id") REFERENCES "user"("id") ON DELETE CASCADE
+);
+
+-- A group nested in another one, its members being members of the parent group as well. The
+-- cycles are rejected before storing the subgroups.
+CREATE TABLE IF NOT EXISTS "user_group_subgroup"(
+    "group_id" VARCHAR(50) NOT NULL,
+    "subgroup_id" VARCHAR(50) NOT NULL,
+    PRIMARY KEY ("group_id", "subgroup_id"),
+    CONSTRAINT "fk_user_group" FOREIGN KEY ("group_id") REFERENCES user_group("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_subgroup" FOREIGN KEY ("subgroup_id") REFERENCES user_group("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_not_self" CHECK ("group_id" <> "subgroup_id")
+);
+
+CREATE TABLE IF NOT EXISTS "event_group"(
+    "event_id" VARCHAR(50) NOT NULL,
+    "group_id" VARCHAR(50) NOT NULL,
+    "sync" BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY ("event_id", "group_id"),
+    CONSTRAINT "fk_event" FOREIGN KEY ("event_id") REFERENCES event("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_user_group" FOREIGN KEY ("group_id") REFERENCES user_group("id")
+);
+
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "group_id" VARCHAR(50) REFERENCES user_group("id") ON DELETE SET NULL;
@@ -7,10 +7,14 @@ INSERT INTO
         timezone,
         created_by,
         created_at,
-        updated_at
+        updated_at,
+        parent_event_id,
+        holiday_calendar_id,
+        skip_holidays,
+        max_attendees
     )
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7);
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
 
 -- name: CreateSchedule :exec
 INSERT INTO
@@ -21,16 +25,40 @@ INSERT INTO
         "duration",
         is_full_day,
         recurring_interval,
-        recurring_type
+        recurring_type,
+        recurrence_rule,
+        "until",
+        "count",
+        buffer_before,
+        buffer_after
     )
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7);
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
 
 -- name: CreateInvitation :exec
 INSERT INTO
-    invitation (id, event_id, user_id, token, status)
+    invitation (
+        id,
+        event_id,
+        user_id,
+        token_hash,
+        status,
+        comment,
+        updated_at,
+        proposed_schedule_id,
+        proposed_start_time,
+        proposed_end_time,
+        guest_email,
+        guest_name,
+        role,
+        can_modify_event,
+        can_invite_others,
+        can_see_guest_list,
+        waitlisted_at,
+        group_id
+    )
 VALUES
-    ($1, $2, $3, $4, $5);
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18);
 
 -- name: DeleteEvent :exec
 DELETE FROM
@@ -49,7 +77,12 @@ SET
     title = $1,
     description = $2,
     timezone = $3,
-    updated_at = $4;
+    updated_at = $4,
+    holiday_calendar_id = $6,
+    skip_holidays = $7,
+    max_attendees = $8
+WHERE
+    id = $5;
 
 -- name: UpsertSchedule :exec
 INSERT INTO
@@ -60,27 +93,70 @@ INSERT INTO
         "duration",
         is_full_day,
         recurring_interval,
-        recurring_type
+        recurring_type,
+        recurrence_rule,
+        "until",
+        "count",
+        buffer_before,
+        buffer_after
     )
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (id, event_id) DO
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (id) DO
 UPDATE
 SET
     start_time = $3,
     "duration" = $4,
-    recurring_interval = $5,
-    recurring_type = $6;
+    is_full_day = $5,
+    recurring_interval = $6,
+    recurring_type = $7,
+    recurrence_rule = $8,
+    "until" = $9,
+    "count" = $10,
+    buffer_before = $11,
+    buffer_after = $12;
 
 -- name: UpsertInvitation :exec
 INSERT INTO
-    invitation (id, event_id, user_id, token, status)
+    invitation (
+        id,
+        event_id,
+        user_id,
+        token_hash,
+        status,
+        comment,
+        updated_at,
+        proposed_schedule_id,
+        proposed_start_time,
+        proposed_end_time,
+        guest_email,
+        guest_name,
+        role,
+        can_modify_event,
+        can_invite_others,
+        can_see_guest_list,
+        waitlisted_at,
+        group_id
+    )
 VALUES
-    ($1, $2, $3, $4, $5) ON CONFLICT (id, event_id) DO
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) ON CONFLICT (id) DO
 UPDATE
 SET
     user_id = $3,
-    token = $4,
-    status = $5;
+    token_hash = $4,
+    status = $5,
+    comment = $6,
+    updated_at = $7,
+    proposed_schedule_id = $8,
+    proposed_start_time = $9,
+    proposed_end_time = $10,
+    guest_email = $11,
+    guest_name = $12,
+    role = $13,
+    can_modify_event = $14,
+    can_invite_others = $15,
+    can_see_guest_list = $16,
+    waitlisted_at = $17,
+    group_id = $18;
 
 -- name: FindEventByID :one
 SELECT
@@ -106,4 +182,477 @@ SELECT
 FROM
     invitation
 WHERE
-    event_id = $1;
\ No newline at end of file
+    event_id = $1;
+
+-- name: FindScheduleIDsByEventID :many
+SELECT
+    id
+FROM
+    schedule
+WHERE
+    event_id = $1;
+
+-- name: DeleteSchedule :exec
+DELETE FROM
+    schedule
+WHERE
+    id = $1;
+
+-- name: FindInvitationIDsByEventID :many
+SELECT
+    id
+FROM
+    invitation
+WHERE
+    event_id = $1;
+
+-- name: DeleteInvitation :exec
+DELETE FROM
+    invitation
+WHERE
+    id = $1;
+
+-- name: UpsertOccurrenceException :exec
+INSERT INTO
+    occurrence_exception (
+        schedule_id,
+        original_start,
+        is_cancelled,
+        start_time,
+        "duration",
+        title,
+        description,
+        updated_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (schedule_id, original_start) DO
+UPDATE
+SET
+    is_cancelled = $3,
+    start_time = $4,
+    "duration" = $5,
+    title = $6,
+    description = $7,
+    updated_at = $8;
+
+-- name: DeleteOccurrenceExceptionsByScheduleID :exec
+DELETE FROM
+    occurrence_exception
+WHERE
+    schedule_id = $1;
+
+-- name: UpdateUserAvailability :execrows
+UPDATE
+    "user"
+SET
+    timezone = $2,
+    default_buffer_before = $3,
+    default_buffer_after = $4,
+    holiday_calendar_id = $5
+WHERE
+    id = $1;
+
+-- name: DeleteWorkingHoursByUserID :exec
+DELETE FROM
+    working_hours
+WHERE
+    user_id = $1;
+
+-- name: CreateWorkingHours :exec
+INSERT INTO
+    working_hours (user_id, weekday, start_minute, end_minute)
+VALUES
+    ($1, $2, $3, $4);
+
+-- name: CreateResource :exec
+INSERT INTO
+    resource (id, name, capacity, location, timezone)
+VALUES
+    ($1, $2, $3, $4, $5);
+
+-- name: FindResourceByID :one
+SELECT
+    *
+FROM
+    resource
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: FindAllResources :many
+SELECT
+    *
+FROM
+    resource
+ORDER BY
+    name;
+
+-- name: DeleteEventResources :exec
+DELETE FROM
+    event_resource
+WHERE
+    event_id = $1;
+
+-- name: CreateEventResource :exec
+INSERT INTO
+    event_resource (event_id, resource_id)
+VALUES
+    ($1, $2);
+
+-- name: FindResourceIDsByEventID :many
+SELECT
+    resource_id
+FROM
+    event_resource
+WHERE
+    event_id = $1
+ORDER BY
+    resource_id;
+
+-- name: CreateReservation :exec
+INSERT INTO
+    reservation (resource_id, event_id, schedule_id, original_start, period)
+VALUES
+    (
+        @resource_id,
+        @event_id,
+        @schedule_id,
+        @original_start,
+        int8range(@start_time::BIGINT, @end_time::BIGINT)
+    );
+
+-- name: DeleteOccurrenceReservations :exec
+DELETE FROM
+    reservation
+WHERE
+    schedule_id = $1
+    AND original_start = $2;
+
+-- name: FindResourceIDsByScheduleID :many
+SELECT
+    er.resource_id
+FROM
+    event_resource er
+    JOIN schedule sch ON sch.event_id = er.event_id
+WHERE
+    sch.id = $1
+ORDER BY
+    er.resource_id;
+
+-- name: UpsertOccurrenceReservation :exec
+INSERT INTO
+    reservation (resource_id, event_id, schedule_id, original_start, period)
+SELECT
+    sqlc.arg(resource_id)::VARCHAR,
+    sch.event_id,
+    sch.id,
+    sqlc.arg(original_start)::BIGINT,
+    int8range(
+        sqlc.arg(start_time)::BIGINT,
+        sqlc.arg(start_time)::BIGINT + COALESCE(NULLIF(sqlc.arg(duration)::BIGINT, 0), sch.duration) * 60
+    )
+FROM
+    schedule sch
+WHERE
+    sch.id = sqlc.arg(schedule_id)::VARCHAR ON CONFLICT (resource_id, schedule_id, original_start) DO
+UPDATE
+SET
+    period = EXCLUDED.period;
+
+-- name: CreateHolidayCalendar :exec
+INSERT INTO
+    holiday_calendar (id, name)
+VALUES
+    ($1, $2);
+
+-- name: CreateHoliday :exec
+INSERT INTO
+    holiday (calendar_id, "date", name)
+VALUES
+    ($1, $2, $3);
+
+-- name: FindHolidayCalendarByID :one
+SELECT
+    *
+FROM
+    holiday_calendar
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: FindHolidaysByCalendarID :many
+SELECT
+    *
+FROM
+    holiday
+WHERE
+    calendar_id = $1
+ORDER BY
+    "date";
+
+-- name: FindInvitationByTokenHash :one
+SELECT
+    *
+FROM
+    invitation
+WHERE
+    token_hash = $1
+LIMIT
+    1;
+
+-- name: UpdateInvitationResponse :execrows
+UPDATE
+    invitation
+SET
+    status = $2,
+    comment = $3,
+    updated_at = $4,
+    proposed_schedule_id = $5,
+    proposed_start_time = $6,
+    proposed_end_time = $7,
+    waitlisted_at = $8
+WHERE
+    id = $1;
+
+-- name: LockEventCapacity :one
+SELECT
+    max_attendees
+FROM
+    event
+WHERE
+    id = $1 FOR UPDATE;
+
+-- name: UpdateInvitationTokenHash :execrows
+UPDATE
+    invitation
+SET
+    token_hash = $2
+WHERE
+    id = $1;
+
+-- name: UpsertOccurrenceResponse :exec
+INSERT INTO
+    occurrence_response (
+        invitation_id,
+        schedule_id,
+        original_start,
+        status,
+        comment,
+        updated_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6) ON CONFLICT (invitation_id, schedule_id, original_start) DO
+UPDATE
+SET
+    status = $4,
+    comment = $5,
+    updated_at = $6;
+
+-- name: FindOccurrenceResponsesByEventID :many
+SELECT
+    r.*
+FROM
+    occurrence_response r
+    JOIN invitation i ON i.id = r.invitation_id
+WHERE
+    i.event_id = $1;
+
+-- name: DeleteOccurrenceResponsesByInvitationID :exec
+DELETE FROM
+    occurrence_response
+WHERE
+    invitation_id = $1;
+
+-- name: CreateUser :one
+INSERT INTO
+    "user" (name, timezone)
+VALUES
+    ($1, $2) RETURNING id;
+
+-- name: FindUserByID :one
+SELECT
+    id,
+    name,
+    timezone,
+    deactivated_at
+FROM
+    "user"
+WHERE
+    id = $1;
+
+-- name: FindUsers :many
+SELECT
+    id,
+    name,
+    timezone,
+    deactivated_at
+FROM
+    "user"
+WHERE
+    @include_deactivated::boolean OR deactivated_at IS NULL
+ORDER BY
+    id
+LIMIT
+    $1 OFFSET $2;
+
+-- name: DeactivateUser :execrows
+UPDATE
+    "user"
+SET
+    deactivated_at = COALESCE(deactivated_at, $2)
+WHERE
+    id = $1;
+
+-- name: CreateGroup :exec
+INSERT INTO
+    user_group (id, name, created_by)
+VALUES
+    ($1, $2, $3);
+
+-- name: FindGroupByID :one
+SELECT
+    *
+FROM
+    user_group
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: FindGroupMemberIDs :many
+SELECT
+    user_id
+FROM
+    user_group_member
+WHERE
+    group_id = $1
+ORDER BY
+    user_id;
+
+-- name: FindSubgroupIDs :many
+SELECT
+    subgroup_id
+FROM
+    user_group_subgroup
+WHERE
+    group_id = $1
+ORDER BY
+    subgroup_id;
+
+-- name: DeleteGroupMembers :exec
+DELETE FROM
+    user_group_member
+WHERE
+    group_id = $1;
+
+-- name: CreateGroupMember :exec
+INSERT INTO
+    user_group_member (group_id, user_id)
+VALUES
+    ($1, $2);
+
+-- name: DeleteSubgroups :exec
+DELETE FROM
+    user_group_subgroup
+WHERE
+    group_id = $1;
+
+-- name: CreateSubgroup :exec
+INSERT INTO
+    user_group_subgroup (group_id, subgroup_id)
+VALUES
+    ($1, $2);
+
+-- name: FindNestedMemberIDs :many
+WITH RECURSIVE nested(group_id) AS (
+    SELECT
+        @group_id::VARCHAR
+    UNION
+    SELECT
+        s.subgroup_id
+    FROM
+        user_group_subgroup s
+        JOIN nested n ON n.group_id = s.group_id
+)
+SELECT DISTINCT
+    m.user_id
+FROM
+    user_group_member m
+    JOIN nested n ON n.group_id = m.group_id
+ORDER BY
+    m.user_id;
+
+-- name: FindNestedSubgroupIDs :many
+WITH RECURSIVE nested(group_id) AS (
+    SELECT
+        subgroup_id
+    FROM
+        user_group_subgroup
+    WHERE
+        user_group_subgroup.group_id = $1
+    UNION
+    SELECT
+        s.subgroup_id
+    FROM
+        user_group_subgroup s
+        JOIN nested n ON n.group_id = s.group_id
+)
+SELECT
+    group_id::VARCHAR
+FROM
+    nested
+ORDER BY
+    group_id;
+
+-- name: FindSyncedEventIDs :many
+WITH RECURSIVE parents(group_id) AS (
+    SELECT
+        @group_id::VARCHAR
+    UNION
+    SELECT
+        s.group_id
+    FROM
+        user_group_subgroup s
+        JOIN parents p ON p.group_id = s.subgroup_id
+)
+SELECT DISTINCT
+    eg.event_id
+FROM
+    event_group eg
+    JOIN parents p ON p.group_id = eg.group_id
+WHERE
+    eg.sync
+ORDER BY
+    eg.event_id;
+
+-- name: DeleteEventGroups :exec
+DELETE FROM
+    event_group
+WHERE
+    event_id = $1;
+
+-- name: CreateEventGroup :exec
+INSERT INTO
+    event_group (event_id, group_id, sync)
+VALUES
+    ($1, $2, $3);
+
+-- name: FindEventGroupsByEventID :many
+SELECT
+    group_id,
+    sync
+FROM
+    event_group
+WHERE
+    event_id = $1
+ORDER BY
+    group_id;
+
+-- name: DeletePendingInvitation :execrows
+DELETE FROM
+    invitation
+WHERE
+    id = $1
+    AND status = $2;